@@ -0,0 +1,34 @@
+package purge
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/env"
+	"github.com/cesc1802/share-module/config"
+	"github.com/cesc1802/share-module/system"
+	"github.com/spf13/cobra"
+)
+
+var olderThan time.Duration
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently remove the requests, applicants and volunteers deleted before the retention period",
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		cfg, err := config.LoadAppConfig(".")
+		if err != nil {
+			return err
+		}
+		sys := system.New(cfg, cmd.Parent().Name())
+
+		return feature.PurgeDeleted(sys, time.Now().Add(-olderThan))
+	},
+}
+
+func RegisterPurge(root *cobra.Command) {
+	purgeCmd.Flags().DurationVar(&olderThan, "older-than", env.GetTrashRetention(),
+		"purge what was deleted longer ago than this, TRASH_RETENTION by default")
+	root.AddCommand(purgeCmd)
+}
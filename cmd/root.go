@@ -4,6 +4,7 @@ import (
 	"log"
 
 	migrate "github.com/cesc1802/onboarding-and-volunteer-service/cmd/migration"
+	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/purge"
 	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/server"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,7 @@ var rootCmd = &cobra.Command{
 func init() {
 	server.RegisterServer(rootCmd)
 	migrate.RegisterMigrate(rootCmd)
+	purge.RegisterPurge(rootCmd)
 }
 
 func Execute() {
@@ -16,8 +16,7 @@ func (Module) Startup(ctx context.Context, mono system.Service) (err error) {
 }
 
 func Root(ctx context.Context, mono system.Service) error {
-	feature.RegisterHandlerV1(mono)
-	return nil
+	return feature.RegisterHandlerV1(mono)
 }
 
 var serverCmd = &cobra.Command{
@@ -36,7 +35,8 @@ var serverCmd = &cobra.Command{
 		}
 
 		sys.Waiter().Add(
-			sys.WaitForWeb)
+			sys.WaitForWeb,
+			feature.NewMailWorker(sys).Run)
 
 		return sys.Waiter().Wait()
 	},
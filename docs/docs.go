@@ -1263,14 +1263,10 @@ const docTemplate = `{
         "dto.ApplicantRequestCreatingDTO": {
             "type": "object",
             "required": [
-                "status",
                 "type",
                 "user_id"
             ],
             "properties": {
-                "status": {
-                    "type": "integer"
-                },
                 "type": {
                     "type": "string"
                 },
@@ -1692,14 +1688,10 @@ const docTemplate = `{
         "dto.VoluteerRequestCreatingDTO": {
             "type": "object",
             "required": [
-                "status",
                 "type",
                 "user_id"
             ],
             "properties": {
-                "status": {
-                    "type": "integer"
-                },
                 "type": {
                     "type": "string"
                 },
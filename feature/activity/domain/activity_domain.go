@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	departmentDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+)
+
+var (
+	ErrActivityNotFound  = errors.New("activity not found")
+	ErrActivityStarted   = errors.New("activity has already started")
+	ErrOutsideDepartment = departmentDomain.ErrOutsideDepartment
+	ErrCapacityTooLow    = errors.New("capacity is lower than the number of confirmed volunteers")
+	ErrNotVolunteer      = errors.New("only active volunteers can sign up")
+	ErrPositionRequired  = errors.New("volunteer does not hold a position the activity requires")
+	ErrAlreadySignedUp   = errors.New("volunteer already signed up for this activity")
+	ErrSignupNotFound    = errors.New("sign-up not found")
+)
+
+// Sign-up statuses. Volunteers signing up for a full activity are waitlisted
+// and confirmed in order when a place frees up.
+const (
+	SignupConfirmed  = "confirmed"
+	SignupWaitlisted = "waitlisted"
+	SignupCancelled  = "cancelled"
+)
+
+// Activity is an event of a department volunteers sign up for. PositionIDs
+// are the positions required to sign up, any of them will do, and no
+// position is required when it is empty. SkillIDs are the skills the
+// activity needs, they rank volunteers when matching but do not restrict
+// sign-ups. Confirmed and Waitlisted count the sign-ups and are only filled
+// when reading.
+type Activity struct {
+	ID           int       `gorm:"primaryKey"`
+	Title        string    `gorm:"size:255;not null"`
+	Description  string    `gorm:"type:text"`
+	DepartmentID int       `gorm:"not null;index"`
+	Location     string    `gorm:"size:255;not null"`
+	StartsAt     time.Time `gorm:"not null;index"`
+	EndsAt       time.Time `gorm:"not null"`
+	Capacity     int       `gorm:"not null"`
+	CreatedBy    int       `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	PositionIDs  []int     `gorm:"-"`
+	SkillIDs     []int     `gorm:"-"`
+	Confirmed    int       `gorm:"->;-:migration"`
+	Waitlisted   int       `gorm:"->;-:migration"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (Activity) TableName() string {
+	return "activities"
+}
+
+// ActivityPosition is a position required to sign up for an activity.
+type ActivityPosition struct {
+	ActivityID int `gorm:"primaryKey"`
+	PositionID int `gorm:"primaryKey"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (ActivityPosition) TableName() string {
+	return "activity_positions"
+}
+
+// ActivitySkill is a skill an activity needs.
+type ActivitySkill struct {
+	ActivityID int `gorm:"primaryKey"`
+	SkillID    int `gorm:"primaryKey"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (ActivitySkill) TableName() string {
+	return "activity_skills"
+}
+
+// Signup is a volunteer signing up for an activity. A volunteer has at most
+// one sign-up that is not cancelled per activity. The volunteer fields are
+// only filled when listing the sign-ups of an activity.
+type Signup struct {
+	ID          int       `gorm:"primaryKey"`
+	ActivityID  int       `gorm:"not null;index"`
+	VolunteerID int       `gorm:"not null;index"`
+	Status      string    `gorm:"size:20;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CancelledAt *time.Time
+	UserID      int    `gorm:"->;-:migration"`
+	Name        string `gorm:"->;-:migration"`
+	Surname     string `gorm:"->;-:migration"`
+	Email       string `gorm:"->;-:migration"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (Signup) TableName() string {
+	return "activity_signups"
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Attendance actions an attendance token is issued for. The coordinator
+// shows one QR code to check in and another one to check out.
+const (
+	AttendanceCheckIn  = "check_in"
+	AttendanceCheckOut = "check_out"
+)
+
+var (
+	ErrInvalidAttendanceToken = errors.New("invalid or expired attendance token")
+	ErrAttendanceTokenUsed    = errors.New("attendance token was already used")
+	ErrNotConfirmed           = errors.New("volunteer is not confirmed for this activity")
+	ErrActivityEnded          = errors.New("activity has already ended")
+	ErrAlreadyCheckedIn       = errors.New("volunteer already checked in")
+	ErrNotCheckedIn           = errors.New("volunteer has not checked in")
+	ErrAlreadyCheckedOut      = errors.New("volunteer already checked out")
+)
+
+// Attendance records when a volunteer checked in and out of an activity, at
+// most once per activity. The volunteer fields are only filled when listing
+// the attendance of an activity.
+type Attendance struct {
+	ID           int       `gorm:"primaryKey"`
+	ActivityID   int       `gorm:"not null;uniqueIndex:idx_activity_attendances_volunteer"`
+	VolunteerID  int       `gorm:"not null;uniqueIndex:idx_activity_attendances_volunteer"`
+	CheckedInAt  time.Time `gorm:"not null"`
+	CheckedOutAt *time.Time
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	UserID       int       `gorm:"->;-:migration"`
+	Name         string    `gorm:"->;-:migration"`
+	Surname      string    `gorm:"->;-:migration"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (Attendance) TableName() string {
+	return "activity_attendances"
+}
+
+// AttendanceTokenUse records that a volunteer used an attendance token, so
+// the same token cannot be replayed by them.
+type AttendanceTokenUse struct {
+	TokenID     string    `gorm:"primaryKey;size:64"`
+	VolunteerID int       `gorm:"primaryKey"`
+	UsedAt      time.Time `gorm:"not null"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (AttendanceTokenUse) TableName() string {
+	return "attendance_token_uses"
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidFeedToken   = errors.New("invalid calendar feed token")
+	ErrFeedNotAllowed     = errors.New("calendar of this department is not shared with you")
+	ErrDepartmentNotFound = errors.New("department not found")
+)
+
+// CalendarFeed is the secret a user subscribes to their calendars with.
+// Calendar clients cannot send the access token, so the feeds are
+// authenticated by the feed token in their URL. Only its hash is stored.
+type CalendarFeed struct {
+	UserID    int       `gorm:"primaryKey;autoIncrement:false"`
+	TokenHash string    `gorm:"size:64;not null;unique"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+// CalendarEvent is an activity as exported to calendars. Status is the
+// sign-up status in the feed of a volunteer and empty in department feeds.
+type CalendarEvent struct {
+	ActivityID     int
+	Title          string
+	Description    string
+	Location       string
+	DepartmentName string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	UpdatedAt      time.Time
+	Status         string
+}
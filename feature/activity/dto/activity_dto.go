@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+// ActivityDTO is what an admin fills in to create or update an activity.
+// PositionIDs are the positions a volunteer needs, any of them, to sign up.
+// SkillIDs are the skills the activity needs, used to match volunteers.
+type ActivityDTO struct {
+	Title        string    `json:"title" binding:"required,max=255"`
+	Description  string    `json:"description"`
+	DepartmentID int       `json:"department_id" binding:"required"`
+	Location     string    `json:"location" binding:"required,max=255"`
+	StartsAt     time.Time `json:"starts_at" binding:"required"`
+	EndsAt       time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	Capacity     int       `json:"capacity" binding:"required,min=1"`
+	PositionIDs  []int     `json:"position_ids" binding:"dive,min=1"`
+	SkillIDs     []int     `json:"skill_ids" binding:"dive,min=1"`
+}
+
+type ActivityResponseDTO struct {
+	ID           int       `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	DepartmentID int       `json:"department_id"`
+	Location     string    `json:"location"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Capacity     int       `json:"capacity"`
+	PositionIDs  []int     `json:"position_ids"`
+	SkillIDs     []int     `json:"skill_ids"`
+	Confirmed    int       `json:"confirmed"`
+	Waitlisted   int       `json:"waitlisted"`
+	CreatedBy    int       `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ListActivityQuery filters activities by department and by start time.
+// Without From and To only the activities that have not ended are listed.
+type ListActivityQuery struct {
+	Page         int        `form:"page" binding:"omitempty,min=1"`
+	PageSize     int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+	DepartmentID *int       `form:"department_id"`
+	From         *time.Time `form:"from" time_format:"2006-01-02"`
+	To           *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+type ListActivityDTO struct {
+	Activities []ActivityResponseDTO `json:"activities"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	Total      int64                 `json:"total"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+type SignupDTO struct {
+	ID          int        `json:"id"`
+	ActivityID  int        `json:"activity_id"`
+	VolunteerID int        `json:"volunteer_id"`
+	UserID      int        `json:"user_id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Surname     string     `json:"surname,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
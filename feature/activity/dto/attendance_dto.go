@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type AttendanceQRQuery struct {
+	Action string `form:"action" binding:"required,oneof=check_in check_out"`
+}
+
+type ScanAttendanceDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type AttendanceDTO struct {
+	ID           int        `json:"id"`
+	ActivityID   int        `json:"activity_id"`
+	VolunteerID  int        `json:"volunteer_id"`
+	UserID       int        `json:"user_id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Surname      string     `json:"surname,omitempty"`
+	CheckedInAt  time.Time  `json:"checked_in_at"`
+	CheckedOutAt *time.Time `json:"checked_out_at,omitempty"`
+}
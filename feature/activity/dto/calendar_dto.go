@@ -0,0 +1,9 @@
+package dto
+
+// CalendarFeedDTO holds the URLs to subscribe to. The token is only shown
+// when the feed is created, creating it again replaces the old one.
+type CalendarFeedDTO struct {
+	Token         string `json:"token"`
+	VolunteerURL  string `json:"volunteer_url"`
+	DepartmentURL string `json:"department_url,omitempty"`
+}
@@ -0,0 +1,414 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// ActivityRepositoryInterface defines the methods that an ActivityRepository should implement
+type ActivityRepositoryInterface interface {
+	CreateActivity(activity *domain.Activity) error
+	UpdateActivity(activity *domain.Activity) error
+	DeleteActivity(id int) error
+	FindActivityByID(id int) (*domain.Activity, error)
+	ListActivities(query dto.ListActivityQuery) ([]*domain.Activity, int64, error)
+	ListSignups(activityID int) ([]*domain.Signup, error)
+	SignUp(activityID int, volunteerID int) (*domain.Signup, error)
+	CancelSignup(activityID int, volunteerID int) error
+	FindVolunteerID(userID int) (int, error)
+}
+
+type ActivityRepository struct {
+	DB *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) *ActivityRepository {
+	return &ActivityRepository{DB: db}
+}
+
+// activityColumns selects an activity with the number of confirmed and
+// waitlisted sign-ups.
+const activityColumns = "activities.*, " +
+	"(SELECT COUNT(*) FROM activity_signups WHERE activity_signups.activity_id = activities.id AND activity_signups.status = 'confirmed') AS confirmed, " +
+	"(SELECT COUNT(*) FROM activity_signups WHERE activity_signups.activity_id = activities.id AND activity_signups.status = 'waitlisted') AS waitlisted"
+
+// CreateActivity creates the activity with its required positions and skills.
+func (r *ActivityRepository) CreateActivity(activity *domain.Activity) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(activity).Error; err != nil {
+			return err
+		}
+		if err := setPositions(tx, activity.ID, activity.PositionIDs); err != nil {
+			return err
+		}
+		return setSkills(tx, activity.ID, activity.SkillIDs)
+	})
+}
+
+// UpdateActivity saves the activity and replaces its required positions and
+// skills. When
+// the capacity grows, waitlisted volunteers are confirmed in the order they
+// signed up.
+func (r *ActivityRepository) UpdateActivity(activity *domain.Activity) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockActivity(tx, activity.ID); err != nil {
+			return err
+		}
+		var confirmed int64
+		if err := tx.Model(&domain.Signup{}).
+			Where("activity_id = ? AND status = ?", activity.ID, domain.SignupConfirmed).
+			Count(&confirmed).Error; err != nil {
+			return err
+		}
+		if int64(activity.Capacity) < confirmed {
+			return domain.ErrCapacityTooLow
+		}
+		if err := tx.Omit("created_by", "created_at").Save(activity).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("activity_id = ?", activity.ID).Delete(&domain.ActivityPosition{}).Error; err != nil {
+			return err
+		}
+		if err := setPositions(tx, activity.ID, activity.PositionIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("activity_id = ?", activity.ID).Delete(&domain.ActivitySkill{}).Error; err != nil {
+			return err
+		}
+		if err := setSkills(tx, activity.ID, activity.SkillIDs); err != nil {
+			return err
+		}
+		return promoteWaitlist(tx, activity.ID, activity.Capacity-int(confirmed))
+	})
+}
+
+// DeleteActivity removes the activity with its sign-ups.
+func (r *ActivityRepository) DeleteActivity(id int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("activity_id = ?", id).Delete(&domain.Signup{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("activity_id = ?", id).Delete(&domain.Attendance{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("activity_id = ?", id).Delete(&domain.ActivityPosition{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("activity_id = ?", id).Delete(&domain.ActivitySkill{}).Error; err != nil {
+			return err
+		}
+		// hours logged for the activity stay with its department
+		if err := tx.Model(&volunteerDomain.HoursEntry{}).Where("activity_id = ?", id).Update("activity_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Activity{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrActivityNotFound
+		}
+		return nil
+	})
+}
+
+func (r *ActivityRepository) FindActivityByID(id int) (*domain.Activity, error) {
+	var activity domain.Activity
+	if err := r.DB.Select(activityColumns).First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrActivityNotFound
+		}
+		return nil, err
+	}
+	if err := r.loadRequirements([]*domain.Activity{&activity}); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// ListActivities returns one page of the activities matching the query,
+// soonest first, together with the number of matching activities. Page and
+// PageSize must be set.
+func (r *ActivityRepository) ListActivities(query dto.ListActivityQuery) ([]*domain.Activity, int64, error) {
+	db := r.DB.Model(&domain.Activity{})
+	if query.DepartmentID != nil {
+		db = db.Where("department_id = ?", *query.DepartmentID)
+	}
+	if query.From != nil {
+		db = db.Where("starts_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("starts_at < ?", query.To.AddDate(0, 0, 1))
+	}
+	if query.From == nil && query.To == nil {
+		db = db.Where("ends_at > ?", time.Now())
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	activities := make([]*domain.Activity, 0)
+	err := db.Select(activityColumns).
+		Order("starts_at").
+		Order("id").
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize).
+		Find(&activities).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadRequirements(activities); err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
+
+// ListSignups returns the sign-ups of the activity that are not cancelled
+// with the volunteer's name and email, the confirmed ones first and then the
+// waitlist in order.
+func (r *ActivityRepository) ListSignups(activityID int) ([]*domain.Signup, error) {
+	signups := make([]*domain.Signup, 0)
+	err := r.DB.Select("activity_signups.*, users.id AS user_id, users.name, users.surname, users.email").
+		Joins("JOIN volunteer_details ON volunteer_details.id = activity_signups.volunteer_id").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Where("activity_signups.activity_id = ? AND activity_signups.status <> ?", activityID, domain.SignupCancelled).
+		Order("CASE WHEN activity_signups.status = 'confirmed' THEN 0 ELSE 1 END").
+		Order("activity_signups.created_at").
+		Order("activity_signups.id").
+		Find(&signups).Error
+	return signups, err
+}
+
+// SignUp signs the volunteer up for the activity, on the waitlist when it is
+// full. Only active volunteers holding one of the required positions can
+// sign up, and only before the activity starts.
+func (r *ActivityRepository) SignUp(activityID int, volunteerID int) (*domain.Signup, error) {
+	signup := &domain.Signup{ActivityID: activityID, VolunteerID: volunteerID}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		activity, err := lockActivity(tx, activityID)
+		if err != nil {
+			return err
+		}
+		if !activity.StartsAt.After(time.Now()) {
+			return domain.ErrActivityStarted
+		}
+		var volunteer volunteerDomain.Volunteer
+		err = tx.Where("id = ? AND status = ?", volunteerID, volunteerDomain.VolunteerStatusActive).Take(&volunteer).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrNotVolunteer
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkPositions(tx, activityID, volunteerID); err != nil {
+			return err
+		}
+
+		var existing int64
+		if err := tx.Model(&domain.Signup{}).
+			Where("activity_id = ? AND volunteer_id = ? AND status <> ?", activityID, volunteerID, domain.SignupCancelled).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return domain.ErrAlreadySignedUp
+		}
+		var confirmed int64
+		if err := tx.Model(&domain.Signup{}).
+			Where("activity_id = ? AND status = ?", activityID, domain.SignupConfirmed).
+			Count(&confirmed).Error; err != nil {
+			return err
+		}
+		signup.Status = domain.SignupConfirmed
+		if confirmed >= int64(activity.Capacity) {
+			signup.Status = domain.SignupWaitlisted
+		}
+		return tx.Create(signup).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return signup, nil
+}
+
+// CancelSignup cancels the sign-up of the volunteer. The place of a confirmed
+// volunteer goes to the first volunteer on the waitlist.
+func (r *ActivityRepository) CancelSignup(activityID int, volunteerID int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockActivity(tx, activityID); err != nil {
+			return err
+		}
+		var signup domain.Signup
+		err := tx.Where("activity_id = ? AND volunteer_id = ? AND status <> ?", activityID, volunteerID, domain.SignupCancelled).
+			Take(&signup).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrSignupNotFound
+		}
+		if err != nil {
+			return err
+		}
+		wasConfirmed := signup.Status == domain.SignupConfirmed
+		if err := tx.Model(&signup).Updates(map[string]interface{}{
+			"status":       domain.SignupCancelled,
+			"cancelled_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		if wasConfirmed {
+			return promoteWaitlist(tx, activityID, 1)
+		}
+		return nil
+	})
+}
+
+// FindVolunteerID returns the id of the volunteer record of the user.
+func (r *ActivityRepository) FindVolunteerID(userID int) (int, error) {
+	var volunteer volunteerDomain.Volunteer
+	err := r.DB.Select("id").Where("user_id = ?", userID).Take(&volunteer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, domain.ErrNotVolunteer
+	}
+	return volunteer.ID, err
+}
+
+// lockActivity loads the activity and locks it until the end of the
+// transaction, so concurrent sign-ups cannot exceed the capacity.
+func lockActivity(tx *gorm.DB, id int) (*domain.Activity, error) {
+	var activity domain.Activity
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrActivityNotFound
+		}
+		return nil, err
+	}
+	return &activity, nil
+}
+
+func setPositions(tx *gorm.DB, activityID int, positionIDs []int) error {
+	if len(positionIDs) == 0 {
+		return nil
+	}
+	seen := make(map[int]bool, len(positionIDs))
+	positions := make([]domain.ActivityPosition, 0, len(positionIDs))
+	for _, id := range positionIDs {
+		if !seen[id] {
+			seen[id] = true
+			positions = append(positions, domain.ActivityPosition{ActivityID: activityID, PositionID: id})
+		}
+	}
+	var found int64
+	if err := tx.Model(&roleDomain.Position{}).Where("id IN ?", positionIDs).Count(&found).Error; err != nil {
+		return err
+	}
+	if found != int64(len(positions)) {
+		return roleDomain.ErrPositionNotFound
+	}
+	return tx.Create(&positions).Error
+}
+
+func setSkills(tx *gorm.DB, activityID int, skillIDs []int) error {
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	seen := make(map[int]bool, len(skillIDs))
+	skills := make([]domain.ActivitySkill, 0, len(skillIDs))
+	for _, id := range skillIDs {
+		if !seen[id] {
+			seen[id] = true
+			skills = append(skills, domain.ActivitySkill{ActivityID: activityID, SkillID: id})
+		}
+	}
+	var found int64
+	if err := tx.Model(&volunteerDomain.Skill{}).Where("id IN ?", skillIDs).Count(&found).Error; err != nil {
+		return err
+	}
+	if found != int64(len(skills)) {
+		return volunteerDomain.ErrSkillNotFound
+	}
+	return tx.Create(&skills).Error
+}
+
+// checkPositions returns ErrPositionRequired unless the activity requires no
+// position or the volunteer currently holds one of them.
+func checkPositions(tx *gorm.DB, activityID int, volunteerID int) error {
+	var required int64
+	if err := tx.Model(&domain.ActivityPosition{}).Where("activity_id = ?", activityID).Count(&required).Error; err != nil {
+		return err
+	}
+	if required == 0 {
+		return nil
+	}
+	var held int64
+	if err := tx.Model(&volunteerDomain.PositionAssignment{}).
+		Where("volunteer_id = ? AND ended_at IS NULL", volunteerID).
+		Where("position_id IN (?)", tx.Model(&domain.ActivityPosition{}).Select("position_id").Where("activity_id = ?", activityID)).
+		Count(&held).Error; err != nil {
+		return err
+	}
+	if held == 0 {
+		return domain.ErrPositionRequired
+	}
+	return nil
+}
+
+// promoteWaitlist confirms up to places volunteers from the waitlist, in the
+// order they signed up.
+func promoteWaitlist(tx *gorm.DB, activityID int, places int) error {
+	if places <= 0 {
+		return nil
+	}
+	var ids []int
+	if err := tx.Model(&domain.Signup{}).
+		Where("activity_id = ? AND status = ?", activityID, domain.SignupWaitlisted).
+		Order("created_at").
+		Order("id").
+		Limit(places).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&domain.Signup{}).Where("id IN ?", ids).Update("status", domain.SignupConfirmed).Error
+}
+
+// loadRequirements fills the positions and skills the activities require.
+func (r *ActivityRepository) loadRequirements(activities []*domain.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+	byID := make(map[int]*domain.Activity, len(activities))
+	ids := make([]int, 0, len(activities))
+	for _, activity := range activities {
+		activity.PositionIDs = make([]int, 0)
+		activity.SkillIDs = make([]int, 0)
+		byID[activity.ID] = activity
+		ids = append(ids, activity.ID)
+	}
+	var positions []domain.ActivityPosition
+	if err := r.DB.Where("activity_id IN ?", ids).Order("position_id").Find(&positions).Error; err != nil {
+		return err
+	}
+	for _, position := range positions {
+		activity := byID[position.ActivityID]
+		activity.PositionIDs = append(activity.PositionIDs, position.PositionID)
+	}
+	var skills []domain.ActivitySkill
+	if err := r.DB.Where("activity_id IN ?", ids).Order("skill_id").Find(&skills).Error; err != nil {
+		return err
+	}
+	for _, skill := range skills {
+		activity := byID[skill.ActivityID]
+		activity.SkillIDs = append(activity.SkillIDs, skill.SkillID)
+	}
+	return nil
+}
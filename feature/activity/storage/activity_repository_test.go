@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+func setupActivityDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &authDomain.User{}, &volunteerDomain.Volunteer{}, &volunteerDomain.PositionAssignment{},
+		&roleDomain.Position{}, &domain.Activity{}, &domain.ActivityPosition{}, &domain.Signup{}, &domain.Attendance{}, &volunteerDomain.HoursEntry{},
+		&volunteerDomain.Skill{}, &domain.ActivitySkill{})
+	for i, name := range []string{"Anna", "Bob", "Carl", "Dan"} {
+		user := &authDomain.User{Email: name + "@example.com", Password: "hash", Name: name, Surname: "Smith", Gender: "other", Mobile: "123", Status: 1}
+		assert.NoError(t, db.Create(user).Error)
+		status := volunteerDomain.VolunteerStatusActive
+		if i == 3 {
+			status = volunteerDomain.VolunteerStatusInactive
+		}
+		assert.NoError(t, db.Create(&volunteerDomain.Volunteer{UserID: user.ID, DepartmentID: 1, Status: status}).Error)
+	}
+	assert.NoError(t, db.Create(&roleDomain.Position{Id: 1, Code: "CVL", Name: "Civil volunteer"}).Error)
+	assert.NoError(t, db.Create(&volunteerDomain.PositionAssignment{VolunteerID: 1, PositionID: 1, AssignedBy: 1, StartedAt: time.Now()}).Error)
+	return db
+}
+
+func newActivity(capacity int, positionIDs ...int) *domain.Activity {
+	start := time.Now().Add(24 * time.Hour)
+	return &domain.Activity{
+		Title:        "Beach cleanup",
+		DepartmentID: 1,
+		Location:     "Beach",
+		StartsAt:     start,
+		EndsAt:       start.Add(3 * time.Hour),
+		Capacity:     capacity,
+		CreatedBy:    1,
+		PositionIDs:  positionIDs,
+	}
+}
+
+func TestSignUp(t *testing.T) {
+	db := setupActivityDB(t)
+	repo := NewActivityRepository(db)
+	activity := newActivity(1)
+	assert.NoError(t, repo.CreateActivity(activity))
+
+	first, err := repo.SignUp(activity.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SignupConfirmed, first.Status)
+
+	second, err := repo.SignUp(activity.ID, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SignupWaitlisted, second.Status)
+
+	third, err := repo.SignUp(activity.ID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SignupWaitlisted, third.Status)
+
+	_, err = repo.SignUp(activity.ID, 1)
+	assert.ErrorIs(t, err, domain.ErrAlreadySignedUp)
+	_, err = repo.SignUp(activity.ID, 4)
+	assert.ErrorIs(t, err, domain.ErrNotVolunteer)
+
+	found, err := repo.FindActivityByID(activity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, found.Confirmed)
+	assert.Equal(t, 2, found.Waitlisted)
+
+	t.Run("cancelling frees the place for the waitlist", func(t *testing.T) {
+		assert.NoError(t, repo.CancelSignup(activity.ID, 1))
+		assert.ErrorIs(t, repo.CancelSignup(activity.ID, 1), domain.ErrSignupNotFound)
+
+		signups, err := repo.ListSignups(activity.ID)
+		assert.NoError(t, err)
+		if assert.Len(t, signups, 2) {
+			assert.Equal(t, 2, signups[0].VolunteerID)
+			assert.Equal(t, domain.SignupConfirmed, signups[0].Status)
+			assert.Equal(t, "Bob", signups[0].Name)
+			assert.Equal(t, domain.SignupWaitlisted, signups[1].Status)
+		}
+	})
+
+	t.Run("growing the capacity confirms the waitlist", func(t *testing.T) {
+		activity.Capacity = 3
+		assert.NoError(t, repo.UpdateActivity(activity))
+		found, err := repo.FindActivityByID(activity.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, found.Confirmed)
+		assert.Equal(t, 0, found.Waitlisted)
+		assert.Equal(t, 1, found.CreatedBy)
+	})
+
+	t.Run("capacity below confirmed", func(t *testing.T) {
+		activity.Capacity = 1
+		assert.ErrorIs(t, repo.UpdateActivity(activity), domain.ErrCapacityTooLow)
+	})
+
+	t.Run("signing up again after cancelling", func(t *testing.T) {
+		signup, err := repo.SignUp(activity.ID, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SignupConfirmed, signup.Status)
+	})
+}
+
+func TestSignUpRules(t *testing.T) {
+	db := setupActivityDB(t)
+	repo := NewActivityRepository(db)
+
+	t.Run("required position", func(t *testing.T) {
+		activity := newActivity(5, 1)
+		assert.NoError(t, repo.CreateActivity(activity))
+		_, err := repo.SignUp(activity.ID, 2)
+		assert.ErrorIs(t, err, domain.ErrPositionRequired)
+		_, err = repo.SignUp(activity.ID, 1)
+		assert.NoError(t, err)
+
+		found, err := repo.FindActivityByID(activity.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, found.PositionIDs)
+	})
+
+	t.Run("unknown position", func(t *testing.T) {
+		assert.ErrorIs(t, repo.CreateActivity(newActivity(5, 9)), roleDomain.ErrPositionNotFound)
+	})
+
+	t.Run("required skills", func(t *testing.T) {
+		assert.NoError(t, db.Create(&volunteerDomain.Skill{ID: 1, Name: "First aid"}).Error)
+		activity := newActivity(5)
+		activity.SkillIDs = []int{1, 1}
+		assert.NoError(t, repo.CreateActivity(activity))
+
+		found, err := repo.FindActivityByID(activity.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, found.SkillIDs)
+
+		activity.SkillIDs = []int{9}
+		assert.ErrorIs(t, repo.UpdateActivity(activity), volunteerDomain.ErrSkillNotFound)
+	})
+
+	t.Run("started", func(t *testing.T) {
+		activity := newActivity(5)
+		activity.StartsAt = time.Now().Add(-time.Hour)
+		assert.NoError(t, repo.CreateActivity(activity))
+		_, err := repo.SignUp(activity.ID, 1)
+		assert.ErrorIs(t, err, domain.ErrActivityStarted)
+	})
+
+	t.Run("unknown activity", func(t *testing.T) {
+		_, err := repo.SignUp(99, 1)
+		assert.ErrorIs(t, err, domain.ErrActivityNotFound)
+	})
+}
+
+func TestListActivities(t *testing.T) {
+	db := setupActivityDB(t)
+	repo := NewActivityRepository(db)
+
+	past := newActivity(5)
+	past.StartsAt = time.Now().AddDate(0, 0, -3)
+	past.EndsAt = past.StartsAt.Add(time.Hour)
+	later := newActivity(5)
+	later.StartsAt = later.StartsAt.AddDate(0, 0, 2)
+	later.EndsAt = later.StartsAt.Add(time.Hour)
+	other := newActivity(5)
+	other.DepartmentID = 2
+	for _, activity := range []*domain.Activity{past, later, other} {
+		assert.NoError(t, repo.CreateActivity(activity))
+	}
+
+	activities, total, err := repo.ListActivities(dto.ListActivityQuery{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	if assert.Len(t, activities, 2) {
+		assert.Equal(t, other.ID, activities[0].ID)
+		assert.Equal(t, later.ID, activities[1].ID)
+	}
+
+	department := 1
+	from := time.Now().AddDate(0, 0, -7)
+	activities, total, err = repo.ListActivities(dto.ListActivityQuery{Page: 1, PageSize: 10, DepartmentID: &department, From: &from})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	if assert.Len(t, activities, 2) {
+		assert.Equal(t, past.ID, activities[0].ID)
+	}
+}
+
+func TestDeleteActivity(t *testing.T) {
+	db := setupActivityDB(t)
+	repo := NewActivityRepository(db)
+	activity := newActivity(5, 1)
+	assert.NoError(t, repo.CreateActivity(activity))
+	_, err := repo.SignUp(activity.ID, 1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.DeleteActivity(activity.ID))
+	assert.ErrorIs(t, repo.DeleteActivity(activity.ID), domain.ErrActivityNotFound)
+
+	var count int64
+	db.Model(&domain.Signup{}).Count(&count)
+	assert.Zero(t, count)
+	db.Model(&domain.ActivityPosition{}).Count(&count)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// AttendanceRepositoryInterface defines the methods that an AttendanceRepository should implement
+type AttendanceRepositoryInterface interface {
+	FindActivityByID(id int) (*domain.Activity, error)
+	FindVolunteerID(userID int) (int, error)
+	IsConfirmed(activityID int, volunteerID int) (bool, error)
+	CheckIn(activityID int, volunteerID int, tokenID string, at time.Time) (*domain.Attendance, error)
+	CheckOut(activityID int, volunteerID int, tokenID string, at time.Time) (*domain.Attendance, error)
+	ListAttendance(activityID int) ([]*domain.Attendance, error)
+}
+
+type AttendanceRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttendanceRepository(db *gorm.DB) *AttendanceRepository {
+	return &AttendanceRepository{DB: db}
+}
+
+func (r *AttendanceRepository) FindActivityByID(id int) (*domain.Activity, error) {
+	var activity domain.Activity
+	if err := r.DB.First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrActivityNotFound
+		}
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// FindVolunteerID returns the id of the volunteer record of the user.
+func (r *AttendanceRepository) FindVolunteerID(userID int) (int, error) {
+	var volunteer volunteerDomain.Volunteer
+	err := r.DB.Select("id").Where("user_id = ?", userID).Take(&volunteer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, domain.ErrNotVolunteer
+	}
+	return volunteer.ID, err
+}
+
+// IsConfirmed returns whether the volunteer's sign-up for the activity is
+// confirmed.
+func (r *AttendanceRepository) IsConfirmed(activityID int, volunteerID int) (bool, error) {
+	var confirmed int64
+	err := r.DB.Model(&domain.Signup{}).
+		Where("activity_id = ? AND volunteer_id = ? AND status = ?", activityID, volunteerID, domain.SignupConfirmed).
+		Count(&confirmed).Error
+	return confirmed > 0, err
+}
+
+// CheckIn records the volunteer arriving at the activity and uses the token.
+func (r *AttendanceRepository) CheckIn(activityID int, volunteerID int, tokenID string, at time.Time) (*domain.Attendance, error) {
+	attendance := &domain.Attendance{ActivityID: activityID, VolunteerID: volunteerID, CheckedInAt: at}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := useToken(tx, tokenID, volunteerID, at); err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(attendance)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrAlreadyCheckedIn
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// CheckOut records the volunteer leaving the activity and uses the token.
+func (r *AttendanceRepository) CheckOut(activityID int, volunteerID int, tokenID string, at time.Time) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := useToken(tx, tokenID, volunteerID, at); err != nil {
+			return err
+		}
+		if err := tx.Where("activity_id = ? AND volunteer_id = ?", activityID, volunteerID).Take(&attendance).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrNotCheckedIn
+			}
+			return err
+		}
+		result := tx.Model(&domain.Attendance{}).
+			Where("id = ? AND checked_out_at IS NULL", attendance.ID).
+			Update("checked_out_at", at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrAlreadyCheckedOut
+		}
+		attendance.CheckedOutAt = &at
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// ListAttendance returns the attendance of the activity in check-in order.
+func (r *AttendanceRepository) ListAttendance(activityID int) ([]*domain.Attendance, error) {
+	attendance := make([]*domain.Attendance, 0)
+	err := r.DB.Select("activity_attendances.*, users.id AS user_id, users.name, users.surname").
+		Joins("JOIN volunteer_details ON volunteer_details.id = activity_attendances.volunteer_id").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Where("activity_attendances.activity_id = ?", activityID).
+		Order("activity_attendances.checked_in_at").
+		Order("activity_attendances.id").
+		Find(&attendance).Error
+	return attendance, err
+}
+
+// useToken records the volunteer using the token, a token they already used
+// gives ErrAttendanceTokenUsed.
+func useToken(tx *gorm.DB, tokenID string, volunteerID int, at time.Time) error {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.AttendanceTokenUse{TokenID: tokenID, VolunteerID: volunteerID, UsedAt: at})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAttendanceTokenUsed
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+)
+
+func TestCheckInAndOut(t *testing.T) {
+	db := setupActivityDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.AttendanceTokenUse{}))
+	activityRepo := NewActivityRepository(db)
+	repo := NewAttendanceRepository(db)
+	activity := newActivity(5)
+	assert.NoError(t, activityRepo.CreateActivity(activity))
+	_, err := activityRepo.SignUp(activity.ID, 2)
+	assert.NoError(t, err)
+
+	confirmed, err := repo.IsConfirmed(activity.ID, 2)
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+	confirmed, err = repo.IsConfirmed(activity.ID, 3)
+	assert.NoError(t, err)
+	assert.False(t, confirmed)
+
+	_, err = repo.CheckOut(activity.ID, 2, "out-1", time.Now())
+	assert.ErrorIs(t, err, domain.ErrNotCheckedIn)
+
+	in := time.Now()
+	attendance, err := repo.CheckIn(activity.ID, 2, "in-1", in)
+	assert.NoError(t, err)
+	assert.NotZero(t, attendance.ID)
+
+	_, err = repo.CheckIn(activity.ID, 2, "in-1", time.Now())
+	assert.ErrorIs(t, err, domain.ErrAttendanceTokenUsed)
+	_, err = repo.CheckIn(activity.ID, 2, "in-2", time.Now())
+	assert.ErrorIs(t, err, domain.ErrAlreadyCheckedIn)
+
+	// the failed check-out did not use its token
+	attendance, err = repo.CheckOut(activity.ID, 2, "out-1", in.Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.NotNil(t, attendance.CheckedOutAt)
+	_, err = repo.CheckOut(activity.ID, 2, "out-2", time.Now())
+	assert.ErrorIs(t, err, domain.ErrAlreadyCheckedOut)
+
+	// other volunteers scan the same code
+	_, err = repo.CheckIn(activity.ID, 3, "in-1", time.Now())
+	assert.NoError(t, err)
+
+	list, err := repo.ListAttendance(activity.ID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "Bob", list[0].Name)
+	assert.NotNil(t, list[0].CheckedOutAt)
+	assert.Nil(t, list[1].CheckedOutAt)
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// CalendarRepositoryInterface defines the methods that a CalendarRepository should implement
+type CalendarRepositoryInterface interface {
+	SaveFeed(userID int, tokenHash string) error
+	DeleteFeed(userID int) error
+	FindFeedUser(tokenHash string) (*authDomain.User, error)
+	FindVolunteer(userID int) (*volunteerDomain.Volunteer, error)
+	FindDepartmentName(id int) (string, error)
+	ListVolunteerEvents(volunteerID int, since time.Time) ([]*domain.CalendarEvent, error)
+	ListDepartmentEvents(departmentID int, since time.Time) ([]*domain.CalendarEvent, error)
+}
+
+type CalendarRepository struct {
+	DB *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) *CalendarRepository {
+	return &CalendarRepository{DB: db}
+}
+
+// SaveFeed sets the feed token of the user, replacing the previous one.
+func (r *CalendarRepository) SaveFeed(userID int, tokenHash string) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(&domain.CalendarFeed{UserID: userID, TokenHash: tokenHash}).Error
+}
+
+func (r *CalendarRepository) DeleteFeed(userID int) error {
+	return r.DB.Where("user_id = ?", userID).Delete(&domain.CalendarFeed{}).Error
+}
+
+// FindFeedUser returns the active user the feed token belongs to.
+func (r *CalendarRepository) FindFeedUser(tokenHash string) (*authDomain.User, error) {
+	var user authDomain.User
+	err := r.DB.Select("users.id", "users.role_id", "users.department_id").
+		Joins("JOIN calendar_feeds ON calendar_feeds.user_id = users.id").
+		Where("calendar_feeds.token_hash = ? AND users.status = ?", tokenHash, 1).
+		Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidFeedToken
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindVolunteer returns the volunteer record of the user.
+func (r *CalendarRepository) FindVolunteer(userID int) (*volunteerDomain.Volunteer, error) {
+	var volunteer volunteerDomain.Volunteer
+	if err := r.DB.Where("user_id = ?", userID).Take(&volunteer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotVolunteer
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+func (r *CalendarRepository) FindDepartmentName(id int) (string, error) {
+	var names []string
+	if err := r.DB.Table("departments").Where("id = ?", id).Limit(1).Pluck("name", &names).Error; err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", domain.ErrDepartmentNotFound
+	}
+	return names[0], nil
+}
+
+// ListVolunteerEvents returns the activities the volunteer is signed up for,
+// confirmed or waitlisted, that ended after since.
+func (r *CalendarRepository) ListVolunteerEvents(volunteerID int, since time.Time) ([]*domain.CalendarEvent, error) {
+	events := make([]*domain.CalendarEvent, 0)
+	err := r.events(r.DB.Model(&domain.Signup{}).
+		Joins("JOIN activities ON activities.id = activity_signups.activity_id"), "activity_signups.status").
+		Where("activity_signups.volunteer_id = ? AND activity_signups.status <> ?", volunteerID, domain.SignupCancelled).
+		Where("activities.ends_at > ?", since).
+		Order("activities.starts_at").
+		Order("activities.id").
+		Scan(&events).Error
+	return events, err
+}
+
+// ListDepartmentEvents returns the activities of the department that ended
+// after since.
+func (r *CalendarRepository) ListDepartmentEvents(departmentID int, since time.Time) ([]*domain.CalendarEvent, error) {
+	events := make([]*domain.CalendarEvent, 0)
+	err := r.events(r.DB.Model(&domain.Activity{}), "''").
+		Where("activities.department_id = ? AND activities.ends_at > ?", departmentID, since).
+		Order("activities.starts_at").
+		Order("activities.id").
+		Scan(&events).Error
+	return events, err
+}
+
+func (r *CalendarRepository) events(db *gorm.DB, status string) *gorm.DB {
+	return db.Select("activities.id AS activity_id, activities.title, activities.description, activities.location, " +
+		"departments.name AS department_name, activities.starts_at, activities.ends_at, activities.updated_at, " + status + " AS status").
+		Joins("LEFT JOIN departments ON departments.id = activities.department_id")
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	db := setupActivityDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.CalendarFeed{}))
+	repo := NewCalendarRepository(db)
+
+	assert.NoError(t, repo.SaveFeed(2, "first"))
+	user, err := repo.FindFeedUser("first")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, user.ID)
+
+	assert.NoError(t, repo.SaveFeed(2, "second"))
+	_, err = repo.FindFeedUser("first")
+	assert.ErrorIs(t, err, domain.ErrInvalidFeedToken)
+	_, err = repo.FindFeedUser("second")
+	assert.NoError(t, err)
+
+	// blocked users cannot read their feeds
+	assert.NoError(t, db.Model(&authDomain.User{}).Where("id = ?", 2).Update("status", 0).Error)
+	_, err = repo.FindFeedUser("second")
+	assert.ErrorIs(t, err, domain.ErrInvalidFeedToken)
+
+	assert.NoError(t, repo.SaveFeed(3, "third"))
+	assert.NoError(t, repo.DeleteFeed(3))
+	_, err = repo.FindFeedUser("third")
+	assert.ErrorIs(t, err, domain.ErrInvalidFeedToken)
+}
+
+func TestCalendarEvents(t *testing.T) {
+	db := setupActivityDB(t)
+	assert.NoError(t, db.Exec("CREATE TABLE departments (id integer primary key, name text)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO departments (id, name) VALUES (1, 'Kitchen')").Error)
+	activityRepo := NewActivityRepository(db)
+	repo := NewCalendarRepository(db)
+
+	full := newActivity(1)
+	assert.NoError(t, activityRepo.CreateActivity(full))
+	later := newActivity(5)
+	later.Title = "Soup kitchen"
+	later.StartsAt = later.StartsAt.Add(48 * time.Hour)
+	later.EndsAt = later.EndsAt.Add(48 * time.Hour)
+	assert.NoError(t, activityRepo.CreateActivity(later))
+	old := newActivity(5)
+	old.StartsAt = time.Now().AddDate(0, -6, 0)
+	old.EndsAt = old.StartsAt.Add(time.Hour)
+	assert.NoError(t, db.Create(old).Error)
+
+	_, err := activityRepo.SignUp(full.ID, 1)
+	assert.NoError(t, err)
+	_, err = activityRepo.SignUp(full.ID, 2)
+	assert.NoError(t, err)
+	_, err = activityRepo.SignUp(later.ID, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, activityRepo.CancelSignup(later.ID, 2))
+
+	since := time.Now().AddDate(0, 0, -90)
+	events, err := repo.ListVolunteerEvents(2, since)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.SignupWaitlisted, events[0].Status)
+	assert.Equal(t, "Kitchen", events[0].DepartmentName)
+
+	events, err = repo.ListDepartmentEvents(1, since)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "Soup kitchen", events[1].Title)
+	assert.Empty(t, events[1].Status)
+
+	name, err := repo.FindDepartmentName(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Kitchen", name)
+	_, err = repo.FindDepartmentName(9)
+	assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
+}
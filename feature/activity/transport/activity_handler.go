@@ -0,0 +1,334 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/usecase"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type ActivityHandler struct {
+	ActivityUsecase usecase.ActivityUsecaseInterface
+}
+
+func NewActivityHandler(activityUsecase usecase.ActivityUsecaseInterface) *ActivityHandler {
+	return &ActivityHandler{ActivityUsecase: activityUsecase}
+}
+
+// CreateActivity godoc
+// @Summary Create activity
+// @Description Create an activity of a department. Admins who belong to a department can only create activities of their department
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param request body dto.ActivityDTO true "Create Activity Request"
+// @Success 201 {object} dto.ActivityResponseDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/activity/ [post]
+func (h *ActivityHandler) CreateActivity(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.ActivityDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	activity, err := h.ActivityUsecase.CreateActivity(userId.(int), input)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, activity)
+}
+
+// UpdateActivity godoc
+// @Summary Update activity
+// @Description Update an activity. Growing the capacity confirms waitlisted volunteers, the capacity cannot drop below the confirmed volunteers
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Param request body dto.ActivityDTO true "Update Activity Request"
+// @Success 200 {object} dto.ActivityResponseDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/activity/{id} [put]
+func (h *ActivityHandler) UpdateActivity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.ActivityDTO
+	if err = c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	activity, err := h.ActivityUsecase.UpdateActivity(userId.(int), id, input)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
+
+// DeleteActivity godoc
+// @Summary Delete activity
+// @Description Delete an activity with its sign-ups
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Success 200 {string} message "Activity deleted successfully"
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/activity/{id} [delete]
+func (h *ActivityHandler) DeleteActivity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.ActivityUsecase.DeleteActivity(userId.(int), id); err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Activity deleted successfully"})
+}
+
+// FindActivityByID godoc
+// @Summary Get activity
+// @Description Get an activity with its required positions and skills and the number of confirmed and waitlisted volunteers
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Success 200 {object} dto.ActivityResponseDTO
+// @Failure 404 string error
+// @Router /api/v1/activity/{id} [get]
+func (h *ActivityHandler) FindActivityByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+
+	activity, err := h.ActivityUsecase.FindActivityByID(id)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
+
+// ListActivities godoc
+// @Summary List activities
+// @Description List activities soonest first, by default the ones that have not ended
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param department_id query int false "Department ID"
+// @Param from query string false "Starting on or after, YYYY-MM-DD"
+// @Param to query string false "Starting on or before, YYYY-MM-DD"
+// @Param page query int false "Page, from 1"
+// @Param page_size query int false "Activities per page, at most 100"
+// @Success 200 {object} dto.ListActivityDTO
+// @Router /api/v1/activity/ [get]
+func (h *ActivityHandler) ListActivities(c *gin.Context) {
+	var query dto.ListActivityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	activities, err := h.ActivityUsecase.ListActivities(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, activities)
+}
+
+// ListSignups godoc
+// @Summary List sign-ups
+// @Description Get the volunteers signed up for the activity, the confirmed ones first and then the waitlist in order
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Success 200 {array} dto.SignupDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/activity/{id}/signups [get]
+func (h *ActivityHandler) ListSignups(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	signups, err := h.ActivityUsecase.ListSignups(userId.(int), id)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, signups)
+}
+
+// CancelSignup godoc
+// @Summary Cancel a volunteer's sign-up
+// @Description Cancel the sign-up of a volunteer, their place goes to the first volunteer on the waitlist
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Param volunteerId path int true "Volunteer ID"
+// @Success 200 {string} message "Sign-up cancelled successfully"
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/activity/{id}/signups/{volunteerId} [delete]
+func (h *ActivityHandler) CancelSignup(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	volunteerID, err := strconv.Atoi(c.Param("volunteerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.ActivityUsecase.CancelSignup(userId.(int), id, volunteerID); err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sign-up cancelled successfully"})
+}
+
+// SignUp godoc
+// @Summary Sign up for activity
+// @Description Sign the current volunteer up for the activity, on the waitlist when it is full
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Success 201 {object} dto.SignupDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/activity/{id}/signup [post]
+func (h *ActivityHandler) SignUp(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	signup, err := h.ActivityUsecase.SignUp(userId.(int), id)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, signup)
+}
+
+// CancelOwnSignup godoc
+// @Summary Cancel sign-up
+// @Description Cancel the sign-up of the current volunteer
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Success 200 {string} message "Sign-up cancelled successfully"
+// @Failure 404 string error
+// @Router /api/v1/activity/{id}/signup [delete]
+func (h *ActivityHandler) CancelOwnSignup(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.ActivityUsecase.CancelOwnSignup(userId.(int), id); err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sign-up cancelled successfully"})
+}
+
+// activityErrorStatus maps activity errors to HTTP status codes.
+func activityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrActivityNotFound), errors.Is(err, domain.ErrSignupNotFound),
+		errors.Is(err, roleDomain.ErrPositionNotFound), errors.Is(err, volunteerDomain.ErrSkillNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrOutsideDepartment), errors.Is(err, domain.ErrNotVolunteer),
+		errors.Is(err, domain.ErrPositionRequired), errors.Is(err, domain.ErrNotConfirmed):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrActivityStarted), errors.Is(err, domain.ErrAlreadySignedUp),
+		errors.Is(err, domain.ErrCapacityTooLow), errors.Is(err, domain.ErrActivityEnded),
+		errors.Is(err, domain.ErrAttendanceTokenUsed), errors.Is(err, domain.ErrAlreadyCheckedIn),
+		errors.Is(err, domain.ErrNotCheckedIn), errors.Is(err, domain.ErrAlreadyCheckedOut):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidAttendanceToken):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,176 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockActivityUsecase struct {
+	mock.Mock
+}
+
+func (m *MockActivityUsecase) CreateActivity(adminID int, input dto.ActivityDTO) (*dto.ActivityResponseDTO, error) {
+	args := m.Called(adminID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ActivityResponseDTO), args.Error(1)
+}
+
+func (m *MockActivityUsecase) UpdateActivity(adminID int, id int, input dto.ActivityDTO) (*dto.ActivityResponseDTO, error) {
+	args := m.Called(adminID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ActivityResponseDTO), args.Error(1)
+}
+
+func (m *MockActivityUsecase) DeleteActivity(adminID int, id int) error {
+	args := m.Called(adminID, id)
+	return args.Error(0)
+}
+
+func (m *MockActivityUsecase) FindActivityByID(id int) (*dto.ActivityResponseDTO, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ActivityResponseDTO), args.Error(1)
+}
+
+func (m *MockActivityUsecase) ListActivities(query dto.ListActivityQuery) (*dto.ListActivityDTO, error) {
+	args := m.Called(query)
+	return args.Get(0).(*dto.ListActivityDTO), args.Error(1)
+}
+
+func (m *MockActivityUsecase) ListSignups(adminID int, id int) ([]dto.SignupDTO, error) {
+	args := m.Called(adminID, id)
+	return args.Get(0).([]dto.SignupDTO), args.Error(1)
+}
+
+func (m *MockActivityUsecase) CancelSignup(adminID int, id int, volunteerID int) error {
+	args := m.Called(adminID, id, volunteerID)
+	return args.Error(0)
+}
+
+func (m *MockActivityUsecase) SignUp(userID int, id int) (*dto.SignupDTO, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SignupDTO), args.Error(1)
+}
+
+func (m *MockActivityUsecase) CancelOwnSignup(userID int, id int) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func setupActivityRouter(handler *ActivityHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set("userId", 7)
+	})
+	r.POST("/api/v1/activity", handler.CreateActivity)
+	r.POST("/api/v1/activity/:id/signup", handler.SignUp)
+	r.DELETE("/api/v1/activity/:id/signup", handler.CancelOwnSignup)
+	return r
+}
+
+func TestCreateActivity(t *testing.T) {
+	mockUsecase := new(MockActivityUsecase)
+	r := setupActivityRouter(NewActivityHandler(mockUsecase))
+
+	t.Run("success", func(t *testing.T) {
+		start := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+		input := dto.ActivityDTO{Title: "Beach cleanup", DepartmentID: 2, Location: "Beach", StartsAt: start, EndsAt: start.Add(3 * time.Hour), Capacity: 10}
+		mockUsecase.On("CreateActivity", 7, input).Return(&dto.ActivityResponseDTO{ID: 4, Title: "Beach cleanup"}, nil)
+
+		body := `{"title":"Beach cleanup","department_id":2,"location":"Beach","starts_at":"2030-05-01T09:00:00Z","ends_at":"2030-05-01T12:00:00Z","capacity":10}`
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/activity", strings.NewReader(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"id":4`)
+	})
+
+	t.Run("ends before it starts", func(t *testing.T) {
+		body := `{"title":"Beach cleanup","department_id":2,"location":"Beach","starts_at":"2030-05-01T09:00:00Z","ends_at":"2030-05-01T08:00:00Z","capacity":10}`
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/activity", strings.NewReader(body))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSignUp(t *testing.T) {
+	mockUsecase := new(MockActivityUsecase)
+	r := setupActivityRouter(NewActivityHandler(mockUsecase))
+
+	t.Run("waitlisted", func(t *testing.T) {
+		mockUsecase.On("SignUp", 7, 4).Return(&dto.SignupDTO{ID: 1, ActivityID: 4, VolunteerID: 5, Status: domain.SignupWaitlisted}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/activity/4/signup", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"waitlisted"`)
+	})
+
+	t.Run("missing position", func(t *testing.T) {
+		mockUsecase.On("SignUp", 7, 5).Return(nil, domain.ErrPositionRequired)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/activity/5/signup", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("already signed up", func(t *testing.T) {
+		mockUsecase.On("SignUp", 7, 6).Return(nil, domain.ErrAlreadySignedUp)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/activity/6/signup", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestCancelOwnSignup(t *testing.T) {
+	mockUsecase := new(MockActivityUsecase)
+	r := setupActivityRouter(NewActivityHandler(mockUsecase))
+	mockUsecase.On("CancelOwnSignup", 7, 4).Return(domain.ErrSignupNotFound)
+
+	req, err := http.NewRequest(http.MethodDelete, "/api/v1/activity/4/signup", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,125 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceHandler struct {
+	AttendanceUsecase usecase.AttendanceUsecaseInterface
+}
+
+func NewAttendanceHandler(attendanceUsecase usecase.AttendanceUsecaseInterface) *AttendanceHandler {
+	return &AttendanceHandler{AttendanceUsecase: attendanceUsecase}
+}
+
+// AttendanceQR godoc
+// @Summary Attendance QR code
+// @Description Get a PNG QR code volunteers scan to check in or out of the activity. It holds a new signed token that expires after ATTENDANCE_TOKEN_TTL
+// @Produce png
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Param action query string true "check_in or check_out"
+// @Success 200 {file} binary
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/activity/{id}/attendance/qr [get]
+func (h *AttendanceHandler) AttendanceQR(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.AttendanceQRQuery
+	if err = c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	png, err := h.AttendanceUsecase.AttendanceQR(userId.(int), id, query.Action)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// ScanAttendance godoc
+// @Summary Check in or out
+// @Description Check the current volunteer in or out of an activity they are confirmed for with the token of a QR code. A token can only be used once
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param request body dto.ScanAttendanceDTO true "Scan Attendance Request"
+// @Success 200 {object} dto.AttendanceDTO
+// @Failure 400 string error
+// @Failure 403 string error
+// @Failure 409 string error
+// @Router /api/v1/activity/attendance [post]
+func (h *AttendanceHandler) ScanAttendance(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.ScanAttendanceDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, err := h.AttendanceUsecase.Scan(userId.(int), input)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// ListAttendance godoc
+// @Summary List attendance
+// @Description Get when the volunteers checked in and out of the activity, in check-in order
+// @Produce json
+// @Tags activity
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Success 200 {array} dto.AttendanceDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/activity/{id}/attendance [get]
+func (h *AttendanceHandler) ListAttendance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	attendance, err := h.AttendanceUsecase.ListAttendance(userId.(int), id)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
@@ -0,0 +1,114 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttendanceUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAttendanceUsecase) AttendanceQR(adminID int, activityID int, action string) ([]byte, error) {
+	args := m.Called(adminID, activityID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockAttendanceUsecase) Scan(userID int, input dto.ScanAttendanceDTO) (*dto.AttendanceDTO, error) {
+	args := m.Called(userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AttendanceDTO), args.Error(1)
+}
+
+func (m *MockAttendanceUsecase) ListAttendance(adminID int, activityID int) ([]dto.AttendanceDTO, error) {
+	args := m.Called(adminID, activityID)
+	return args.Get(0).([]dto.AttendanceDTO), args.Error(1)
+}
+
+func TestAttendanceQR(t *testing.T) {
+	mockUsecase := new(MockAttendanceUsecase)
+	handler := NewAttendanceHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/activity/:id/attendance/qr", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.AttendanceQR(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("AttendanceQR", 7, 4, domain.AttendanceCheckIn).Return([]byte("\x89PNG"), nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/activity/4/attendance/qr?action=check_in", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/activity/4/attendance/qr?action=leave", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestScanAttendance(t *testing.T) {
+	mockUsecase := new(MockAttendanceUsecase)
+	handler := NewAttendanceHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/activity/attendance", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.ScanAttendance(c)
+	})
+
+	cases := []struct {
+		name   string
+		token  string
+		result *dto.AttendanceDTO
+		err    error
+		status int
+	}{
+		{"checked in", "good", &dto.AttendanceDTO{ID: 1, ActivityID: 4, VolunteerID: 3, CheckedInAt: time.Now()}, nil, http.StatusOK},
+		{"replayed", "used", nil, domain.ErrAttendanceTokenUsed, http.StatusConflict},
+		{"expired", "expired", nil, domain.ErrInvalidAttendanceToken, http.StatusBadRequest},
+		{"not confirmed", "other", nil, domain.ErrNotConfirmed, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUsecase.On("Scan", 11, dto.ScanAttendanceDTO{Token: tc.token}).Return(tc.result, tc.err)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/activity/attendance", strings.NewReader(`{"token":"`+tc.token+`"}`))
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.status, rr.Code)
+		})
+	}
+}
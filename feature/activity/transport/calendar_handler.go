@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	CalendarUsecase usecase.CalendarUsecaseInterface
+}
+
+func NewCalendarHandler(calendarUsecase usecase.CalendarUsecaseInterface) *CalendarHandler {
+	return &CalendarHandler{CalendarUsecase: calendarUsecase}
+}
+
+// CreateMyFeed godoc
+// @Summary Create my calendar feed
+// @Description Create the token of the current user's calendar feeds and get the URLs to subscribe to. Creating it again replaces the previous token
+// @Produce json
+// @Tags calendar
+// @Security bearerToken
+// @Success 201 {object} dto.CalendarFeedDTO
+// @Router /api/v1/me/calendar-feed [post]
+func (h *CalendarHandler) CreateMyFeed(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	feed, err := h.CalendarUsecase.CreateFeed(userId.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+// RevokeMyFeed godoc
+// @Summary Revoke my calendar feed
+// @Description Revoke the token of the current user's calendar feeds, the subscribed calendars stop updating
+// @Produce json
+// @Tags calendar
+// @Security bearerToken
+// @Success 200 {string} message "Calendar feed revoked successfully"
+// @Router /api/v1/me/calendar-feed [delete]
+func (h *CalendarHandler) RevokeMyFeed(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.CalendarUsecase.RevokeFeed(userId.(int)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+// VolunteerCalendar godoc
+// @Summary Volunteer calendar
+// @Description iCalendar feed of the activities the volunteer signed up for, authenticated by their feed token
+// @Produce text/calendar
+// @Tags calendar
+// @Param token query string true "Feed token"
+// @Success 200 {file} binary
+// @Failure 401 string error
+// @Failure 403 string error
+// @Router /api/v1/calendar/me.ics [get]
+func (h *CalendarHandler) VolunteerCalendar(c *gin.Context) {
+	calendar, err := h.CalendarUsecase.VolunteerCalendar(c.Query("token"))
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="activities.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// DepartmentCalendar godoc
+// @Summary Department calendar
+// @Description iCalendar feed of the activities of a department for its volunteers and coordinators, authenticated by their feed token
+// @Produce text/calendar
+// @Tags calendar
+// @Param id path int true "Department ID"
+// @Param token query string true "Feed token"
+// @Success 200 {file} binary
+// @Failure 401 string error
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/calendar/departments/{id}/activities.ics [get]
+func (h *CalendarHandler) DepartmentCalendar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	calendar, err := h.CalendarUsecase.DepartmentCalendar(c.Query("token"), id)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="department-activities.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// calendarErrorStatus maps calendar feed errors to HTTP status codes.
+func calendarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidFeedToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrNotVolunteer), errors.Is(err, domain.ErrFeedNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrDepartmentNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,116 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalendarUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCalendarUsecase) CreateFeed(userID int) (*dto.CalendarFeedDTO, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CalendarFeedDTO), args.Error(1)
+}
+
+func (m *MockCalendarUsecase) RevokeFeed(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockCalendarUsecase) VolunteerCalendar(token string) ([]byte, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockCalendarUsecase) DepartmentCalendar(token string, departmentID int) ([]byte, error) {
+	args := m.Called(token, departmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestCreateMyFeed(t *testing.T) {
+	mockUsecase := new(MockCalendarUsecase)
+	handler := NewCalendarHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/me/calendar-feed", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.CreateMyFeed(c)
+	})
+
+	mockUsecase.On("CreateFeed", 11).Return(&dto.CalendarFeedDTO{Token: "abc"}, nil)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/me/calendar-feed", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"abc"`)
+}
+
+func TestDepartmentCalendarHandler(t *testing.T) {
+	mockUsecase := new(MockCalendarUsecase)
+	handler := NewCalendarHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/calendar/departments/:id/activities.ics", handler.DepartmentCalendar)
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("DepartmentCalendar", "good", 2).Return([]byte("BEGIN:VCALENDAR\r\n"), nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/calendar/departments/2/activities.ics?token=good", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "BEGIN:VCALENDAR\r\n", rr.Body.String())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockUsecase.On("DepartmentCalendar", "bad", 2).Return(nil, domain.ErrInvalidFeedToken)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/calendar/departments/2/activities.ics?token=bad", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		mockUsecase.On("DepartmentCalendar", "other", 2).Return(nil, domain.ErrFeedNotAllowed)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/calendar/departments/2/activities.ics?token=other", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
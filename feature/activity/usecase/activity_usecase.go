@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/storage"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+)
+
+type ActivityUsecaseInterface interface {
+	CreateActivity(adminID int, input dto.ActivityDTO) (*dto.ActivityResponseDTO, error)
+	UpdateActivity(adminID int, id int, input dto.ActivityDTO) (*dto.ActivityResponseDTO, error)
+	DeleteActivity(adminID int, id int) error
+	FindActivityByID(id int) (*dto.ActivityResponseDTO, error)
+	ListActivities(query dto.ListActivityQuery) (*dto.ListActivityDTO, error)
+	ListSignups(adminID int, id int) ([]dto.SignupDTO, error)
+	CancelSignup(adminID int, id int, volunteerID int) error
+	SignUp(userID int, id int) (*dto.SignupDTO, error)
+	CancelOwnSignup(userID int, id int) error
+}
+
+// ActivityUsecase manages activities and sign-ups. Admins who belong to a
+// department only manage the activities of their department, admins without
+// a department manage all of them.
+type ActivityUsecase struct {
+	ActivityRepo    storage.ActivityRepositoryInterface
+	DepartmentScope departmentUsecase.DepartmentScopeInterface
+}
+
+func NewActivityUsecase(activityRepo storage.ActivityRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface) *ActivityUsecase {
+	return &ActivityUsecase{ActivityRepo: activityRepo, DepartmentScope: departmentScope}
+}
+
+const defaultActivityPageSize = 20
+
+func (u *ActivityUsecase) CreateActivity(adminID int, input dto.ActivityDTO) (*dto.ActivityResponseDTO, error) {
+	if err := u.DepartmentScope.CheckDepartment(adminID, input.DepartmentID); err != nil {
+		return nil, err
+	}
+	activity := &domain.Activity{CreatedBy: adminID}
+	applyActivity(activity, input)
+	if err := u.ActivityRepo.CreateActivity(activity); err != nil {
+		return nil, err
+	}
+	return u.FindActivityByID(activity.ID)
+}
+
+func (u *ActivityUsecase) UpdateActivity(adminID int, id int, input dto.ActivityDTO) (*dto.ActivityResponseDTO, error) {
+	activity, err := u.ActivityRepo.FindActivityByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, input.DepartmentID); err != nil {
+		return nil, err
+	}
+	applyActivity(activity, input)
+	if err := u.ActivityRepo.UpdateActivity(activity); err != nil {
+		return nil, err
+	}
+	return u.FindActivityByID(id)
+}
+
+func (u *ActivityUsecase) DeleteActivity(adminID int, id int) error {
+	activity, err := u.ActivityRepo.FindActivityByID(id)
+	if err != nil {
+		return err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return err
+	}
+	return u.ActivityRepo.DeleteActivity(id)
+}
+
+func (u *ActivityUsecase) FindActivityByID(id int) (*dto.ActivityResponseDTO, error) {
+	activity, err := u.ActivityRepo.FindActivityByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toActivityResponse(activity)
+	return &response, nil
+}
+
+func (u *ActivityUsecase) ListActivities(query dto.ListActivityQuery) (*dto.ListActivityDTO, error) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultActivityPageSize
+	}
+	activities, total, err := u.ActivityRepo.ListActivities(query)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.ListActivityDTO{
+		Activities: make([]dto.ActivityResponseDTO, 0, len(activities)),
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Total:      total,
+		TotalPages: int((total + int64(query.PageSize) - 1) / int64(query.PageSize)),
+	}
+	for _, activity := range activities {
+		response.Activities = append(response.Activities, toActivityResponse(activity))
+	}
+	return response, nil
+}
+
+func (u *ActivityUsecase) ListSignups(adminID int, id int) ([]dto.SignupDTO, error) {
+	activity, err := u.ActivityRepo.FindActivityByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return nil, err
+	}
+	signups, err := u.ActivityRepo.ListSignups(id)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.SignupDTO, 0, len(signups))
+	for _, signup := range signups {
+		response = append(response, toSignup(signup))
+	}
+	return response, nil
+}
+
+// CancelSignup cancels the sign-up of a volunteer on behalf of an admin.
+func (u *ActivityUsecase) CancelSignup(adminID int, id int, volunteerID int) error {
+	activity, err := u.ActivityRepo.FindActivityByID(id)
+	if err != nil {
+		return err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return err
+	}
+	return u.ActivityRepo.CancelSignup(id, volunteerID)
+}
+
+// SignUp signs the volunteer record of the user up for the activity.
+func (u *ActivityUsecase) SignUp(userID int, id int) (*dto.SignupDTO, error) {
+	volunteerID, err := u.ActivityRepo.FindVolunteerID(userID)
+	if err != nil {
+		return nil, err
+	}
+	signup, err := u.ActivityRepo.SignUp(id, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	response := toSignup(signup)
+	return &response, nil
+}
+
+func (u *ActivityUsecase) CancelOwnSignup(userID int, id int) error {
+	volunteerID, err := u.ActivityRepo.FindVolunteerID(userID)
+	if err != nil {
+		return err
+	}
+	return u.ActivityRepo.CancelSignup(id, volunteerID)
+}
+
+func applyActivity(activity *domain.Activity, input dto.ActivityDTO) {
+	activity.Title = input.Title
+	activity.Description = input.Description
+	activity.DepartmentID = input.DepartmentID
+	activity.Location = input.Location
+	activity.StartsAt = input.StartsAt
+	activity.EndsAt = input.EndsAt
+	activity.Capacity = input.Capacity
+	activity.PositionIDs = input.PositionIDs
+	activity.SkillIDs = input.SkillIDs
+}
+
+func toActivityResponse(activity *domain.Activity) dto.ActivityResponseDTO {
+	positionIDs := activity.PositionIDs
+	if positionIDs == nil {
+		positionIDs = make([]int, 0)
+	}
+	skillIDs := activity.SkillIDs
+	if skillIDs == nil {
+		skillIDs = make([]int, 0)
+	}
+	return dto.ActivityResponseDTO{
+		ID:           activity.ID,
+		Title:        activity.Title,
+		Description:  activity.Description,
+		DepartmentID: activity.DepartmentID,
+		Location:     activity.Location,
+		StartsAt:     activity.StartsAt,
+		EndsAt:       activity.EndsAt,
+		Capacity:     activity.Capacity,
+		PositionIDs:  positionIDs,
+		SkillIDs:     skillIDs,
+		Confirmed:    activity.Confirmed,
+		Waitlisted:   activity.Waitlisted,
+		CreatedBy:    activity.CreatedBy,
+		CreatedAt:    activity.CreatedAt,
+	}
+}
+
+func toSignup(signup *domain.Signup) dto.SignupDTO {
+	return dto.SignupDTO{
+		ID:          signup.ID,
+		ActivityID:  signup.ActivityID,
+		VolunteerID: signup.VolunteerID,
+		UserID:      signup.UserID,
+		Name:        signup.Name,
+		Surname:     signup.Surname,
+		Email:       signup.Email,
+		Status:      signup.Status,
+		CreatedAt:   signup.CreatedAt,
+		CancelledAt: signup.CancelledAt,
+	}
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityRepository) CreateActivity(activity *domain.Activity) error {
+	args := m.Called(activity)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) UpdateActivity(activity *domain.Activity) error {
+	args := m.Called(activity)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) DeleteActivity(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) FindActivityByID(id int) (*domain.Activity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Activity), args.Error(1)
+}
+
+func (m *MockActivityRepository) ListActivities(query dto.ListActivityQuery) ([]*domain.Activity, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*domain.Activity), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockActivityRepository) ListSignups(activityID int) ([]*domain.Signup, error) {
+	args := m.Called(activityID)
+	return args.Get(0).([]*domain.Signup), args.Error(1)
+}
+
+func (m *MockActivityRepository) SignUp(activityID int, volunteerID int) (*domain.Signup, error) {
+	args := m.Called(activityID, volunteerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Signup), args.Error(1)
+}
+
+func (m *MockActivityRepository) CancelSignup(activityID int, volunteerID int) error {
+	args := m.Called(activityID, volunteerID)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) FindVolunteerID(userID int) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockDepartmentScopeRepository finds the department of the admins, the
+// department scope itself is not mocked.
+type MockDepartmentScopeRepository struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentScopeRepository) FindUserDepartment(userID int) (*int, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockDepartmentScopeRepository) HasAllDepartments(userID int) (bool, error) {
+	args := m.Called(userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func activityInput(departmentID int) dto.ActivityDTO {
+	start := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	return dto.ActivityDTO{
+		Title:        "Beach cleanup",
+		DepartmentID: departmentID,
+		Location:     "Beach",
+		StartsAt:     start,
+		EndsAt:       start.Add(3 * time.Hour),
+		Capacity:     10,
+		PositionIDs:  []int{1},
+	}
+}
+
+func TestCreateActivity(t *testing.T) {
+	department := 2
+
+	t.Run("in the admin's department", func(t *testing.T) {
+		mockRepo := new(MockActivityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewActivityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+		departments.On("FindUserDepartment", 7).Return(&department, nil)
+		mockRepo.On("CreateActivity", mock.MatchedBy(func(activity *domain.Activity) bool {
+			return activity.CreatedBy == 7 && activity.DepartmentID == 2 && activity.Capacity == 10
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.Activity).ID = 4
+		}).Return(nil)
+		mockRepo.On("FindActivityByID", 4).Return(&domain.Activity{ID: 4, DepartmentID: 2, Capacity: 10, CreatedBy: 7}, nil)
+
+		result, err := usecase.CreateActivity(7, activityInput(2))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.ID)
+		assert.Equal(t, []int{}, result.PositionIDs)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("outside the admin's department", func(t *testing.T) {
+		mockRepo := new(MockActivityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewActivityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+		departments.On("FindUserDepartment", 7).Return(&department, nil)
+
+		_, err := usecase.CreateActivity(7, activityInput(3))
+
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "CreateActivity", mock.Anything)
+	})
+
+	t.Run("admin without department", func(t *testing.T) {
+		mockRepo := new(MockActivityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewActivityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+		departments.On("FindUserDepartment", 1).Return(nil, nil)
+		departments.On("HasAllDepartments", 1).Return(true, nil)
+		mockRepo.On("CreateActivity", mock.Anything).Return(nil)
+		mockRepo.On("FindActivityByID", 0).Return(&domain.Activity{DepartmentID: 3}, nil)
+
+		_, err := usecase.CreateActivity(1, activityInput(3))
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdateActivityMovingDepartment(t *testing.T) {
+	mockRepo := new(MockActivityRepository)
+	departments := new(MockDepartmentScopeRepository)
+	usecase := NewActivityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+	department := 2
+	mockRepo.On("FindActivityByID", 4).Return(&domain.Activity{ID: 4, DepartmentID: 2}, nil)
+	departments.On("FindUserDepartment", 7).Return(&department, nil)
+
+	_, err := usecase.UpdateActivity(7, 4, activityInput(3))
+
+	assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	mockRepo.AssertNotCalled(t, "UpdateActivity", mock.Anything)
+}
+
+func TestSignUp(t *testing.T) {
+	mockRepo := new(MockActivityRepository)
+	departments := new(MockDepartmentScopeRepository)
+	usecase := NewActivityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+	mockRepo.On("FindVolunteerID", 11).Return(5, nil)
+	mockRepo.On("SignUp", 4, 5).Return(&domain.Signup{ID: 1, ActivityID: 4, VolunteerID: 5, Status: domain.SignupWaitlisted}, nil)
+
+	result, err := usecase.SignUp(11, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SignupWaitlisted, result.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListActivities(t *testing.T) {
+	mockRepo := new(MockActivityRepository)
+	departments := new(MockDepartmentScopeRepository)
+	usecase := NewActivityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+	mockRepo.On("ListActivities", dto.ListActivityQuery{Page: 1, PageSize: 20}).
+		Return([]*domain.Activity{{ID: 1, Title: "Beach cleanup", PositionIDs: []int{2}, Confirmed: 3}}, int64(21), nil)
+
+	result, err := usecase.ListActivities(dto.ListActivityQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalPages)
+	if assert.Len(t, result.Activities, 1) {
+		assert.Equal(t, 3, result.Activities[0].Confirmed)
+		assert.Equal(t, []int{2}, result.Activities[0].PositionIDs)
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/skip2/go-qrcode"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/storage"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+)
+
+// attendancePurpose marks attendance tokens so they cannot be confused with
+// other tokens signed with the same key.
+const attendancePurpose = "attendance"
+
+// attendanceQRSize is the width and height of the QR images in pixels.
+const attendanceQRSize = 320
+
+type AttendanceUsecaseInterface interface {
+	AttendanceQR(adminID int, activityID int, action string) ([]byte, error)
+	Scan(userID int, input dto.ScanAttendanceDTO) (*dto.AttendanceDTO, error)
+	ListAttendance(adminID int, activityID int) ([]dto.AttendanceDTO, error)
+}
+
+// AttendanceUsecase checks volunteers in and out of activities. Coordinators
+// show a QR code holding a short-lived signed token for the activity and the
+// action; volunteers scan it and send the token back.
+type AttendanceUsecase struct {
+	AttendanceRepo  storage.AttendanceRepositoryInterface
+	DepartmentScope departmentUsecase.DepartmentScopeInterface
+	secretKey       string
+	tokenTTL        time.Duration
+	scanURL         string
+}
+
+func NewAttendanceUsecase(attendanceRepo storage.AttendanceRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface, secretKey string, tokenTTL time.Duration, scanURL string) *AttendanceUsecase {
+	return &AttendanceUsecase{
+		AttendanceRepo:  attendanceRepo,
+		DepartmentScope: departmentScope,
+		secretKey:       secretKey,
+		tokenTTL:        tokenTTL,
+		scanURL:         scanURL,
+	}
+}
+
+// AttendanceQR returns a PNG QR code linking to the scan page with a new
+// token for the activity and the action.
+func (u *AttendanceUsecase) AttendanceQR(adminID int, activityID int, action string) ([]byte, error) {
+	activity, err := u.AttendanceRepo.FindActivityByID(activityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return nil, err
+	}
+	if action == domain.AttendanceCheckIn && !activity.EndsAt.After(time.Now()) {
+		return nil, domain.ErrActivityEnded
+	}
+	token, err := u.attendanceToken(activityID, action)
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.Encode(fmt.Sprintf("%s?token=%s", u.scanURL, url.QueryEscape(token)), qrcode.Medium, attendanceQRSize)
+}
+
+// Scan checks the volunteer in or out with a token read from a QR code. The
+// volunteer must be confirmed for the activity and each token can be used
+// once by them.
+func (u *AttendanceUsecase) Scan(userID int, input dto.ScanAttendanceDTO) (*dto.AttendanceDTO, error) {
+	activityID, action, tokenID, err := u.parseAttendanceToken(input.Token)
+	if err != nil {
+		return nil, err
+	}
+	volunteerID, err := u.AttendanceRepo.FindVolunteerID(userID)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := u.AttendanceRepo.FindActivityByID(activityID)
+	if err != nil {
+		return nil, err
+	}
+	confirmed, err := u.AttendanceRepo.IsConfirmed(activityID, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return nil, domain.ErrNotConfirmed
+	}
+
+	now := time.Now()
+	var attendance *domain.Attendance
+	switch action {
+	case domain.AttendanceCheckIn:
+		if !activity.EndsAt.After(now) {
+			return nil, domain.ErrActivityEnded
+		}
+		attendance, err = u.AttendanceRepo.CheckIn(activityID, volunteerID, tokenID, now)
+	case domain.AttendanceCheckOut:
+		attendance, err = u.AttendanceRepo.CheckOut(activityID, volunteerID, tokenID, now)
+	default:
+		return nil, domain.ErrInvalidAttendanceToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := toAttendance(attendance)
+	return &response, nil
+}
+
+func (u *AttendanceUsecase) ListAttendance(adminID int, activityID int) ([]dto.AttendanceDTO, error) {
+	activity, err := u.AttendanceRepo.FindActivityByID(activityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return nil, err
+	}
+	attendance, err := u.AttendanceRepo.ListAttendance(activityID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.AttendanceDTO, 0, len(attendance))
+	for _, entry := range attendance {
+		response = append(response, toAttendance(entry))
+	}
+	return response, nil
+}
+
+func (u *AttendanceUsecase) attendanceToken(activityID int, action string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"activityId": activityID,
+		"action":     action,
+		"purpose":    attendancePurpose,
+		"jti":        hex.EncodeToString(b),
+		"iat":        time.Now().Unix(),
+		"exp":        time.Now().Add(u.tokenTTL).Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(u.secretKey))
+}
+
+// parseAttendanceToken returns the activity, action and id of a valid
+// attendance token, ErrInvalidAttendanceToken otherwise.
+func (u *AttendanceUsecase) parseAttendanceToken(tokenString string) (int, string, string, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(u.secretKey), nil
+	})
+	if err != nil || !token.Valid || claims["purpose"] != attendancePurpose {
+		return 0, "", "", domain.ErrInvalidAttendanceToken
+	}
+	activityID, activityOk := claims["activityId"].(float64)
+	action, actionOk := claims["action"].(string)
+	tokenID, tokenOk := claims["jti"].(string)
+	if !activityOk || !actionOk || !tokenOk || tokenID == "" {
+		return 0, "", "", domain.ErrInvalidAttendanceToken
+	}
+	return int(activityID), action, tokenID, nil
+}
+
+func toAttendance(attendance *domain.Attendance) dto.AttendanceDTO {
+	return dto.AttendanceDTO{
+		ID:           attendance.ID,
+		ActivityID:   attendance.ActivityID,
+		VolunteerID:  attendance.VolunteerID,
+		UserID:       attendance.UserID,
+		Name:         attendance.Name,
+		Surname:      attendance.Surname,
+		CheckedInAt:  attendance.CheckedInAt,
+		CheckedOutAt: attendance.CheckedOutAt,
+	}
+}
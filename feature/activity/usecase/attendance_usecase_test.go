@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttendanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttendanceRepository) FindActivityByID(id int) (*domain.Activity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Activity), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) FindVolunteerID(userID int) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) IsConfirmed(activityID int, volunteerID int) (bool, error) {
+	args := m.Called(activityID, volunteerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CheckIn(activityID int, volunteerID int, tokenID string, at time.Time) (*domain.Attendance, error) {
+	args := m.Called(activityID, volunteerID, tokenID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) CheckOut(activityID int, volunteerID int, tokenID string, at time.Time) (*domain.Attendance, error) {
+	args := m.Called(activityID, volunteerID, tokenID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) ListAttendance(activityID int) ([]*domain.Attendance, error) {
+	args := m.Called(activityID)
+	return args.Get(0).([]*domain.Attendance), args.Error(1)
+}
+
+func runningActivity() *domain.Activity {
+	return &domain.Activity{ID: 4, DepartmentID: 1, StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour)}
+}
+
+func TestAttendanceQR(t *testing.T) {
+	kitchen, garden := 1, 2
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+
+		mockRepo.On("FindActivityByID", 4).Return(runningActivity(), nil)
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+
+		png, err := usecase.AttendanceQR(7, 4, domain.AttendanceCheckIn)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG")))
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+
+		mockRepo.On("FindActivityByID", 4).Return(runningActivity(), nil)
+		departments.On("FindUserDepartment", 7).Return(&garden, nil)
+
+		_, err := usecase.AttendanceQR(7, 4, domain.AttendanceCheckIn)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	})
+
+	t.Run("check-in after the end", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+
+		ended := runningActivity()
+		ended.EndsAt = time.Now().Add(-time.Minute)
+		mockRepo.On("FindActivityByID", 4).Return(ended, nil)
+		departments.On("FindUserDepartment", 7).Return(nil, nil)
+		departments.On("HasAllDepartments", 7).Return(true, nil)
+
+		_, err := usecase.AttendanceQR(7, 4, domain.AttendanceCheckIn)
+		assert.ErrorIs(t, err, domain.ErrActivityEnded)
+
+		_, err = usecase.AttendanceQR(7, 4, domain.AttendanceCheckOut)
+		assert.NoError(t, err)
+	})
+}
+
+func TestScanAttendance(t *testing.T) {
+	t.Run("check in", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+		token, err := usecase.attendanceToken(4, domain.AttendanceCheckIn)
+		assert.NoError(t, err)
+
+		mockRepo.On("FindVolunteerID", 11).Return(3, nil)
+		mockRepo.On("FindActivityByID", 4).Return(runningActivity(), nil)
+		mockRepo.On("IsConfirmed", 4, 3).Return(true, nil)
+		mockRepo.On("CheckIn", 4, 3, mock.MatchedBy(func(tokenID string) bool { return len(tokenID) == 32 }), mock.Anything).
+			Return(&domain.Attendance{ID: 1, ActivityID: 4, VolunteerID: 3, CheckedInAt: time.Now()}, nil)
+
+		result, err := usecase.Scan(11, dto.ScanAttendanceDTO{Token: token})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.VolunteerID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+		token, err := usecase.attendanceToken(4, domain.AttendanceCheckOut)
+		assert.NoError(t, err)
+
+		mockRepo.On("FindVolunteerID", 11).Return(3, nil)
+		mockRepo.On("FindActivityByID", 4).Return(runningActivity(), nil)
+		mockRepo.On("IsConfirmed", 4, 3).Return(true, nil)
+		mockRepo.On("CheckOut", 4, 3, mock.Anything, mock.Anything).Return(nil, domain.ErrAttendanceTokenUsed)
+
+		_, err = usecase.Scan(11, dto.ScanAttendanceDTO{Token: token})
+		assert.ErrorIs(t, err, domain.ErrAttendanceTokenUsed)
+	})
+
+	t.Run("not confirmed", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+		token, err := usecase.attendanceToken(4, domain.AttendanceCheckIn)
+		assert.NoError(t, err)
+
+		mockRepo.On("FindVolunteerID", 11).Return(3, nil)
+		mockRepo.On("FindActivityByID", 4).Return(runningActivity(), nil)
+		mockRepo.On("IsConfirmed", 4, 3).Return(false, nil)
+
+		_, err = usecase.Scan(11, dto.ScanAttendanceDTO{Token: token})
+		assert.ErrorIs(t, err, domain.ErrNotConfirmed)
+		mockRepo.AssertNotCalled(t, "CheckIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		mockRepo := new(MockAttendanceRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", 10*time.Minute, "https://app.example.com/attendance")
+
+		expired, err := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "secret", -time.Minute, "").attendanceToken(4, domain.AttendanceCheckIn)
+		assert.NoError(t, err)
+		otherKey, err := NewAttendanceUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), "other", 10*time.Minute, "").attendanceToken(4, domain.AttendanceCheckIn)
+		assert.NoError(t, err)
+		accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"userId": 11, "roleId": 3, "sid": "s", "exp": time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte("secret"))
+		assert.NoError(t, err)
+
+		for _, token := range []string{expired, otherKey, accessToken, "garbage"} {
+			_, err := usecase.Scan(11, dto.ScanAttendanceDTO{Token: token})
+			assert.ErrorIs(t, err, domain.ErrInvalidAttendanceToken)
+		}
+		mockRepo.AssertNotCalled(t, "FindVolunteerID", mock.Anything)
+	})
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/storage"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// calendarHistory is how far back the feeds go.
+const calendarHistory = 90 * 24 * time.Hour
+
+// PermissionChecker reports whether a role was granted a permission.
+type PermissionChecker interface {
+	RoleHasPermission(roleId uint, permission string) (bool, error)
+}
+
+type CalendarUsecaseInterface interface {
+	CreateFeed(userID int) (*dto.CalendarFeedDTO, error)
+	RevokeFeed(userID int) error
+	VolunteerCalendar(token string) ([]byte, error)
+	DepartmentCalendar(token string, departmentID int) ([]byte, error)
+}
+
+// CalendarUsecase exports activities as iCalendar feeds calendar clients
+// subscribe to: the activities a volunteer signed up for, and those of a
+// department for its volunteers and coordinators.
+type CalendarUsecase struct {
+	CalendarRepo storage.CalendarRepositoryInterface
+	permissions  PermissionChecker
+	baseURL      string
+}
+
+func NewCalendarUsecase(calendarRepo storage.CalendarRepositoryInterface, permissions PermissionChecker, baseURL string) *CalendarUsecase {
+	return &CalendarUsecase{CalendarRepo: calendarRepo, permissions: permissions, baseURL: baseURL}
+}
+
+// CreateFeed gives the user a new feed token, the URLs using the previous one
+// stop working.
+func (u *CalendarUsecase) CreateFeed(userID int) (*dto.CalendarFeedDTO, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if err := u.CalendarRepo.SaveFeed(userID, hashFeedToken(token)); err != nil {
+		return nil, err
+	}
+	response := &dto.CalendarFeedDTO{
+		Token:        token,
+		VolunteerURL: fmt.Sprintf("%s/api/v1/calendar/me.ics?token=%s", u.baseURL, url.QueryEscape(token)),
+	}
+	if volunteer, err := u.CalendarRepo.FindVolunteer(userID); err == nil {
+		response.DepartmentURL = u.departmentURL(volunteer.DepartmentID, token)
+	}
+	return response, nil
+}
+
+func (u *CalendarUsecase) RevokeFeed(userID int) error {
+	return u.CalendarRepo.DeleteFeed(userID)
+}
+
+// VolunteerCalendar returns the activities the owner of the token signed up
+// for, waitlisted ones being tentative.
+func (u *CalendarUsecase) VolunteerCalendar(token string) ([]byte, error) {
+	user, err := u.CalendarRepo.FindFeedUser(hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+	volunteer, err := u.CalendarRepo.FindVolunteer(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := u.CalendarRepo.ListVolunteerEvents(volunteer.ID, time.Now().Add(-calendarHistory))
+	if err != nil {
+		return nil, err
+	}
+	return writeCalendar("My activities", u.uidDomain(), events, time.Now()), nil
+}
+
+// DepartmentCalendar returns the activities of the department to its active
+// volunteers and to the coordinators managing it.
+func (u *CalendarUsecase) DepartmentCalendar(token string, departmentID int) ([]byte, error) {
+	user, err := u.CalendarRepo.FindFeedUser(hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+	name, err := u.CalendarRepo.FindDepartmentName(departmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkDepartmentFeed(user.ID, user.RoleID, user.DepartmentID, departmentID); err != nil {
+		return nil, err
+	}
+	events, err := u.CalendarRepo.ListDepartmentEvents(departmentID, time.Now().Add(-calendarHistory))
+	if err != nil {
+		return nil, err
+	}
+	return writeCalendar(name+" activities", u.uidDomain(), events, time.Now()), nil
+}
+
+func (u *CalendarUsecase) checkDepartmentFeed(userID int, roleID int, userDepartment *int, departmentID int) error {
+	if userDepartment == nil || *userDepartment == departmentID {
+		manager, err := u.permissions.RoleHasPermission(uint(roleID), roleDomain.PermissionActivityManage)
+		if err != nil {
+			return err
+		}
+		if manager {
+			return nil
+		}
+	}
+	volunteer, err := u.CalendarRepo.FindVolunteer(userID)
+	if err != nil && !errors.Is(err, domain.ErrNotVolunteer) {
+		return err
+	}
+	if err == nil && volunteer.DepartmentID == departmentID && volunteer.Status == volunteerDomain.VolunteerStatusActive {
+		return nil
+	}
+	return domain.ErrFeedNotAllowed
+}
+
+func (u *CalendarUsecase) departmentURL(departmentID int, token string) string {
+	return fmt.Sprintf("%s/api/v1/calendar/departments/%d/activities.ics?token=%s", u.baseURL, departmentID, url.QueryEscape(token))
+}
+
+// uidDomain returns the host of the service, which keeps the event ids
+// unique across installations.
+func (u *CalendarUsecase) uidDomain() string {
+	if parsed, err := url.Parse(u.baseURL); err == nil && parsed.Hostname() != "" {
+		return parsed.Hostname()
+	}
+	return "onboarding-and-volunteer-service"
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
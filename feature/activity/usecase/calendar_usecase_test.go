@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarRepository) SaveFeed(userID int, tokenHash string) error {
+	args := m.Called(userID, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockCalendarRepository) DeleteFeed(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockCalendarRepository) FindFeedUser(tokenHash string) (*authDomain.User, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authDomain.User), args.Error(1)
+}
+
+func (m *MockCalendarRepository) FindVolunteer(userID int) (*volunteerDomain.Volunteer, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*volunteerDomain.Volunteer), args.Error(1)
+}
+
+func (m *MockCalendarRepository) FindDepartmentName(id int) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCalendarRepository) ListVolunteerEvents(volunteerID int, since time.Time) ([]*domain.CalendarEvent, error) {
+	args := m.Called(volunteerID, since)
+	return args.Get(0).([]*domain.CalendarEvent), args.Error(1)
+}
+
+func (m *MockCalendarRepository) ListDepartmentEvents(departmentID int, since time.Time) ([]*domain.CalendarEvent, error) {
+	args := m.Called(departmentID, since)
+	return args.Get(0).([]*domain.CalendarEvent), args.Error(1)
+}
+
+type MockPermissionChecker struct {
+	mock.Mock
+}
+
+func (m *MockPermissionChecker) RoleHasPermission(roleId uint, permission string) (bool, error) {
+	args := m.Called(roleId, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestWriteCalendar(t *testing.T) {
+	start := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	events := []*domain.CalendarEvent{{
+		ActivityID:     4,
+		Title:          "Beach cleanup, north side; bring gloves",
+		Description:    strings.Repeat("Meet at the pier. ", 6) + "\nThanks!",
+		Location:       "Beach",
+		DepartmentName: "Kitchen",
+		StartsAt:       start,
+		EndsAt:         start.Add(3 * time.Hour),
+		UpdatedAt:      start.Add(-time.Hour),
+		Status:         domain.SignupWaitlisted,
+	}}
+
+	calendar := string(writeCalendar("My activities", "volunteer.example.com", events, start))
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(calendar, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, calendar, "UID:activity-4@volunteer.example.com\r\n")
+	assert.Contains(t, calendar, "DTSTART:20300501T090000Z\r\nDTEND:20300501T120000Z\r\n")
+	assert.Contains(t, calendar, `SUMMARY:Beach cleanup\, north side\; bring gloves`)
+	assert.Contains(t, calendar, "STATUS:TENTATIVE\r\n")
+	for _, line := range strings.Split(calendar, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	assert.Contains(t, unfolded, `Meet at the pier. \nThanks!`)
+}
+
+func TestDepartmentCalendar(t *testing.T) {
+	kitchen, garden := 1, 2
+	token := "feed-token"
+
+	cases := []struct {
+		name       string
+		user       *authDomain.User
+		manager    bool
+		volunteer  *volunteerDomain.Volunteer
+		allowed    bool
+		department int
+	}{
+		{"coordinator of the department", &authDomain.User{ID: 7, RoleID: 1, DepartmentID: &kitchen}, true, nil, true, kitchen},
+		{"coordinator without department", &authDomain.User{ID: 7, RoleID: 1}, true, nil, true, garden},
+		{"coordinator of another department", &authDomain.User{ID: 7, RoleID: 1, DepartmentID: &garden}, true, nil, false, kitchen},
+		{"volunteer of the department", &authDomain.User{ID: 11, RoleID: 3}, false,
+			&volunteerDomain.Volunteer{ID: 3, DepartmentID: kitchen, Status: volunteerDomain.VolunteerStatusActive}, true, kitchen},
+		{"inactive volunteer", &authDomain.User{ID: 11, RoleID: 3}, false,
+			&volunteerDomain.Volunteer{ID: 3, DepartmentID: kitchen, Status: volunteerDomain.VolunteerStatusInactive}, false, kitchen},
+		{"volunteer of another department", &authDomain.User{ID: 11, RoleID: 3}, false,
+			&volunteerDomain.Volunteer{ID: 3, DepartmentID: garden, Status: volunteerDomain.VolunteerStatusActive}, false, kitchen},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockCalendarRepository)
+			permissions := new(MockPermissionChecker)
+			usecase := NewCalendarUsecase(mockRepo, permissions, "https://volunteer.example.com")
+
+			mockRepo.On("FindFeedUser", hashFeedToken(token)).Return(tc.user, nil)
+			mockRepo.On("FindDepartmentName", tc.department).Return("Kitchen", nil)
+			permissions.On("RoleHasPermission", uint(tc.user.RoleID), roleDomain.PermissionActivityManage).Return(tc.manager, nil)
+			if tc.volunteer != nil {
+				mockRepo.On("FindVolunteer", tc.user.ID).Return(tc.volunteer, nil)
+			} else {
+				mockRepo.On("FindVolunteer", tc.user.ID).Return(nil, domain.ErrNotVolunteer)
+			}
+			mockRepo.On("ListDepartmentEvents", tc.department, mock.Anything).Return([]*domain.CalendarEvent{}, nil)
+
+			calendar, err := usecase.DepartmentCalendar(token, tc.department)
+			if tc.allowed {
+				assert.NoError(t, err)
+				assert.Contains(t, string(calendar), "X-WR-CALNAME:Kitchen activities")
+			} else {
+				assert.ErrorIs(t, err, domain.ErrFeedNotAllowed)
+			}
+		})
+	}
+}
+
+func TestCreateFeed(t *testing.T) {
+	mockRepo := new(MockCalendarRepository)
+	usecase := NewCalendarUsecase(mockRepo, new(MockPermissionChecker), "https://volunteer.example.com")
+
+	mockRepo.On("SaveFeed", 11, mock.AnythingOfType("string")).Return(nil)
+	mockRepo.On("FindVolunteer", 11).Return(&volunteerDomain.Volunteer{ID: 3, DepartmentID: 2}, nil)
+
+	feed, err := usecase.CreateFeed(11)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://volunteer.example.com/api/v1/calendar/me.ics?token="+feed.Token, feed.VolunteerURL)
+	assert.Equal(t, "https://volunteer.example.com/api/v1/calendar/departments/2/activities.ics?token="+feed.Token, feed.DepartmentURL)
+	mockRepo.AssertCalled(t, "SaveFeed", 11, hashFeedToken(feed.Token))
+}
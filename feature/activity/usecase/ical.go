@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+)
+
+// icalTime is the UTC date-time format of iCalendar (RFC 5545).
+const icalTime = "20060102T150405Z"
+
+// writeCalendar renders the events as an iCalendar document. uidDomain makes
+// the event ids unique across services.
+func writeCalendar(name string, uidDomain string, events []*domain.CalendarEvent, now time.Time) []byte {
+	var b bytes.Buffer
+	line := func(property string, value string) {
+		writeFolded(&b, property+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Onboarding and Volunteer Service//Activities//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeText(name))
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("activity-%d@%s", event.ActivityID, uidDomain))
+		line("DTSTAMP", now.UTC().Format(icalTime))
+		line("DTSTART", event.StartsAt.UTC().Format(icalTime))
+		line("DTEND", event.EndsAt.UTC().Format(icalTime))
+		line("LAST-MODIFIED", event.UpdatedAt.UTC().Format(icalTime))
+		line("SUMMARY", escapeText(event.Title))
+		if event.Location != "" {
+			line("LOCATION", escapeText(event.Location))
+		}
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.DepartmentName != "" {
+			line("CATEGORIES", escapeText(event.DepartmentName))
+		}
+		if event.Status == domain.SignupWaitlisted {
+			line("STATUS", "TENTATIVE")
+		} else {
+			line("STATUS", "CONFIRMED")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return b.Bytes()
+}
+
+// writeFolded writes a content line ended by CRLF, folded so no line is
+// longer than 75 octets.
+func writeFolded(b *bytes.Buffer, content string) {
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// continuation lines start with a space
+		limit = 74
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(text string) string {
+	return icalEscaper.Replace(text)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// PasswordResetToken is a single-use token emailed to a user who forgot their
+// password. Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        int       `gorm:"primaryKey"`
+	UserID    int       `gorm:"index;not null"`
+	TokenHash string    `gorm:"unique;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// RefreshToken is a persisted, single-use refresh token. Every token issued
+// from the same login shares a FamilyID, which also identifies the session
+// carried by the access tokens ("sid" claim).
+type RefreshToken struct {
+	ID           int       `gorm:"primaryKey"`
+	UserID       int       `gorm:"index;not null"`
+	FamilyID     string    `gorm:"index;not null"`
+	TokenHash    string    `gorm:"unique;not null"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	RevokedAt    *time.Time
+	ReplacedByID *int
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
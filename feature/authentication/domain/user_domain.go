@@ -2,24 +2,32 @@ package domain
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// User is an account as seen by authentication. EmailVerifiedAt is set once
+// the user follows the link sent to their email and EmailVerificationSentAt
+// throttles resending that link. Soft deleted users cannot log in.
 type User struct {
-	ID                 int       `gorm:"primaryKey"`
-	RoleID             int       `gorm:"index"`
-	DepartmentID       *int      `gorm:"index"`
-	Email              string    `gorm:"unique;not null"`
-	Password           string    `gorm:"not null"`
-	Name               string    `gorm:"not null"`
-	Surname            string    `gorm:"not null"`
-	Gender             string    `gorm:"not null"`
-	Dob                time.Time `gorm:"not null"`
-	Mobile             string    `gorm:"not null"`
-	CountryID          int       `gorm:"index"`
-	ResidentCountryID  int       `gorm:"index"`
-	Avatar             *string
-	VerificationStatus int       `gorm:"default:0"`
-	Status             int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	ID                      int       `gorm:"primaryKey"`
+	RoleID                  int       `gorm:"index"`
+	DepartmentID            *int      `gorm:"index"`
+	Email                   string    `gorm:"unique;not null"`
+	Password                string    `gorm:"not null"`
+	Name                    string    `gorm:"not null"`
+	Surname                 string    `gorm:"not null"`
+	Gender                  string    `gorm:"not null"`
+	Dob                     time.Time `gorm:"not null"`
+	Mobile                  string    `gorm:"not null"`
+	CountryID               int       `gorm:"index"`
+	ResidentCountryID       int       `gorm:"index"`
+	Avatar                  *string
+	VerificationStatus      int `gorm:"default:0"`
+	Status                  int `gorm:"not null"`
+	EmailVerifiedAt         *time.Time
+	EmailVerificationSentAt *time.Time
+	CreatedAt               time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt               time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt               gorm.DeletedAt `gorm:"index"`
 }
@@ -25,7 +25,21 @@ type LoginUserResponse struct {
 }
 
 type LoginUserTokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutResponse struct {
+	Message string `json:"message"`
 }
 
 type RegisterUserRequest struct {
@@ -38,3 +52,47 @@ type RegisterUserRequest struct {
 type RegisterUserResponse struct {
 	Message string `json:"message"`
 }
+
+type VerifyEmailRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+type VerifyEmailResponse struct {
+	Message string `json:"message"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResendVerificationResponse struct {
+	Message string `json:"message"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+type ResetPasswordRequest struct {
+	Token      string `json:"token" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	RePassword string `json:"re_password" binding:"required,eqfield=Password"`
+}
+
+type ResetPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,nefield=OldPassword"`
+	RePassword  string `json:"re_password" binding:"required,eqfield=NewPassword"`
+}
+
+type ChangePasswordResponse struct {
+	Message string `json:"message"`
+}
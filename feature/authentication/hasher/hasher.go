@@ -0,0 +1,69 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+var (
+	ErrMismatchedPassword = errors.New("password is incorrect")
+	ErrUnknownAlgorithm   = errors.New("unknown password hashing algorithm")
+)
+
+// PasswordHasher hashes and verifies user passwords.
+type PasswordHasher interface {
+	// Hash returns the encoded hash of password.
+	Hash(password string) (string, error)
+	// Compare returns ErrMismatchedPassword if password does not match hashed.
+	Compare(hashed string, password string) error
+	// NeedsRehash reports whether hashed was produced by another algorithm
+	// or with weaker parameters than the ones currently configured.
+	NeedsRehash(hashed string) bool
+}
+
+// New returns the hasher for algorithm, falling back to bcrypt when empty.
+// Whatever the configured algorithm, the returned hasher can still verify
+// bcrypt, argon2id and legacy plaintext passwords so rows can be upgraded.
+func New(algorithm string) (PasswordHasher, error) {
+	switch strings.ToLower(strings.TrimSpace(algorithm)) {
+	case "", AlgorithmBcrypt:
+		return &multiHasher{current: NewBcryptHasher(0)}, nil
+	case AlgorithmArgon2id:
+		return &multiHasher{current: NewArgon2idHasher(DefaultArgon2idParams)}, nil
+	}
+	return nil, ErrUnknownAlgorithm
+}
+
+// multiHasher hashes with the current algorithm and verifies with whichever
+// algorithm produced the stored value.
+type multiHasher struct {
+	current PasswordHasher
+}
+
+func (h *multiHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+func (h *multiHasher) Compare(hashed string, password string) error {
+	switch {
+	case isBcryptHash(hashed):
+		return NewBcryptHasher(0).Compare(hashed, password)
+	case isArgon2idHash(hashed):
+		return NewArgon2idHasher(DefaultArgon2idParams).Compare(hashed, password)
+	}
+	// legacy rows stored the password verbatim
+	if subtle.ConstantTimeCompare([]byte(hashed), []byte(password)) != 1 {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+func (h *multiHasher) NeedsRehash(hashed string) bool {
+	return h.current.NeedsRehash(hashed)
+}
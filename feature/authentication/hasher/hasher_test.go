@@ -0,0 +1,84 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("defaults to bcrypt", func(t *testing.T) {
+		h, err := New("")
+		assert.NoError(t, err)
+
+		hashed, err := h.Hash("password123")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hashed, "$2a$"))
+	})
+
+	t.Run("argon2id", func(t *testing.T) {
+		h, err := New("argon2id")
+		assert.NoError(t, err)
+
+		hashed, err := h.Hash("password123")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=65536,t=3,p=2$"))
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		h, err := New("md5")
+		assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+		assert.Nil(t, h)
+	})
+}
+
+func TestCompare(t *testing.T) {
+	bcryptHasher, _ := New(AlgorithmBcrypt)
+	argonHasher, _ := New(AlgorithmArgon2id)
+
+	bcryptHash, _ := bcryptHasher.Hash("password123")
+	argonHash, _ := argonHasher.Hash("password123")
+
+	tests := []struct {
+		name     string
+		hashed   string
+		password string
+		wantErr  error
+	}{
+		{"bcrypt match", bcryptHash, "password123", nil},
+		{"bcrypt mismatch", bcryptHash, "wrong", ErrMismatchedPassword},
+		{"argon2id match", argonHash, "password123", nil},
+		{"argon2id mismatch", argonHash, "wrong", ErrMismatchedPassword},
+		{"legacy plaintext match", "password123", "password123", nil},
+		{"legacy plaintext mismatch", "password123", "wrong", ErrMismatchedPassword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// both hashers must verify every stored format
+			assert.ErrorIs(t, bcryptHasher.Compare(tt.hashed, tt.password), tt.wantErr)
+			assert.ErrorIs(t, argonHasher.Compare(tt.hashed, tt.password), tt.wantErr)
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptHasher, _ := New(AlgorithmBcrypt)
+	argonHasher, _ := New(AlgorithmArgon2id)
+
+	bcryptHash, _ := bcryptHasher.Hash("password123")
+	argonHash, _ := argonHasher.Hash("password123")
+	weakBcrypt, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	weakArgon, _ := NewArgon2idHasher(Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}).Hash("password123")
+
+	assert.False(t, bcryptHasher.NeedsRehash(bcryptHash))
+	assert.True(t, bcryptHasher.NeedsRehash(argonHash))
+	assert.True(t, bcryptHasher.NeedsRehash(string(weakBcrypt)))
+	assert.True(t, bcryptHasher.NeedsRehash("password123"))
+
+	assert.False(t, argonHasher.NeedsRehash(argonHash))
+	assert.True(t, argonHasher.NeedsRehash(bcryptHash))
+	assert.True(t, argonHasher.NeedsRehash(weakArgon))
+	assert.True(t, argonHasher.NeedsRehash("password123"))
+}
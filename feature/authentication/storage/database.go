@@ -1,9 +0,0 @@
-package storage
-
-import (
-	"os"
-)
-
-func GetSecretKey() string {
-	return os.Getenv("SECRET_KEY")
-}
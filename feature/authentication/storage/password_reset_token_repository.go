@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"gorm.io/gorm"
+)
+
+var ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid or expired")
+
+type PasswordResetTokenStore interface {
+	CreatePasswordResetToken(token *domain.PasswordResetToken, interval time.Duration) (bool, error)
+	ResetPassword(tokenHash string, password string) (int, error)
+}
+
+type PasswordResetTokenRepository struct {
+	db     *gorm.DB
+	hasher hasher.PasswordHasher
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB, hasher hasher.PasswordHasher) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{db: db, hasher: hasher}
+}
+
+// CreatePasswordResetToken stores a new token and invalidates the ones
+// previously issued to the same user, so only the latest email works. It
+// reports false, storing nothing, when an unused token that has not expired
+// was issued less than interval ago.
+func (r *PasswordResetTokenRepository) CreatePasswordResetToken(token *domain.PasswordResetToken, interval time.Duration) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var recent int64
+		if err := tx.Model(&domain.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL AND expires_at > ? AND created_at > ?", token.UserID, now, now.Add(-interval)).
+			Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent > 0 {
+			return nil
+		}
+		if err := tx.Model(&domain.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(token).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// ResetPassword consumes a token, stores the new password of its user and
+// revokes every session of that user, in one transaction: when a step fails
+// the token can still be used. It returns the id of the user, or
+// ErrPasswordResetTokenInvalid when the token is unknown, expired or was
+// already used, including by a concurrent request.
+func (r *PasswordResetTokenRepository) ResetPassword(tokenHash string, password string) (int, error) {
+	hashed, err := r.hasher.Hash(password)
+	if err != nil {
+		return 0, err
+	}
+	var token domain.PasswordResetToken
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPasswordResetTokenInvalid
+			}
+			return err
+		}
+		result := tx.Model(&domain.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPasswordResetTokenInvalid
+		}
+		if err := tx.Model(&domain.User{}).Where("id = ?", token.UserID).Update("password", hashed).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", token.UserID).
+			Update("revoked_at", now).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return token.UserID, nil
+}
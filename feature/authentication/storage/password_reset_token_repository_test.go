@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupPasswordResetDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.PasswordResetToken{}, &domain.User{}, &domain.RefreshToken{})
+	if err := db.Create(&domain.User{ID: 1, Email: "anna@example.com", Password: "old"}).Error; err != nil {
+		t.Fatalf("could not seed user: %v", err)
+	}
+	return db
+}
+
+func TestResetPassword(t *testing.T) {
+	db := setupPasswordResetDB(t)
+	passwordHasher := newTestHasher(t)
+	repo := NewPasswordResetTokenRepository(db, passwordHasher)
+	assert.NoError(t, db.Create(&domain.RefreshToken{UserID: 1, FamilyID: "session", TokenHash: "refresh", ExpiresAt: time.Now().Add(time.Hour)}).Error)
+
+	_, err := repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}, 0)
+	assert.NoError(t, err)
+
+	userID, err := repo.ResetPassword("hash", "new-password")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, userID)
+
+	var user domain.User
+	db.First(&user, 1)
+	assert.NoError(t, passwordHasher.Compare(user.Password, "new-password"))
+	var session domain.RefreshToken
+	db.Where("token_hash = ?", "refresh").First(&session)
+	assert.NotNil(t, session.RevokedAt)
+
+	// single use
+	_, err = repo.ResetPassword("hash", "other-password")
+	assert.ErrorIs(t, err, ErrPasswordResetTokenInvalid)
+
+	_, err = repo.ResetPassword("unknown", "other-password")
+	assert.ErrorIs(t, err, ErrPasswordResetTokenInvalid)
+}
+
+func TestResetPassword_Expired(t *testing.T) {
+	repo := NewPasswordResetTokenRepository(setupPasswordResetDB(t), newTestHasher(t))
+
+	_, err := repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "hash", ExpiresAt: time.Now().Add(-time.Minute)}, 0)
+	assert.NoError(t, err)
+
+	_, err = repo.ResetPassword("hash", "new-password")
+	assert.ErrorIs(t, err, ErrPasswordResetTokenInvalid)
+}
+
+func TestResetPassword_FailureKeepsToken(t *testing.T) {
+	db := setupPasswordResetDB(t)
+	repo := NewPasswordResetTokenRepository(db, newTestHasher(t))
+	_, err := repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}, 0)
+	assert.NoError(t, err)
+
+	// revoking the sessions fails, the whole reset is rolled back
+	assert.NoError(t, db.Migrator().DropTable(&domain.RefreshToken{}))
+	_, err = repo.ResetPassword("hash", "new-password")
+	assert.Error(t, err)
+
+	var user domain.User
+	db.First(&user, 1)
+	assert.Equal(t, "old", user.Password)
+
+	assert.NoError(t, db.AutoMigrate(&domain.RefreshToken{}))
+	_, err = repo.ResetPassword("hash", "new-password")
+	assert.NoError(t, err)
+}
+
+func TestCreatePasswordResetToken_InvalidatesPrevious(t *testing.T) {
+	repo := NewPasswordResetTokenRepository(setupPasswordResetDB(t), newTestHasher(t))
+
+	_, err := repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "first", ExpiresAt: time.Now().Add(time.Hour)}, 0)
+	assert.NoError(t, err)
+	_, err = repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "second", ExpiresAt: time.Now().Add(time.Hour)}, 0)
+	assert.NoError(t, err)
+
+	_, err = repo.ResetPassword("first", "new-password")
+	assert.ErrorIs(t, err, ErrPasswordResetTokenInvalid)
+	_, err = repo.ResetPassword("second", "new-password")
+	assert.NoError(t, err)
+}
+
+func TestCreatePasswordResetToken_Cooldown(t *testing.T) {
+	repo := NewPasswordResetTokenRepository(setupPasswordResetDB(t), newTestHasher(t))
+
+	created, err := repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "first", ExpiresAt: time.Now().Add(time.Hour)}, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	// within the cooldown the first link stays the valid one
+	created, err = repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "second", ExpiresAt: time.Now().Add(time.Hour)}, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	_, err = repo.ResetPassword("second", "new-password")
+	assert.ErrorIs(t, err, ErrPasswordResetTokenInvalid)
+
+	// a used token does not hold back a new one
+	_, err = repo.ResetPassword("first", "new-password")
+	assert.NoError(t, err)
+	created, err = repo.CreatePasswordResetToken(&domain.PasswordResetToken{UserID: 1, TokenHash: "third", ExpiresAt: time.Now().Add(time.Hour)}, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, created)
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"gorm.io/gorm"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+type RefreshTokenStore interface {
+	CreateRefreshToken(token *domain.RefreshToken) error
+	GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error)
+	RotateRefreshToken(old *domain.RefreshToken, next *domain.RefreshToken) error
+	RevokeFamily(familyID string) error
+	IsSessionActive(familyID string) (bool, error)
+}
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken stores next and marks old as replaced by it in a single
+// transaction. It returns ErrRefreshTokenReused when old was already revoked,
+// e.g. by a concurrent refresh with the same token.
+func (r *RefreshTokenRepository) RotateRefreshToken(old *domain.RefreshToken, next *domain.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by_id": next.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+		return nil
+	})
+}
+
+// RevokeFamily revokes every token of a session, which also invalidates the
+// access tokens issued for it.
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// IsSessionActive reports whether the session still has a live refresh token.
+func (r *RefreshTokenRepository) IsSessionActive(familyID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupRefreshTokenDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.RefreshToken{})
+	return db
+}
+
+func newRefreshToken(familyID, hash string) *domain.RefreshToken {
+	return &domain.RefreshToken{
+		UserID:    1,
+		FamilyID:  familyID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	repo := NewRefreshTokenRepository(setupRefreshTokenDB(t))
+
+	old := newRefreshToken("family", "hash-1")
+	assert.NoError(t, repo.CreateRefreshToken(old))
+
+	next := newRefreshToken("family", "hash-2")
+	assert.NoError(t, repo.RotateRefreshToken(old, next))
+
+	rotated, err := repo.GetRefreshTokenByHash("hash-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, rotated.RevokedAt)
+	assert.Equal(t, next.ID, *rotated.ReplacedByID)
+
+	active, err := repo.IsSessionActive("family")
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	t.Run("rotating an already rotated token", func(t *testing.T) {
+		err := repo.RotateRefreshToken(old, newRefreshToken("family", "hash-3"))
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+		// the losing rotation must not leave a usable token behind
+		_, err = repo.GetRefreshTokenByHash("hash-3")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestRevokeFamily(t *testing.T) {
+	repo := NewRefreshTokenRepository(setupRefreshTokenDB(t))
+
+	assert.NoError(t, repo.CreateRefreshToken(newRefreshToken("family", "hash-1")))
+	assert.NoError(t, repo.CreateRefreshToken(newRefreshToken("other", "hash-2")))
+
+	assert.NoError(t, repo.RevokeFamily("family"))
+
+	active, err := repo.IsSessionActive("family")
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	active, err = repo.IsSessionActive("other")
+	assert.NoError(t, err)
+	assert.True(t, active)
+}
+
+func TestIsSessionActive_Expired(t *testing.T) {
+	repo := NewRefreshTokenRepository(setupRefreshTokenDB(t))
+
+	token := newRefreshToken("family", "hash-1")
+	token.ExpiresAt = time.Now().Add(-time.Minute)
+	assert.NoError(t, repo.CreateRefreshToken(token))
+
+	active, err := repo.IsSessionActive("family")
+	assert.NoError(t, err)
+	assert.False(t, active)
+}
@@ -1,23 +1,55 @@
 package storage
 
 import (
+	"errors"
+	"log"
+	"time"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/env"
 	"gorm.io/gorm"
 )
 
+// Unverified login policies, see env.GetUnverifiedLoginPolicy.
+const (
+	UnverifiedLoginAllow = "allow"
+	UnverifiedLoginGrace = "grace"
+	UnverifiedLoginDeny  = "deny"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
 type AuthenticationStore interface {
 	GetUserByEmail(email string, password string) (*domain.User, string)
+	GetUserByID(id int) (*domain.User, error)
+	FindUserByEmail(email string) (*domain.User, error)
 	RegisterUser(request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error)
+	CheckPassword(userID int, password string) error
+	ChangePassword(userID int, password string, keepFamilyID string) error
+	MarkEmailVerified(userID int) error
+	MarkVerificationEmailSent(userID int, interval time.Duration) (bool, error)
 }
 
 type AuthenticationRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	hasher hasher.PasswordHasher
+	// unverifiedLogin and unverifiedGrace decide whether users who have not
+	// verified their email may log in.
+	unverifiedLogin string
+	unverifiedGrace time.Duration
 }
 
-func NewAuthenticationRepository(db *gorm.DB) *AuthenticationRepository {
-	return &AuthenticationRepository{db: db}
+func NewAuthenticationRepository(db *gorm.DB, hasher hasher.PasswordHasher) *AuthenticationRepository {
+	return &AuthenticationRepository{
+		db:              db,
+		hasher:          hasher,
+		unverifiedLogin: env.GetUnverifiedLoginPolicy(),
+		unverifiedGrace: env.GetUnverifiedLoginGrace(),
+	}
 }
+
 func (r *AuthenticationRepository) GetUserByEmail(email string, password string) (*domain.User, string) {
 	var user domain.User
 	err := r.db.Where("email = ?", email).First(&user).Error
@@ -27,17 +59,66 @@ func (r *AuthenticationRepository) GetUserByEmail(email string, password string)
 	if user.Status == 0 {
 		return nil, "User is inactive"
 	}
-	if user.Password != password {
+	if err := r.hasher.Compare(user.Password, password); err != nil {
 		return nil, "Password is incorrect"
 	}
+	// upgrade legacy plaintext rows and outdated hashes on successful login
+	if r.hasher.NeedsRehash(user.Password) {
+		if err := r.rehashPassword(&user, password); err != nil {
+			log.Printf("rehash password for user %d: %v", user.ID, err)
+		}
+	}
+	if !r.mayLoginUnverified(&user) {
+		return nil, "Email is not verified"
+	}
 	return &user, ""
 }
 
+// mayLoginUnverified applies the unverified login policy to a user whose
+// password has been checked.
+func (r *AuthenticationRepository) mayLoginUnverified(user *domain.User) bool {
+	if user.EmailVerifiedAt != nil {
+		return true
+	}
+	switch r.unverifiedLogin {
+	case UnverifiedLoginDeny:
+		return false
+	case UnverifiedLoginGrace:
+		return time.Since(user.CreatedAt) <= r.unverifiedGrace
+	}
+	return true
+}
+
+func (r *AuthenticationRepository) GetUserByID(id int) (*domain.User, error) {
+	var user domain.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindUserByEmail returns the user with the given email, or ErrUserNotFound.
+func (r *AuthenticationRepository) FindUserByEmail(email string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
+	hashed, err := r.hasher.Hash(request.Password)
+	if err != nil {
+		return nil, err
+	}
 	user := domain.User{
 		Email:    request.Email,
 		Name:     request.Name,
-		Password: request.Password,
+		Password: hashed,
 		Status:   1,
 	}
 
@@ -50,3 +131,68 @@ func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest
 	}
 	return response, nil
 }
+
+// CheckPassword returns hasher.ErrMismatchedPassword if password is not the
+// password of the user.
+func (r *AuthenticationRepository) CheckPassword(userID int, password string) error {
+	user, err := r.GetUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return r.hasher.Compare(user.Password, password)
+}
+
+// ChangePassword stores a new password for the user and revokes every session
+// of that user except keepFamilyID, in one transaction: when a step fails the
+// old password and sessions stay valid.
+func (r *AuthenticationRepository) ChangePassword(userID int, password string, keepFamilyID string) error {
+	hashed, err := r.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.User{}).Where("id = ?", userID).Update("password", hashed).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.RefreshToken{}).
+			Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, keepFamilyID).
+			Update("revoked_at", time.Now()).Error
+	})
+}
+
+func (r *AuthenticationRepository) rehashPassword(user *domain.User, password string) error {
+	hashed, err := r.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	if err := r.db.Model(&domain.User{}).Where("id = ?", user.ID).Update("password", hashed).Error; err != nil {
+		return err
+	}
+	user.Password = hashed
+	return nil
+}
+
+// MarkEmailVerified records that the user verified their email. Verifying
+// twice keeps the first date.
+func (r *AuthenticationRepository) MarkEmailVerified(userID int) error {
+	return r.db.Model(&domain.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", time.Now()).Error
+}
+
+// MarkVerificationEmailSent records that a verification email is being sent
+// to the user. It reports false, recording nothing, when the previous one was
+// sent less than interval ago.
+func (r *AuthenticationRepository) MarkVerificationEmailSent(userID int, interval time.Duration) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND (email_verification_sent_at IS NULL OR email_verification_sent_at <= ?)", userID, now.Add(-interval)).
+		Update("email_verification_sent_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -2,10 +2,15 @@ package storage
 
 import (
 	"errors"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -18,7 +23,8 @@ func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
 	}
 
 	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
 	}), &gorm.Config{})
 	if err != nil {
 		return nil, nil, err
@@ -27,18 +33,29 @@ func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
 	return gormDB, mock, nil
 }
 
+func newTestHasher(t *testing.T) hasher.PasswordHasher {
+	h, err := hasher.New(hasher.AlgorithmBcrypt)
+	assert.NoError(t, err)
+	return h
+}
+
+var selectUserByEmail = regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?")
+
 func TestGetUserByEmail(t *testing.T) {
 	db, mock, err := setupMockDB()
 	assert.NoError(t, err)
 	defer db.DB()
 
-	repo := NewAuthenticationRepository(db)
+	passwordHasher := newTestHasher(t)
+	hashed, err := passwordHasher.Hash("password123")
+	assert.NoError(t, err)
+	repo := NewAuthenticationRepository(db, passwordHasher)
 
 	t.Run("successful retrieval", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
-			AddRow(1, "test@example.com", "password123", 1)
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("test@example.com").
+			AddRow(1, "test@example.com", hashed, 1)
+		mock.ExpectQuery(selectUserByEmail).
+			WithArgs("test@example.com", 1).
 			WillReturnRows(rows)
 
 		user, errMsg := repo.GetUserByEmail("test@example.com", "password123")
@@ -48,8 +65,8 @@ func TestGetUserByEmail(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("unknown@example.com").
+		mock.ExpectQuery(selectUserByEmail).
+			WithArgs("unknown@example.com", 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
 		user, errMsg := repo.GetUserByEmail("unknown@example.com", "password123")
@@ -59,9 +76,9 @@ func TestGetUserByEmail(t *testing.T) {
 
 	t.Run("inactive user", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
-			AddRow(1, "inactive@example.com", "password123", 0)
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("inactive@example.com").
+			AddRow(1, "inactive@example.com", hashed, 0)
+		mock.ExpectQuery(selectUserByEmail).
+			WithArgs("inactive@example.com", 1).
 			WillReturnRows(rows)
 
 		user, errMsg := repo.GetUserByEmail("inactive@example.com", "password123")
@@ -71,15 +88,35 @@ func TestGetUserByEmail(t *testing.T) {
 
 	t.Run("incorrect password", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
-			AddRow(1, "test@example.com", "password123", 1)
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("test@example.com").
+			AddRow(1, "test@example.com", hashed, 1)
+		mock.ExpectQuery(selectUserByEmail).
+			WithArgs("test@example.com", 1).
 			WillReturnRows(rows)
 
 		user, errMsg := repo.GetUserByEmail("test@example.com", "wrongpassword")
 		assert.Equal(t, "Password is incorrect", errMsg)
 		assert.Nil(t, user)
 	})
+
+	t.Run("legacy plaintext password is rehashed", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
+			AddRow(2, "legacy@example.com", "password123", 1)
+		mock.ExpectQuery(selectUserByEmail).
+			WithArgs("legacy@example.com", 1).
+			WillReturnRows(rows)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password`=?,`updated_at`=? WHERE id = ? AND `users`.`deleted_at` IS NULL")).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		user, errMsg := repo.GetUserByEmail("legacy@example.com", "password123")
+		assert.Empty(t, errMsg)
+		assert.NotNil(t, user)
+		assert.NoError(t, passwordHasher.Compare(user.Password, "password123"))
+		assert.False(t, passwordHasher.NeedsRehash(user.Password))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestRegisterUser(t *testing.T) {
@@ -87,7 +124,7 @@ func TestRegisterUser(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.DB()
 
-	repo := NewAuthenticationRepository(db)
+	repo := NewAuthenticationRepository(db, newTestHasher(t))
 
 	t.Run("successful registration", func(t *testing.T) {
 		mock.ExpectBegin()
@@ -122,3 +159,91 @@ func TestRegisterUser(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func setupUserDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.User{})
+	return db
+}
+
+func TestMarkVerificationEmailSent(t *testing.T) {
+	db := setupUserDB(t)
+	repo := NewAuthenticationRepository(db, newTestHasher(t))
+	user := &domain.User{Email: "test@example.com", Password: "x", Status: 1}
+	assert.NoError(t, db.Create(user).Error)
+
+	sent, err := repo.MarkVerificationEmailSent(user.ID, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, sent)
+
+	// asked again within the interval
+	sent, err = repo.MarkVerificationEmailSent(user.ID, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, sent)
+
+	// the interval has passed
+	db.Model(user).Update("email_verification_sent_at", time.Now().Add(-2*time.Minute))
+	sent, err = repo.MarkVerificationEmailSent(user.ID, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, sent)
+}
+
+func TestGetUserByEmail_UnverifiedPolicy(t *testing.T) {
+	db := setupUserDB(t)
+	passwordHasher := newTestHasher(t)
+	hashed, err := passwordHasher.Hash("password123")
+	assert.NoError(t, err)
+	user := &domain.User{Email: "test@example.com", Password: hashed, Status: 1}
+	assert.NoError(t, db.Create(user).Error)
+	repo := NewAuthenticationRepository(db, passwordHasher)
+
+	repo.unverifiedLogin = UnverifiedLoginAllow
+	found, msg := repo.GetUserByEmail(user.Email, "password123")
+	assert.Empty(t, msg)
+	assert.NotNil(t, found)
+
+	repo.unverifiedLogin = UnverifiedLoginGrace
+	repo.unverifiedGrace = time.Hour
+	_, msg = repo.GetUserByEmail(user.Email, "password123")
+	assert.Empty(t, msg)
+
+	db.Model(user).Update("created_at", time.Now().Add(-2*time.Hour))
+	_, msg = repo.GetUserByEmail(user.Email, "password123")
+	assert.Equal(t, "Email is not verified", msg)
+
+	repo.unverifiedLogin = UnverifiedLoginDeny
+	_, msg = repo.GetUserByEmail(user.Email, "password123")
+	assert.Equal(t, "Email is not verified", msg)
+
+	assert.NoError(t, repo.MarkEmailVerified(user.ID))
+	found, msg = repo.GetUserByEmail(user.Email, "password123")
+	assert.Empty(t, msg)
+	assert.NotNil(t, found.EmailVerifiedAt)
+
+	_, err = repo.FindUserByEmail("unknown@example.com")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestChangePassword(t *testing.T) {
+	db := testdb.Open(t, &domain.User{}, &domain.RefreshToken{})
+	repo := NewAuthenticationRepository(db, newTestHasher(t))
+	tokenRepo := NewRefreshTokenRepository(db)
+	user := &domain.User{Email: "test@example.com", Password: "old-password", Status: 1}
+	assert.NoError(t, db.Create(user).Error)
+	assert.NoError(t, tokenRepo.CreateRefreshToken(newRefreshToken("current", "hash-1")))
+	assert.NoError(t, tokenRepo.CreateRefreshToken(newRefreshToken("other", "hash-2")))
+	someoneElse := newRefreshToken("someone-else", "hash-3")
+	someoneElse.UserID = 2
+	assert.NoError(t, tokenRepo.CreateRefreshToken(someoneElse))
+
+	assert.NoError(t, repo.CheckPassword(user.ID, "old-password"))
+	assert.NoError(t, repo.ChangePassword(user.ID, "new-password", "current"))
+
+	assert.ErrorIs(t, repo.CheckPassword(user.ID, "old-password"), hasher.ErrMismatchedPassword)
+	assert.NoError(t, repo.CheckPassword(user.ID, "new-password"))
+	assert.ErrorIs(t, repo.CheckPassword(user.ID+1, "new-password"), ErrUserNotFound)
+	for family, want := range map[string]bool{"current": true, "other": false, "someone-else": true} {
+		active, err := tokenRepo.IsSessionActive(family)
+		assert.NoError(t, err)
+		assert.Equal(t, want, active, family)
+	}
+}
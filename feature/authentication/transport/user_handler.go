@@ -63,3 +63,187 @@ func (h *AuthenticationHandler) Register(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a rotated refresh token
+// @Produce json
+// @Tags authentication
+// @Param refreshTokenRequest body dto.RefreshTokenRequest true "Refresh Token Request"
+// @Success 200 {object} dto.LoginUserTokenResponse{}
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthenticationHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.Refresh(req)
+	if msg != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the session of the given refresh token
+// @Produce json
+// @Tags authentication
+// @Param logoutRequest body dto.LogoutRequest true "Logout Request"
+// @Success 200 {object} dto.LogoutResponse{}
+// @Router /api/v1/auth/logout [post]
+func (h *AuthenticationHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.Logout(req)
+	if msg != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Verify the email of an account with the token from the link sent by email
+// @Produce json
+// @Tags authentication
+// @Param token query string true "Verification token"
+// @Success 200 {object} dto.VerifyEmailResponse{}
+// @Router /api/v1/auth/verify-email [get]
+func (h *AuthenticationHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.VerifyEmail(req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResendVerificationEmail godoc
+// @Summary Resend verification email
+// @Description Send the email verification link again. Answers 429 when asked again too soon.
+// @Produce json
+// @Tags authentication
+// @Param resendVerificationRequest body dto.ResendVerificationRequest true "Resend Verification Request"
+// @Success 200 {object} dto.ResendVerificationResponse{}
+// @Router /api/v1/auth/verify-email/resend [post]
+func (h *AuthenticationHandler) ResendVerificationEmail(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.ResendVerificationEmail(req)
+	if msg == usecase.MsgVerificationThrottled {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": msg})
+		return
+	}
+	if msg != "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ForgotPassword godoc
+// @Summary Forgot password
+// @Description Email a single-use link to reset the password of an account
+// @Produce json
+// @Tags authentication
+// @Param forgotPasswordRequest body dto.ForgotPasswordRequest true "Forgot Password Request"
+// @Success 200 {object} dto.ForgotPasswordResponse{}
+// @Router /api/v1/auth/forgot-password [post]
+func (h *AuthenticationHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.ForgotPassword(req)
+	if msg != "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token from the reset email. Every session of the account is revoked.
+// @Produce json
+// @Tags authentication
+// @Param resetPasswordRequest body dto.ResetPasswordRequest true "Reset Password Request"
+// @Success 200 {object} dto.ResetPasswordResponse{}
+// @Router /api/v1/auth/reset-password [post]
+func (h *AuthenticationHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.ResetPassword(req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ChangePassword godoc
+// @Summary Change my password
+// @Description Change the password of the authenticated user. Every other session is revoked.
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param changePasswordRequest body dto.ChangePasswordRequest true "Change Password Request"
+// @Success 200 {object} dto.ChangePasswordResponse{}
+// @Router /api/v1/me/password [put]
+func (h *AuthenticationHandler) ChangePassword(c *gin.Context) {
+	userId := c.GetInt("userId")
+	sessionId := c.GetString("sessionId")
+	if userId == 0 || sessionId == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.ChangePassword(userId, sessionId, req)
+	if msg == usecase.MsgOldPasswordIncorrect {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if msg != "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
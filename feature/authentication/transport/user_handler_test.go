@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,6 +34,63 @@ func (m *MockUserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.Regist
 	}
 	return nil, args.String(1)
 }
+
+func (m *MockUserUsecase) Refresh(req dto.RefreshTokenRequest) (*dto.LoginUserTokenResponse, string) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.LoginUserTokenResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
+func (m *MockUserUsecase) Logout(req dto.LogoutRequest) (*dto.LogoutResponse, string) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.LogoutResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
+func (m *MockUserUsecase) VerifyEmail(req dto.VerifyEmailRequest) (*dto.VerifyEmailResponse, string) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.VerifyEmailResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
+func (m *MockUserUsecase) ResendVerificationEmail(req dto.ResendVerificationRequest) (*dto.ResendVerificationResponse, string) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ResendVerificationResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
+func (m *MockUserUsecase) ForgotPassword(req dto.ForgotPasswordRequest) (*dto.ForgotPasswordResponse, string) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ForgotPasswordResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
+func (m *MockUserUsecase) ResetPassword(req dto.ResetPasswordRequest) (*dto.ResetPasswordResponse, string) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ResetPasswordResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
+func (m *MockUserUsecase) ChangePassword(userID int, sessionID string, req dto.ChangePasswordRequest) (*dto.ChangePasswordResponse, string) {
+	args := m.Called(userID, sessionID, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ChangePasswordResponse), args.String(1)
+	}
+	return nil, args.String(1)
+}
+
 func TestAuthenticationHandler_Login(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockUsecase := new(MockUserUsecase)
@@ -111,8 +169,10 @@ func TestAuthenticationHandler_Register(t *testing.T) {
 
 	t.Run("successful registration", func(t *testing.T) {
 		registerReq := dto.RegisterUserRequest{
-			Email:    "test@example.com",
-			Password: "password",
+			Email:      "test@example.com",
+			Name:       "Test",
+			Password:   "password",
+			RePassword: "password",
 		}
 		registerResp := &dto.RegisterUserResponse{
 
@@ -150,8 +210,10 @@ func TestAuthenticationHandler_Register(t *testing.T) {
 
 	t.Run("register with existing user", func(t *testing.T) {
 		registerReq := dto.RegisterUserRequest{
-			Email:    "test@example.com",
-			Password: "password",
+			Email:      "taken@example.com",
+			Name:       "Taken",
+			Password:   "password",
+			RePassword: "password",
 		}
 		mockUsecase.On("RegisterUser", registerReq).Return(nil, "user already exists")
 
@@ -169,3 +231,188 @@ func TestAuthenticationHandler_Register(t *testing.T) {
 		assert.Equal(t, "user already exists", response["error"])
 	})
 }
+
+func TestAuthenticationHandler_Refresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.POST("/api/v1/auth/refresh", handler.Refresh)
+
+	t.Run("successful refresh", func(t *testing.T) {
+		refreshReq := dto.RefreshTokenRequest{RefreshToken: "valid"}
+		refreshResp := &dto.LoginUserTokenResponse{Token: "access", RefreshToken: "rotated", ExpiresIn: 900}
+		mockUsecase.On("Refresh", refreshReq).Return(refreshResp, "")
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(refreshReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.LoginUserTokenResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, *refreshResp, response)
+	})
+
+	t.Run("reused refresh token", func(t *testing.T) {
+		refreshReq := dto.RefreshTokenRequest{RefreshToken: "reused"}
+		mockUsecase.On("Refresh", refreshReq).Return(nil, "Refresh token reused, session revoked")
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(refreshReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("missing refresh token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBuffer([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthenticationHandler_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.POST("/api/v1/auth/logout", handler.Logout)
+
+	logoutReq := dto.LogoutRequest{RefreshToken: "valid"}
+	mockUsecase.On("Logout", logoutReq).Return(&dto.LogoutResponse{Message: "Logout success"}, "")
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(logoutReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/logout", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Logout success")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthenticationHandler_VerifyEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.GET("/api/v1/auth/verify-email", handler.VerifyEmail)
+
+	mockUsecase.On("VerifyEmail", dto.VerifyEmailRequest{Token: "valid"}).Return(&dto.VerifyEmailResponse{Message: "Email verified"}, "")
+	mockUsecase.On("VerifyEmail", dto.VerifyEmailRequest{Token: "expired"}).Return(nil, "Invalid or expired verification link")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/auth/verify-email?token=valid", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/auth/verify-email?token=expired", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/auth/verify-email", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAuthenticationHandler_ResendVerificationEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.POST("/api/v1/auth/verify-email/resend", handler.ResendVerificationEmail)
+
+	resendReq := dto.ResendVerificationRequest{Email: "test@example.com"}
+	mockUsecase.On("ResendVerificationEmail", resendReq).Return(nil, usecase.MsgVerificationThrottled)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(resendReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/verify-email/resend", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthenticationHandler_ResetPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.POST("/api/v1/auth/reset-password", handler.ResetPassword)
+
+	t.Run("passwords do not match", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := `{"token":"raw","password":"one","re_password":"two"}`
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/reset-password", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "ResetPassword", mock.Anything)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resetReq := dto.ResetPasswordRequest{Token: "raw", Password: "new", RePassword: "new"}
+		mockUsecase.On("ResetPassword", resetReq).Return(&dto.ResetPasswordResponse{Message: "Password has been reset"}, "")
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(resetReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/reset-password", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestAuthenticationHandler_ChangePassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.PUT("/api/v1/me/password", func(c *gin.Context) {
+		c.Set("userId", 7)
+		c.Set("sessionId", "current")
+		handler.ChangePassword(c)
+	})
+
+	changeReq := dto.ChangePasswordRequest{OldPassword: "old", NewPassword: "new", RePassword: "new"}
+	mockUsecase.On("ChangePassword", 7, "current", changeReq).Return(nil, usecase.MsgOldPasswordIncorrect)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(changeReq)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/me/password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
@@ -1,61 +1,392 @@
 package usecase
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/env"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// MsgVerificationThrottled is returned when a verification email is asked
+// for again before the resend interval has passed.
+const MsgVerificationThrottled = "Verification email sent too recently, try again later"
+
+// MsgOldPasswordIncorrect is returned when changing the password with a wrong
+// current password.
+const MsgOldPasswordIncorrect = "Old password is incorrect"
+
+// emailVerificationPurpose marks verification tokens so they cannot be
+// confused with access tokens signed with the same key.
+const emailVerificationPurpose = "email_verification"
+
+var errVerificationThrottled = errors.New("verification email sent too recently")
+
 type UserUsecaseInterface interface {
 	Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string)
 	RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string)
+	Refresh(req dto.RefreshTokenRequest) (*dto.LoginUserTokenResponse, string)
+	Logout(req dto.LogoutRequest) (*dto.LogoutResponse, string)
+	VerifyEmail(req dto.VerifyEmailRequest) (*dto.VerifyEmailResponse, string)
+	ResendVerificationEmail(req dto.ResendVerificationRequest) (*dto.ResendVerificationResponse, string)
+	ForgotPassword(req dto.ForgotPasswordRequest) (*dto.ForgotPasswordResponse, string)
+	ResetPassword(req dto.ResetPasswordRequest) (*dto.ResetPasswordResponse, string)
+	ChangePassword(userID int, sessionID string, req dto.ChangePasswordRequest) (*dto.ChangePasswordResponse, string)
 }
 
 type UserUsecase struct {
-	repo      storage.AuthenticationStore
-	secretKey string
+	repo                 storage.AuthenticationStore
+	tokenRepo            storage.RefreshTokenStore
+	resetTokenRepo       storage.PasswordResetTokenStore
+	mailer               mailer.Mailer
+	secretKey            string
+	baseURL              string
+	passwordResetURL     string
+	accessTokenTTL       time.Duration
+	refreshTokenTTL      time.Duration
+	emailVerificationTTL time.Duration
+	resendInterval       time.Duration
+	passwordResetTTL     time.Duration
+	resetInterval        time.Duration
 }
 
-func NewUserUsecase(repo storage.AuthenticationStore, secretKey string) *UserUsecase {
+func NewUserUsecase(repo storage.AuthenticationStore, tokenRepo storage.RefreshTokenStore, resetTokenRepo storage.PasswordResetTokenStore, mailer mailer.Mailer, secretKey string) *UserUsecase {
 	return &UserUsecase{
-		repo:      repo,
-		secretKey: secretKey,
+		repo:                 repo,
+		tokenRepo:            tokenRepo,
+		resetTokenRepo:       resetTokenRepo,
+		mailer:               mailer,
+		secretKey:            secretKey,
+		baseURL:              env.GetAppBaseURL(),
+		passwordResetURL:     env.GetPasswordResetURL(),
+		accessTokenTTL:       env.GetAccessTokenTTL(),
+		refreshTokenTTL:      env.GetRefreshTokenTTL(),
+		emailVerificationTTL: env.GetEmailVerificationTTL(),
+		resendInterval:       env.GetEmailVerificationResendInterval(),
+		passwordResetTTL:     env.GetPasswordResetTTL(),
+		resetInterval:        env.GetPasswordResetResendInterval(),
 	}
 }
+
 func (u *UserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string) {
 	user, msg := u.repo.GetUserByEmail(req.Email, req.Password)
 	if user != nil {
-		claims := jwt.MapClaims{
-			"userId": user.ID,
-			"roleId": user.RoleID,
-			"exp":    time.Now().Add(time.Hour * 72).Unix(),
+		familyID, err := RandomToken(16)
+		if err != nil {
+			return nil, "Could not generate token"
 		}
-
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-		tokenString, err := token.SignedString([]byte(u.secretKey))
+		refreshToken, token, err := u.newRefreshToken(user.ID, familyID)
 		if err != nil {
 			return nil, "Could not generate token"
 		}
-		return &dto.LoginUserTokenResponse{
-			Token: tokenString,
-		}, ""
+		if err := u.tokenRepo.CreateRefreshToken(token); err != nil {
+			return nil, "Could not generate token"
+		}
+		return u.issueTokens(user, familyID, refreshToken)
 	}
 	return nil, msg
 }
 
 func (u *UserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string) {
 	// check existed user
-	user, _ := u.repo.GetUserByEmail(req.Email, "")
+	user, err := u.repo.FindUserByEmail(req.Email)
 	if user != nil {
 		return nil, "User existed"
 	}
+	if !errors.Is(err, storage.ErrUserNotFound) {
+		return nil, "Register failed"
+	}
 	// register user
 	registerUser, err := u.repo.RegisterUser(&req)
 	if err != nil {
 		return nil, "Register failed"
 	}
 
+	// the account exists even if the email cannot be sent, the user can ask
+	// for another one
+	if user, err = u.repo.FindUserByEmail(req.Email); err == nil {
+		err = u.sendVerificationEmail(user)
+	}
+	if err != nil {
+		log.Printf("send verification email to %s: %v", req.Email, err)
+	}
+
 	return registerUser, ""
 }
+
+// VerifyEmail marks the email of the user a verification link was sent to as
+// verified. The link is refused once expired or if the user changed email
+// since it was sent.
+func (u *UserUsecase) VerifyEmail(req dto.VerifyEmailRequest) (*dto.VerifyEmailResponse, string) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(req.Token, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(u.secretKey), nil
+	})
+	if err != nil || !token.Valid || claims["purpose"] != emailVerificationPurpose {
+		return nil, "Invalid or expired verification link"
+	}
+	userID, userOk := claims["userId"].(float64)
+	email, emailOk := claims["email"].(string)
+	if !userOk || !emailOk {
+		return nil, "Invalid or expired verification link"
+	}
+
+	user, err := u.repo.GetUserByID(int(userID))
+	if err != nil || user.Email != email {
+		return nil, "Invalid or expired verification link"
+	}
+	if user.EmailVerifiedAt == nil {
+		if err := u.repo.MarkEmailVerified(user.ID); err != nil {
+			return nil, "Could not verify email"
+		}
+	}
+	return &dto.VerifyEmailResponse{Message: "Email verified"}, ""
+}
+
+// ResendVerificationEmail sends a new verification link, at most once per
+// resend interval. Unknown and already verified addresses get the same answer
+// so the endpoint cannot be used to find out who has an account.
+func (u *UserUsecase) ResendVerificationEmail(req dto.ResendVerificationRequest) (*dto.ResendVerificationResponse, string) {
+	resp := &dto.ResendVerificationResponse{Message: "If the account exists and is not verified yet, a verification email has been sent"}
+	user, err := u.repo.FindUserByEmail(req.Email)
+	if errors.Is(err, storage.ErrUserNotFound) {
+		return resp, ""
+	}
+	if err != nil {
+		return nil, "Could not send verification email"
+	}
+	if user.EmailVerifiedAt != nil {
+		return resp, ""
+	}
+
+	err = u.sendVerificationEmail(user)
+	if errors.Is(err, errVerificationThrottled) {
+		return nil, MsgVerificationThrottled
+	}
+	if err != nil {
+		return nil, "Could not send verification email"
+	}
+	return resp, ""
+}
+
+// Refresh exchanges a refresh token for a new access/refresh token pair.
+// Presenting a token that was already rotated or revoked is treated as
+// theft and revokes the whole session.
+func (u *UserUsecase) Refresh(req dto.RefreshTokenRequest) (*dto.LoginUserTokenResponse, string) {
+	current, err := u.tokenRepo.GetRefreshTokenByHash(HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, "Invalid refresh token"
+	}
+	if current.RevokedAt != nil {
+		if err := u.tokenRepo.RevokeFamily(current.FamilyID); err != nil {
+			return nil, "Could not revoke session"
+		}
+		return nil, "Refresh token reused, session revoked"
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, "Refresh token expired"
+	}
+
+	user, err := u.repo.GetUserByID(current.UserID)
+	if err != nil || user.Status == 0 {
+		_ = u.tokenRepo.RevokeFamily(current.FamilyID)
+		return nil, "User is inactive"
+	}
+
+	refreshToken, next, err := u.newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, "Could not generate token"
+	}
+	if err := u.tokenRepo.RotateRefreshToken(current, next); err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenReused) {
+			_ = u.tokenRepo.RevokeFamily(current.FamilyID)
+			return nil, "Refresh token reused, session revoked"
+		}
+		return nil, "Could not generate token"
+	}
+	return u.issueTokens(user, current.FamilyID, refreshToken)
+}
+
+// Logout revokes the session the refresh token belongs to.
+func (u *UserUsecase) Logout(req dto.LogoutRequest) (*dto.LogoutResponse, string) {
+	current, err := u.tokenRepo.GetRefreshTokenByHash(HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, "Invalid refresh token"
+	}
+	if err := u.tokenRepo.RevokeFamily(current.FamilyID); err != nil {
+		return nil, "Could not revoke session"
+	}
+	return &dto.LogoutResponse{Message: "Logout success"}, ""
+}
+
+// ForgotPassword emails a single-use password reset link, at most one per
+// resend interval. Unknown and inactive accounts get the same answer so the
+// endpoint cannot be used to find out who has an account.
+func (u *UserUsecase) ForgotPassword(req dto.ForgotPasswordRequest) (*dto.ForgotPasswordResponse, string) {
+	resp := &dto.ForgotPasswordResponse{Message: "If the account exists, a password reset email has been sent"}
+	user, err := u.repo.FindUserByEmail(req.Email)
+	if errors.Is(err, storage.ErrUserNotFound) {
+		return resp, ""
+	}
+	if err != nil {
+		return nil, "Could not send password reset email"
+	}
+	if user.Status == 0 {
+		return resp, ""
+	}
+
+	raw, err := RandomToken(32)
+	if err != nil {
+		return nil, "Could not send password reset email"
+	}
+	token := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(u.passwordResetTTL),
+	}
+	created, err := u.resetTokenRepo.CreatePasswordResetToken(token, u.resetInterval)
+	if err != nil {
+		return nil, "Could not send password reset email"
+	}
+	if !created {
+		// a link was sent moments ago, do not flood the inbox
+		return resp, ""
+	}
+	msg, err := mailer.Render(user.Email, mailer.TemplateResetPassword, mailer.LinkData{
+		Name:      user.Name,
+		Link:      fmt.Sprintf("%s?token=%s", u.passwordResetURL, url.QueryEscape(raw)),
+		ExpiresIn: u.passwordResetTTL.String(),
+	})
+	if err != nil {
+		return nil, "Could not send password reset email"
+	}
+	if err := u.mailer.Send(msg); err != nil {
+		return nil, "Could not send password reset email"
+	}
+	return resp, ""
+}
+
+// ResetPassword sets a new password with a reset token and logs the user out
+// of every session.
+func (u *UserUsecase) ResetPassword(req dto.ResetPasswordRequest) (*dto.ResetPasswordResponse, string) {
+	_, err := u.resetTokenRepo.ResetPassword(HashToken(req.Token), req.Password)
+	if errors.Is(err, storage.ErrPasswordResetTokenInvalid) {
+		return nil, "Invalid or expired reset token"
+	}
+	if err != nil {
+		return nil, "Could not reset password"
+	}
+	return &dto.ResetPasswordResponse{Message: "Password has been reset"}, ""
+}
+
+// ChangePassword replaces the password of a logged in user and revokes every
+// other session, keeping the one the request was made with.
+func (u *UserUsecase) ChangePassword(userID int, sessionID string, req dto.ChangePasswordRequest) (*dto.ChangePasswordResponse, string) {
+	err := u.repo.CheckPassword(userID, req.OldPassword)
+	if errors.Is(err, hasher.ErrMismatchedPassword) {
+		return nil, MsgOldPasswordIncorrect
+	}
+	if err != nil {
+		return nil, "Could not change password"
+	}
+	if err := u.repo.ChangePassword(userID, req.NewPassword, sessionID); err != nil {
+		return nil, "Could not change password"
+	}
+	return &dto.ChangePasswordResponse{Message: "Password changed"}, ""
+}
+
+func (u *UserUsecase) issueTokens(user *domain.User, familyID string, refreshToken string) (*dto.LoginUserTokenResponse, string) {
+	claims := jwt.MapClaims{
+		"userId": user.ID,
+		"roleId": user.RoleID,
+		"sid":    familyID,
+		"iat":    time.Now().Unix(),
+		"exp":    time.Now().Add(u.accessTokenTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(u.secretKey))
+	if err != nil {
+		return nil, "Could not generate token"
+	}
+	return &dto.LoginUserTokenResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(u.accessTokenTTL.Seconds()),
+	}, ""
+}
+
+func (u *UserUsecase) sendVerificationEmail(user *domain.User) error {
+	sent, err := u.repo.MarkVerificationEmailSent(user.ID, u.resendInterval)
+	if err != nil {
+		return err
+	}
+	if !sent {
+		return errVerificationThrottled
+	}
+
+	claims := jwt.MapClaims{
+		"userId":  user.ID,
+		"email":   user.Email,
+		"purpose": emailVerificationPurpose,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(u.emailVerificationTTL).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(u.secretKey))
+	if err != nil {
+		return err
+	}
+	msg, err := mailer.Render(user.Email, mailer.TemplateVerifyEmail, mailer.LinkData{
+		Name:      user.Name,
+		Link:      fmt.Sprintf("%s/api/v1/auth/verify-email?token=%s", u.baseURL, url.QueryEscape(token)),
+		ExpiresIn: u.emailVerificationTTL.String(),
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(msg)
+}
+
+func (u *UserUsecase) newRefreshToken(userID int, familyID string) (string, *domain.RefreshToken, error) {
+	raw, err := RandomToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+	return raw, &domain.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(u.refreshTokenTTL),
+	}, nil
+}
+
+// RandomToken returns size random bytes encoded for use in URLs.
+func RandomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken is what gets persisted, so a database leak does not expose usable
+// tokens. Other features issuing tokens redeemed here must hash with it too.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,11 +1,17 @@
 package usecase
 
 import (
+	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +30,14 @@ func (m *MockAuthenticationStore) GetUserByEmail(email, password string) (*domai
 	return nil, args.String(1)
 }
 
+func (m *MockAuthenticationStore) GetUserByID(id int) (*domain.User, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockAuthenticationStore) RegisterUser(req *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
 	args := m.Called(req)
 	if args.Get(0) != nil {
@@ -32,26 +46,115 @@ func (m *MockAuthenticationStore) RegisterUser(req *dto.RegisterUserRequest) (*d
 	return nil, args.Error(1)
 }
 
+func (m *MockAuthenticationStore) FindUserByEmail(email string) (*domain.User, error) {
+	args := m.Called(email)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthenticationStore) MarkEmailVerified(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthenticationStore) MarkVerificationEmailSent(userID int, interval time.Duration) (bool, error) {
+	args := m.Called(userID, interval)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAuthenticationStore) CheckPassword(userID int, password string) error {
+	args := m.Called(userID, password)
+	return args.Error(0)
+}
+
+func (m *MockAuthenticationStore) ChangePassword(userID int, password string, keepFamilyID string) error {
+	args := m.Called(userID, password, keepFamilyID)
+	return args.Error(0)
+}
+
+// MockPasswordResetTokenStore is a mock implementation of the PasswordResetTokenStore interface
+type MockPasswordResetTokenStore struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetTokenStore) CreatePasswordResetToken(token *domain.PasswordResetToken, interval time.Duration) (bool, error) {
+	args := m.Called(token, interval)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPasswordResetTokenStore) ResetPassword(tokenHash string, password string) (int, error) {
+	args := m.Called(tokenHash, password)
+	return args.Int(0), args.Error(1)
+}
+
+// MockMailer is a mock implementation of the Mailer interface
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(msg mailer.Message) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
+
+// MockRefreshTokenStore is a mock implementation of the RefreshTokenStore interface
+type MockRefreshTokenStore struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenStore) CreateRefreshToken(token *domain.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenStore) GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.RefreshToken), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRefreshTokenStore) RotateRefreshToken(old *domain.RefreshToken, next *domain.RefreshToken) error {
+	args := m.Called(old, next)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenStore) RevokeFamily(familyID string) error {
+	args := m.Called(familyID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenStore) IsSessionActive(familyID string) (bool, error) {
+	args := m.Called(familyID)
+	return args.Bool(0), args.Error(1)
+}
+
 func TestUserUsecase_Login(t *testing.T) {
 	mockRepo := new(MockAuthenticationStore)
+	mockTokenRepo := new(MockRefreshTokenStore)
 	secretKey := "secret"
-	usecase := NewUserUsecase(mockRepo, secretKey)
+	usecase := NewUserUsecase(mockRepo, mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
 
 	req := dto.LoginUserRequest{
 		Email:    "test@example.com",
 		Password: "password",
 	}
-	mockUser := domain.User{
+	mockUser := &domain.User{
 		ID:     123,
 		RoleID: 456,
 	}
 	mockRepo.On("GetUserByEmail", req.Email, req.Password).Return(mockUser, "")
+	mockTokenRepo.On("CreateRefreshToken", mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
 
 	resp, msg := usecase.Login(req)
 
 	assert.Equal(t, "", msg)
 	assert.NotNil(t, resp)
 	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
 
 	claims := jwt.MapClaims{}
 	_, err := jwt.ParseWithClaims(resp.Token, claims, func(token *jwt.Token) (interface{}, error) {
@@ -59,29 +162,391 @@ func TestUserUsecase_Login(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	assert.Equal(t, mockUser.ID, claims["userId"])
-	assert.Equal(t, mockUser.RoleID, claims["roleId"])
-	assert.True(t, claims.VerifyExpiresAt(time.Now().Add(time.Hour*72).Unix(), true))
+	assert.Equal(t, float64(mockUser.ID), claims["userId"])
+	assert.Equal(t, float64(mockUser.RoleID), claims["roleId"])
+	assert.True(t, claims.VerifyExpiresAt(time.Now().Unix(), true))
+	assert.False(t, claims.VerifyExpiresAt(time.Now().Add(time.Hour).Unix(), true))
+
+	stored := mockTokenRepo.Calls[0].Arguments.Get(0).(*domain.RefreshToken)
+	assert.Equal(t, claims["sid"], stored.FamilyID)
+	assert.Equal(t, HashToken(resp.RefreshToken), stored.TokenHash)
+	assert.Equal(t, mockUser.ID, stored.UserID)
 }
 
 func TestUserUsecase_RegisterUser(t *testing.T) {
 	mockRepo := new(MockAuthenticationStore)
+	mockMailer := new(MockMailer)
 	secretKey := "secret"
-	usecase := NewUserUsecase(mockRepo, secretKey)
+	usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), mockMailer, secretKey)
 
 	req := dto.RegisterUserRequest{
 		Email:    "test@example.com",
 		Password: "password",
 	}
-	mockRepo.On("GetUserByEmail", req.Email, "").Return(nil, "")
+	mockRepo.On("FindUserByEmail", req.Email).Return(nil, storage.ErrUserNotFound).Once()
 	mockResponse := &dto.RegisterUserResponse{
 		Message: req.Email,
 	}
 	mockRepo.On("RegisterUser", &req).Return(mockResponse, nil)
+	mockRepo.On("FindUserByEmail", req.Email).Return(&domain.User{ID: 7, Email: req.Email}, nil)
+	mockRepo.On("MarkVerificationEmailSent", 7, usecase.resendInterval).Return(true, nil)
+	mockMailer.On("Send", mock.AnythingOfType("mailer.Message")).Return(nil)
 
 	resp, msg := usecase.RegisterUser(req)
 
 	assert.Equal(t, "", msg)
 	assert.NotNil(t, resp)
 	assert.Equal(t, mockResponse, resp)
+
+	sent := mockMailer.Calls[0].Arguments.Get(0).(mailer.Message)
+	assert.Equal(t, req.Email, sent.To)
+	assert.Contains(t, sent.Body, "/api/v1/auth/verify-email?token=")
+}
+
+func TestUserUsecase_RegisterUser_Existing(t *testing.T) {
+	mockRepo := new(MockAuthenticationStore)
+	usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), new(MockMailer), "secret")
+
+	mockRepo.On("FindUserByEmail", "test@example.com").Return(&domain.User{ID: 7}, nil)
+
+	resp, msg := usecase.RegisterUser(dto.RegisterUserRequest{Email: "test@example.com"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, "User existed", msg)
+	mockRepo.AssertNotCalled(t, "RegisterUser", mock.Anything)
+}
+
+// linkToken extracts the token from the link of an email.
+func linkToken(t *testing.T, msg mailer.Message) string {
+	_, link, found := strings.Cut(msg.Body, "token=")
+	assert.True(t, found)
+	token, err := url.QueryUnescape(strings.Fields(link)[0])
+	assert.NoError(t, err)
+	return token
+}
+
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	secretKey := "secret"
+	user := &domain.User{ID: 7, Email: "test@example.com"}
+
+	sendLink := func(t *testing.T, ttl time.Duration) string {
+		mockRepo := new(MockAuthenticationStore)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), mockMailer, secretKey)
+		usecase.emailVerificationTTL = ttl
+		mockRepo.On("MarkVerificationEmailSent", user.ID, mock.Anything).Return(true, nil)
+		mockMailer.On("Send", mock.Anything).Return(nil)
+
+		assert.NoError(t, usecase.sendVerificationEmail(user))
+		return linkToken(t, mockMailer.Calls[0].Arguments.Get(0).(mailer.Message))
+	}
+
+	t.Run("valid link", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+		mockRepo.On("GetUserByID", user.ID).Return(&domain.User{ID: user.ID, Email: user.Email}, nil)
+		mockRepo.On("MarkEmailVerified", user.ID).Return(nil)
+
+		resp, msg := usecase.VerifyEmail(dto.VerifyEmailRequest{Token: sendLink(t, time.Hour)})
+
+		assert.Equal(t, "", msg)
+		assert.Equal(t, "Email verified", resp.Message)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("email changed since", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+		mockRepo.On("GetUserByID", user.ID).Return(&domain.User{ID: user.ID, Email: "new@example.com"}, nil)
+
+		resp, msg := usecase.VerifyEmail(dto.VerifyEmailRequest{Token: sendLink(t, time.Hour)})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Invalid or expired verification link", msg)
+		mockRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		usecase := NewUserUsecase(new(MockAuthenticationStore), new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		resp, msg := usecase.VerifyEmail(dto.VerifyEmailRequest{Token: sendLink(t, -time.Minute)})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Invalid or expired verification link", msg)
+	})
+
+	t.Run("access token is not a verification link", func(t *testing.T) {
+		accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"userId": user.ID,
+			"roleId": 1,
+			"sid":    "session",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(secretKey))
+		assert.NoError(t, err)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		resp, msg := usecase.VerifyEmail(dto.VerifyEmailRequest{Token: accessToken})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Invalid or expired verification link", msg)
+	})
+}
+
+func TestUserUsecase_ResendVerificationEmail(t *testing.T) {
+	req := dto.ResendVerificationRequest{Email: "test@example.com"}
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), mockMailer, "secret")
+		mockRepo.On("FindUserByEmail", req.Email).Return(nil, storage.ErrUserNotFound)
+
+		resp, msg := usecase.ResendVerificationEmail(req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), mockMailer, "secret")
+		mockRepo.On("FindUserByEmail", req.Email).Return(&domain.User{ID: 7, Email: req.Email}, nil)
+		mockRepo.On("MarkVerificationEmailSent", 7, usecase.resendInterval).Return(false, nil)
+
+		resp, msg := usecase.ResendVerificationEmail(req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, MsgVerificationThrottled, msg)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("sent", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), mockMailer, "secret")
+		mockRepo.On("FindUserByEmail", req.Email).Return(&domain.User{ID: 7, Email: req.Email}, nil)
+		mockRepo.On("MarkVerificationEmailSent", 7, usecase.resendInterval).Return(true, nil)
+		mockMailer.On("Send", mock.Anything).Return(nil)
+
+		resp, msg := usecase.ResendVerificationEmail(req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		mockMailer.AssertNumberOfCalls(t, "Send", 1)
+	})
+}
+
+func TestUserUsecase_Refresh(t *testing.T) {
+	secretKey := "secret"
+	user := &domain.User{ID: 123, RoleID: 456, Status: 1}
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockTokenRepo := new(MockRefreshTokenStore)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		current := &domain.RefreshToken{ID: 1, UserID: user.ID, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+		mockTokenRepo.On("GetRefreshTokenByHash", HashToken("refresh")).Return(current, nil)
+		mockRepo.On("GetUserByID", user.ID).Return(user, nil)
+		mockTokenRepo.On("RotateRefreshToken", current, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		resp, msg := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Equal(t, "", msg)
+		assert.NotEmpty(t, resp.Token)
+		assert.NotEqual(t, "refresh", resp.RefreshToken)
+
+		next := mockTokenRepo.Calls[1].Arguments.Get(1).(*domain.RefreshToken)
+		assert.Equal(t, "family", next.FamilyID)
+		assert.Equal(t, HashToken(resp.RefreshToken), next.TokenHash)
+	})
+
+	t.Run("reused token revokes the family", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockTokenRepo := new(MockRefreshTokenStore)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		revokedAt := time.Now()
+		current := &domain.RefreshToken{ID: 1, UserID: user.ID, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+		mockTokenRepo.On("GetRefreshTokenByHash", HashToken("refresh")).Return(current, nil)
+		mockTokenRepo.On("RevokeFamily", "family").Return(nil)
+
+		resp, msg := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Refresh token reused, session revoked", msg)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent rotation revokes the family", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockTokenRepo := new(MockRefreshTokenStore)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		current := &domain.RefreshToken{ID: 1, UserID: user.ID, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+		mockTokenRepo.On("GetRefreshTokenByHash", HashToken("refresh")).Return(current, nil)
+		mockRepo.On("GetUserByID", user.ID).Return(user, nil)
+		mockTokenRepo.On("RotateRefreshToken", current, mock.AnythingOfType("*domain.RefreshToken")).Return(storage.ErrRefreshTokenReused)
+		mockTokenRepo.On("RevokeFamily", "family").Return(nil)
+
+		resp, msg := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Refresh token reused, session revoked", msg)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		mockTokenRepo := new(MockRefreshTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		current := &domain.RefreshToken{ID: 1, UserID: user.ID, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Minute)}
+		mockTokenRepo.On("GetRefreshTokenByHash", HashToken("refresh")).Return(current, nil)
+
+		resp, msg := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Refresh token expired", msg)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockTokenRepo := new(MockRefreshTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), secretKey)
+
+		mockTokenRepo.On("GetRefreshTokenByHash", HashToken("refresh")).Return(nil, errors.New("record not found"))
+
+		resp, msg := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Invalid refresh token", msg)
+	})
+}
+
+func TestUserUsecase_Logout(t *testing.T) {
+	mockTokenRepo := new(MockRefreshTokenStore)
+	usecase := NewUserUsecase(new(MockAuthenticationStore), mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), "secret")
+
+	current := &domain.RefreshToken{ID: 1, UserID: 123, FamilyID: "family"}
+	mockTokenRepo.On("GetRefreshTokenByHash", HashToken("refresh")).Return(current, nil)
+	mockTokenRepo.On("RevokeFamily", "family").Return(nil)
+
+	resp, msg := usecase.Logout(dto.LogoutRequest{RefreshToken: "refresh"})
+
+	assert.Equal(t, "", msg)
+	assert.Equal(t, "Logout success", resp.Message)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_ForgotPassword(t *testing.T) {
+	req := dto.ForgotPasswordRequest{Email: "test@example.com"}
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockResetRepo := new(MockPasswordResetTokenStore)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), mockResetRepo, new(MockMailer), "secret")
+		mockRepo.On("FindUserByEmail", req.Email).Return(nil, storage.ErrUserNotFound)
+
+		resp, msg := usecase.ForgotPassword(req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		mockResetRepo.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("sends a single-use link", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockResetRepo := new(MockPasswordResetTokenStore)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), mockResetRepo, mockMailer, "secret")
+		mockRepo.On("FindUserByEmail", req.Email).Return(&domain.User{ID: 7, Email: req.Email, Status: 1}, nil)
+		mockResetRepo.On("CreatePasswordResetToken", mock.AnythingOfType("*domain.PasswordResetToken"), time.Minute).Return(true, nil)
+		mockMailer.On("Send", mock.Anything).Return(nil)
+
+		resp, msg := usecase.ForgotPassword(req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		stored := mockResetRepo.Calls[0].Arguments.Get(0).(*domain.PasswordResetToken)
+		sent := mockMailer.Calls[0].Arguments.Get(0).(mailer.Message)
+		assert.Equal(t, 7, stored.UserID)
+		assert.Equal(t, req.Email, sent.To)
+		// only the hash of the emailed token is stored
+		assert.Equal(t, HashToken(linkToken(t, sent)), stored.TokenHash)
+	})
+
+	t.Run("link sent moments ago", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockResetRepo := new(MockPasswordResetTokenStore)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), mockResetRepo, mockMailer, "secret")
+		mockRepo.On("FindUserByEmail", req.Email).Return(&domain.User{ID: 7, Email: req.Email, Status: 1}, nil)
+		mockResetRepo.On("CreatePasswordResetToken", mock.AnythingOfType("*domain.PasswordResetToken"), time.Minute).Return(false, nil)
+
+		resp, msg := usecase.ForgotPassword(req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	req := dto.ResetPasswordRequest{Token: "raw", Password: "new-password", RePassword: "new-password"}
+
+	t.Run("valid token", func(t *testing.T) {
+		mockResetRepo := new(MockPasswordResetTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), new(MockRefreshTokenStore), mockResetRepo, new(MockMailer), "secret")
+		mockResetRepo.On("ResetPassword", HashToken("raw"), "new-password").Return(7, nil)
+
+		resp, msg := usecase.ResetPassword(req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		mockResetRepo.AssertExpectations(t)
+	})
+
+	t.Run("used or expired token", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockResetRepo := new(MockPasswordResetTokenStore)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), mockResetRepo, new(MockMailer), "secret")
+		mockResetRepo.On("ResetPassword", HashToken("raw"), "new-password").Return(0, storage.ErrPasswordResetTokenInvalid)
+
+		resp, msg := usecase.ResetPassword(req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, "Invalid or expired reset token", msg)
+		mockRepo.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ChangePassword(t *testing.T) {
+	req := dto.ChangePasswordRequest{OldPassword: "old", NewPassword: "new", RePassword: "new"}
+
+	t.Run("keeps the current session", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		mockTokenRepo := new(MockRefreshTokenStore)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, new(MockPasswordResetTokenStore), new(MockMailer), "secret")
+		mockRepo.On("CheckPassword", 7, "old").Return(nil)
+		mockRepo.On("ChangePassword", 7, "new", "current").Return(nil)
+
+		resp, msg := usecase.ChangePassword(7, "current", req)
+
+		assert.Equal(t, "", msg)
+		assert.NotNil(t, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong old password", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		usecase := NewUserUsecase(mockRepo, new(MockRefreshTokenStore), new(MockPasswordResetTokenStore), new(MockMailer), "secret")
+		mockRepo.On("CheckPassword", 7, "old").Return(hasher.ErrMismatchedPassword)
+
+		resp, msg := usecase.ChangePassword(7, "current", req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, MsgOldPasswordIncorrect, msg)
+		mockRepo.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
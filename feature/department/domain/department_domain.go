@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
@@ -13,3 +14,7 @@ type Department struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// ErrOutsideDepartment is returned when a user who belongs to a department
+// acts on another one.
+var ErrOutsideDepartment = errors.New("department is outside the one you manage")
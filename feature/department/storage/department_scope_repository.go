@@ -0,0 +1,47 @@
+package storage
+
+import (
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"gorm.io/gorm"
+)
+
+// DepartmentScopeRepositoryInterface finds the department a user belongs to
+// and whether they may act on every department.
+type DepartmentScopeRepositoryInterface interface {
+	FindUserDepartment(userID int) (*int, error)
+	HasAllDepartments(userID int) (bool, error)
+}
+
+type DepartmentScopeRepository struct {
+	DB *gorm.DB
+}
+
+func NewDepartmentScopeRepository(db *gorm.DB) *DepartmentScopeRepository {
+	return &DepartmentScopeRepository{DB: db}
+}
+
+// FindUserDepartment returns the department of the user, nil when they do not
+// belong to one.
+func (r *DepartmentScopeRepository) FindUserDepartment(userID int) (*int, error) {
+	var user authDomain.User
+	if err := r.DB.Select("id", "department_id").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	return user.DepartmentID, nil
+}
+
+// HasAllDepartments reports whether the role of the user has been granted the
+// department:all permission.
+func (r *DepartmentScopeRepository) HasAllDepartments(userID int) (bool, error) {
+	var count int64
+	err := r.DB.Model(&authDomain.User{}).
+		Joins("JOIN role_permissions ON role_permissions.role_id = users.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("users.id = ? AND permissions.name = ?", userID, roleDomain.PermissionDepartmentAll).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
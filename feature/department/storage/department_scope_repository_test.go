@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+)
+
+func TestHasAllDepartments(t *testing.T) {
+	db := testdb.Open(t, &authDomain.User{}, &roleDomain.Permission{}, &roleDomain.RolePermission{})
+	permission := &roleDomain.Permission{Name: roleDomain.PermissionDepartmentAll}
+	assert.NoError(t, db.Create(permission).Error)
+	assert.NoError(t, db.Create(&roleDomain.RolePermission{RoleId: 1, PermissionId: permission.Id}).Error)
+	admin := &authDomain.User{Email: "admin@example.com", Password: "hash", Name: "Anna", Surname: "Smith", Gender: "other", Mobile: "123", Status: 1, RoleID: 1}
+	coordinator := &authDomain.User{Email: "coordinator@example.com", Password: "hash", Name: "Bob", Surname: "Smith", Gender: "other", Mobile: "123", Status: 1, RoleID: 2}
+	assert.NoError(t, db.Create(admin).Error)
+	assert.NoError(t, db.Create(coordinator).Error)
+	repo := NewDepartmentScopeRepository(db)
+
+	all, err := repo.HasAllDepartments(admin.ID)
+	assert.NoError(t, err)
+	assert.True(t, all)
+
+	all, err = repo.HasAllDepartments(coordinator.ID)
+	assert.NoError(t, err)
+	assert.False(t, all)
+}
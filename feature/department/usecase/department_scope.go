@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/storage"
+)
+
+// DepartmentScopeInterface limits coordinators who belong to a department to
+// that department. Those who do not belong to one act on every department when
+// their role has the department:all permission, and on none otherwise.
+type DepartmentScopeInterface interface {
+	CheckDepartment(userID int, departmentID int) error
+	ScopeDepartment(userID int, departmentID *int) (*int, error)
+}
+
+type DepartmentScope struct {
+	Repo storage.DepartmentScopeRepositoryInterface
+}
+
+func NewDepartmentScope(repo storage.DepartmentScopeRepositoryInterface) *DepartmentScope {
+	return &DepartmentScope{Repo: repo}
+}
+
+// CheckDepartment returns ErrOutsideDepartment when the user may not act on
+// the given department.
+func (s *DepartmentScope) CheckDepartment(userID int, departmentID int) error {
+	_, err := s.ScopeDepartment(userID, &departmentID)
+	return err
+}
+
+// ScopeDepartment returns the department the user may look at: theirs when
+// they belong to a department, the requested one, possibly nil for all, when
+// they may act on every department. Asking for another department, or not
+// belonging to one without department:all, gives ErrOutsideDepartment.
+func (s *DepartmentScope) ScopeDepartment(userID int, departmentID *int) (*int, error) {
+	userDepartment, err := s.Repo.FindUserDepartment(userID)
+	if err != nil {
+		return nil, err
+	}
+	if userDepartment == nil {
+		all, err := s.Repo.HasAllDepartments(userID)
+		if err != nil {
+			return nil, err
+		}
+		if !all {
+			return nil, domain.ErrOutsideDepartment
+		}
+		return departmentID, nil
+	}
+	if departmentID != nil && *departmentID != *userDepartment {
+		return nil, domain.ErrOutsideDepartment
+	}
+	return userDepartment, nil
+}
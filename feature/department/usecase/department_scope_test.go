@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDepartmentScopeRepository struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentScopeRepository) FindUserDepartment(userID int) (*int, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockDepartmentScopeRepository) HasAllDepartments(userID int) (bool, error) {
+	args := m.Called(userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestScopeDepartment(t *testing.T) {
+	kitchen, garden := 1, 2
+	repo := new(MockDepartmentScopeRepository)
+	repo.On("FindUserDepartment", 7).Return(&kitchen, nil)
+	repo.On("FindUserDepartment", 8).Return(nil, nil)
+	repo.On("HasAllDepartments", 8).Return(true, nil)
+	repo.On("FindUserDepartment", 9).Return(nil, errors.New("record not found"))
+	repo.On("FindUserDepartment", 10).Return(nil, nil)
+	repo.On("HasAllDepartments", 10).Return(false, nil)
+	scope := NewDepartmentScope(repo)
+
+	t.Run("member", func(t *testing.T) {
+		departmentID, err := scope.ScopeDepartment(7, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, &kitchen, departmentID)
+
+		departmentID, err = scope.ScopeDepartment(7, &kitchen)
+		assert.NoError(t, err)
+		assert.Equal(t, &kitchen, departmentID)
+
+		_, err = scope.ScopeDepartment(7, &garden)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	})
+
+	t.Run("all departments", func(t *testing.T) {
+		departmentID, err := scope.ScopeDepartment(8, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, departmentID)
+
+		departmentID, err = scope.ScopeDepartment(8, &garden)
+		assert.NoError(t, err)
+		assert.Equal(t, &garden, departmentID)
+	})
+
+	t.Run("no department", func(t *testing.T) {
+		_, err := scope.ScopeDepartment(10, nil)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+
+		_, err = scope.ScopeDepartment(10, &garden)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := scope.ScopeDepartment(9, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckDepartment(t *testing.T) {
+	kitchen, garden := 1, 2
+	repo := new(MockDepartmentScopeRepository)
+	repo.On("FindUserDepartment", 7).Return(&kitchen, nil)
+	repo.On("FindUserDepartment", 8).Return(nil, nil)
+	repo.On("HasAllDepartments", 8).Return(true, nil)
+	repo.On("FindUserDepartment", 10).Return(nil, nil)
+	repo.On("HasAllDepartments", 10).Return(false, nil)
+	scope := NewDepartmentScope(repo)
+
+	assert.NoError(t, scope.CheckDepartment(7, kitchen))
+	assert.ErrorIs(t, scope.CheckDepartment(7, garden), domain.ErrOutsideDepartment)
+	assert.NoError(t, scope.CheckDepartment(8, garden))
+	assert.ErrorIs(t, scope.CheckDepartment(10, garden), domain.ErrOutsideDepartment)
+}
@@ -0,0 +1,164 @@
+// Package env reads the settings of the service from environment variables.
+// It has no dependency on the features, each of them turns the values into
+// its own types.
+package env
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// GetSecretKey returns the key signing access tokens.
+func GetSecretKey() string {
+	return os.Getenv("SECRET_KEY")
+}
+
+// GetPasswordHashAlgorithm returns the algorithm used to hash new passwords ("bcrypt" or "argon2id").
+func GetPasswordHashAlgorithm() string {
+	return os.Getenv("PASSWORD_HASHER")
+}
+
+// GetAccessTokenTTL returns how long access tokens are valid, 15 minutes by default.
+func GetAccessTokenTTL() time.Duration {
+	return GetDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// GetRefreshTokenTTL returns how long refresh tokens are valid, 30 days by default.
+func GetRefreshTokenTTL() time.Duration {
+	return GetDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+}
+
+// GetAppBaseURL returns the public address of the service, used to build
+// links sent by email.
+func GetAppBaseURL() string {
+	if url := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/"); url != "" {
+		return url
+	}
+	return "http://localhost:8080"
+}
+
+// GetEmailVerificationTTL returns how long email verification links are
+// valid, 24 hours by default.
+func GetEmailVerificationTTL() time.Duration {
+	return GetDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour)
+}
+
+// GetEmailVerificationResendInterval returns how long a user has to wait
+// before another verification email is sent, 1 minute by default.
+func GetEmailVerificationResendInterval() time.Duration {
+	return GetDuration("EMAIL_VERIFICATION_RESEND_INTERVAL", time.Minute)
+}
+
+// GetUnverifiedLoginPolicy returns whether users who have not verified their
+// email may log in: "allow" (the default), "grace" or "deny". Other values
+// allow them.
+func GetUnverifiedLoginPolicy() string {
+	if policy := strings.ToLower(strings.TrimSpace(os.Getenv("UNVERIFIED_LOGIN_POLICY"))); policy != "" {
+		return policy
+	}
+	return "allow"
+}
+
+// GetUnverifiedLoginGrace returns how long after registering an unverified
+// user may still log in under the "grace" policy, 72 hours by default.
+func GetUnverifiedLoginGrace() time.Duration {
+	return GetDuration("UNVERIFIED_LOGIN_GRACE", 72*time.Hour)
+}
+
+// GetPasswordResetTTL returns how long password reset tokens are valid, 1
+// hour by default.
+func GetPasswordResetTTL() time.Duration {
+	return GetDuration("PASSWORD_RESET_TTL", time.Hour)
+}
+
+// GetPasswordResetResendInterval returns how long a user has to wait before
+// another password reset email is sent, 1 minute by default.
+func GetPasswordResetResendInterval() time.Duration {
+	return GetDuration("PASSWORD_RESET_RESEND_INTERVAL", time.Minute)
+}
+
+// GetPasswordResetURL returns the page the password reset email links to, the
+// token is appended as a query parameter.
+func GetPasswordResetURL() string {
+	if url := os.Getenv("PASSWORD_RESET_URL"); url != "" {
+		return url
+	}
+	return GetAppBaseURL() + "/reset-password"
+}
+
+// GetRequestClaimTTL returns how long a reviewer holds a request they claimed
+// or were assigned.
+func GetRequestClaimTTL() time.Duration {
+	return GetDuration("REQUEST_CLAIM_TTL", 30*time.Minute)
+}
+
+// GetTrashRetention returns how long deleted requests, applicants and
+// volunteers stay in the trash before the purge command removes them.
+func GetTrashRetention() time.Duration {
+	return GetDuration("TRASH_RETENTION", 30*24*time.Hour)
+}
+
+// GetAccountSetupTTL returns how long the account setup link emailed to
+// volunteers added by an admin is valid, 72 hours by default.
+func GetAccountSetupTTL() time.Duration {
+	return GetDuration("ACCOUNT_SETUP_TTL", 72*time.Hour)
+}
+
+// GetVolunteerDeactivationPolicy returns what deactivating a volunteer does to
+// their account: "demote" keeps the login with the rights of an applicant,
+// anything else, "block" by default, disables it.
+func GetVolunteerDeactivationPolicy() string {
+	if policy := strings.ToLower(strings.TrimSpace(os.Getenv("VOLUNTEER_DEACTIVATION_POLICY"))); policy != "" {
+		return policy
+	}
+	return "block"
+}
+
+// GetAttendanceSecretKey returns the key signing the attendance tokens shown
+// as QR codes, SECRET_KEY when ATTENDANCE_SECRET_KEY is not set.
+func GetAttendanceSecretKey() string {
+	if key := os.Getenv("ATTENDANCE_SECRET_KEY"); key != "" {
+		return key
+	}
+	return GetSecretKey()
+}
+
+// GetAttendanceTokenTTL returns how long an attendance QR code can be
+// scanned, 10 minutes by default.
+func GetAttendanceTokenTTL() time.Duration {
+	return GetDuration("ATTENDANCE_TOKEN_TTL", 10*time.Minute)
+}
+
+// GetAttendanceURL returns the page attendance QR codes link to, the token is
+// appended as a query parameter.
+func GetAttendanceURL() string {
+	if url := os.Getenv("ATTENDANCE_URL"); url != "" {
+		return url
+	}
+	return GetAppBaseURL() + "/attendance"
+}
+
+// GetCertificateTemplate returns the text template volunteer certificates
+// are rendered from, the built-in template when CERTIFICATE_TEMPLATE is not
+// set.
+func GetCertificateTemplate() string {
+	return os.Getenv("CERTIFICATE_TEMPLATE")
+}
+
+// GetAvailabilityTimezone returns the IANA time zone, such as
+// "Asia/Ho_Chi_Minh", the weekly availability slots of volunteers are written
+// in. It is empty, meaning UTC, when AVAILABILITY_TIMEZONE is not set.
+func GetAvailabilityTimezone() string {
+	return os.Getenv("AVAILABILITY_TIMEZONE")
+}
+
+// GetDuration returns the duration in the variable key, or fallback when it
+// is not set or not a positive duration.
+func GetDuration(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
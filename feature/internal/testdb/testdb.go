@@ -0,0 +1,37 @@
+// Package testdb sets up the in-memory SQLite databases repository tests run
+// against. gorm.io/driver/sqlite is built on github.com/mattn/go-sqlite3, so
+// running these tests needs cgo and a C compiler.
+package testdb
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open returns an empty in-memory database with the tables of models. The
+// test fails when the database cannot be set up.
+func Open(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("could not set up test DB: %v", err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("could not migrate test DB: %v", err)
+	}
+	return db
+}
+
+// Exec runs raw statements, for the tables tests need without their model
+// and the rows they start with.
+func Exec(t testing.TB, db *gorm.DB, statements ...string) {
+	t.Helper()
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("could not prepare test DB: %v", err)
+		}
+	}
+}
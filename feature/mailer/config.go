@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/env"
+)
+
+// Config holds the mail settings read from the environment.
+type Config struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	// MaxAttempts is how many times the worker tries to deliver an email
+	// before giving up on it.
+	MaxAttempts int
+	// PollInterval is how often the worker looks for emails to deliver.
+	PollInterval time.Duration
+}
+
+// ConfigFromEnv reads the SMTP_* and MAIL_* variables. Without SMTP_HOST
+// emails are written to the server log.
+func ConfigFromEnv() Config {
+	return Config{
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getInt("SMTP_PORT", 587),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		From:         getString("MAIL_FROM", "no-reply@localhost"),
+		MaxAttempts:  getInt("MAIL_MAX_ATTEMPTS", 5),
+		PollInterval: env.GetDuration("MAIL_POLL_INTERVAL", 10*time.Second),
+	}
+}
+
+func getString(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getInt(key string, fallback int) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
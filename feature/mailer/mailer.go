@@ -0,0 +1,39 @@
+package mailer
+
+import "log"
+
+// Message is an email with a plain text body and an optional HTML
+// alternative.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	HTML    string
+}
+
+// Mailer delivers emails to users.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New returns the transport configured by cfg: SMTP when a host is set, the
+// server log otherwise.
+func New(cfg Config) Mailer {
+	if cfg.SMTPHost == "" {
+		return NewLogMailer()
+	}
+	return NewSMTPMailer(cfg)
+}
+
+// LogMailer writes messages to the server log instead of delivering them. It
+// is meant for local development, where no mail server is configured.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	log.Printf("mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Outbox email statuses.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed"
+)
+
+// OutboxEmail is an email waiting in the outbox for the worker to deliver.
+// Writing it in the transaction of the change it announces means the email is
+// sent if and only if the change is committed.
+type OutboxEmail struct {
+	ID            int    `gorm:"primaryKey"`
+	Recipient     string `gorm:"not null"`
+	Subject       string `gorm:"not null"`
+	TextBody      string `gorm:"not null"`
+	HTMLBody      string
+	Status        string    `gorm:"index;not null"`
+	Attempts      int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"index;not null"`
+	LastError     string
+	SentAt        *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (OutboxEmail) TableName() string {
+	return "email_outbox"
+}
+
+// Enqueue adds msg to the outbox using db, which is usually the transaction
+// of the change the email is about.
+func Enqueue(db *gorm.DB, msg Message) error {
+	return db.Create(&OutboxEmail{
+		Recipient:     msg.To,
+		Subject:       msg.Subject,
+		TextBody:      msg.Body,
+		HTMLBody:      msg.HTML,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: time.Now(),
+	}).Error
+}
+
+// Outbox is a Mailer that enqueues messages for the worker instead of sending
+// them right away, so a mail server outage does not fail the request.
+type Outbox struct {
+	db *gorm.DB
+}
+
+func NewOutbox(db *gorm.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+func (o *Outbox) Send(msg Message) error {
+	return Enqueue(o.db, msg)
+}
@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPServer accepts a single SMTP session and sends the received DATA on
+// the returned channel.
+func fakeSMTPServer(t *testing.T) (host string, port int, received <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	data := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 end with <CRLF>.<CRLF>")
+				var body strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					body.WriteString(line)
+				}
+				data <- body.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, data
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	host, port, received := fakeSMTPServer(t)
+	m := NewSMTPMailer(Config{SMTPHost: host, SMTPPort: port, From: "no-reply@example.com"})
+
+	err := m.Send(Message{
+		To:      "user@example.com",
+		Subject: "Réinitialisation",
+		Body:    "plain body",
+		HTML:    "<p>html body</p>",
+	})
+
+	assert.NoError(t, err)
+	data := <-received
+	assert.Contains(t, data, "From: no-reply@example.com\r\n")
+	assert.Contains(t, data, "To: user@example.com\r\n")
+	assert.Contains(t, data, "Subject: =?utf-8?q?R=C3=A9initialisation?=\r\n")
+	assert.Contains(t, data, "multipart/alternative")
+	assert.Contains(t, data, "plain body")
+	assert.Contains(t, data, "<p>html body</p>")
+}
+
+func TestNew(t *testing.T) {
+	assert.IsType(t, &LogMailer{}, New(Config{}))
+	assert.IsType(t, &SMTPMailer{}, New(Config{SMTPHost: "localhost", SMTPPort: 25}))
+	assert.Equal(t, "localhost:25", New(Config{SMTPHost: "localhost", SMTPPort: 25}).(*SMTPMailer).addr)
+}
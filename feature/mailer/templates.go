@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Email templates. Each one has a <name>.txt.tmpl file, which also defines the
+// "subject" template, and a <name>.html.tmpl file in templates/.
+const (
+	TemplateRequestApproved = "request_approved"
+	TemplateRequestRejected = "request_rejected"
+	TemplateVerifyEmail     = "verify_email"
+	TemplateResetPassword   = "reset_password"
+	TemplateRequestMessage  = "request_message"
+	TemplateAccountSetup    = "account_setup"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// every text file defines its own "subject", so each template is parsed into
+// a set of its own
+var (
+	textTemplates = map[string]*texttemplate.Template{}
+	htmlTemplates = map[string]*htmltemplate.Template{}
+)
+
+func init() {
+	for _, name := range []string{TemplateRequestApproved, TemplateRequestRejected, TemplateVerifyEmail, TemplateResetPassword, TemplateRequestMessage, TemplateAccountSetup} {
+		textTemplates[name] = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/"+name+".txt.tmpl"))
+		htmlTemplates[name] = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/"+name+".html.tmpl"))
+	}
+}
+
+// RequestData is the data of the request_approved and request_rejected
+// templates.
+type RequestData struct {
+	Name      string
+	RequestID int
+	Type      string
+	Notes     string
+}
+
+// MessageData is the data of the request_message template. Name is the
+// recipient, Sender the author of the message.
+type MessageData struct {
+	Name      string
+	Sender    string
+	RequestID int
+	Type      string
+	Body      string
+}
+
+// LinkData is the data of the verify_email, reset_password and account_setup
+// templates.
+type LinkData struct {
+	Name      string
+	Link      string
+	ExpiresIn string
+}
+
+// Render builds the message of template name for the given recipient.
+func Render(to string, name string, data any) (Message, error) {
+	text, html := textTemplates[name], htmlTemplates[name]
+	if text == nil || html == nil {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, body, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := text.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	if err := html.Execute(&htmlBody, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	msg, err := Render("user@example.com", TemplateRequestRejected, RequestData{
+		Name:      "Ana",
+		RequestID: 4,
+		Type:      "verification",
+		Notes:     "passport <expired>",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", msg.To)
+	assert.Equal(t, "Your verification request has been rejected", msg.Subject)
+	assert.Contains(t, msg.Body, "request #4 has been rejected")
+	assert.Contains(t, msg.Body, "passport <expired>")
+	// notes are escaped in the HTML part only
+	assert.Contains(t, msg.HTML, "passport &lt;expired&gt;")
+}
+
+func TestRender_EveryTemplate(t *testing.T) {
+	data := map[string]any{
+		TemplateRequestApproved: RequestData{Name: "Ana", RequestID: 1, Type: "registration"},
+		TemplateRequestRejected: RequestData{Name: "Ana", RequestID: 1, Type: "registration"},
+		TemplateVerifyEmail:     LinkData{Name: "Ana", Link: "http://localhost/verify", ExpiresIn: "24h0m0s"},
+		TemplateResetPassword:   LinkData{Name: "Ana", Link: "http://localhost/reset", ExpiresIn: "1h0m0s"},
+		TemplateRequestMessage:  MessageData{Name: "Ana", Sender: "Bao", RequestID: 1, Type: "registration", Body: "Which passport?"},
+		TemplateAccountSetup:    LinkData{Name: "Ana", Link: "http://localhost/reset", ExpiresIn: "72h0m0s"},
+	}
+	subjects := map[string]bool{}
+	for name, d := range data {
+		msg, err := Render("user@example.com", name, d)
+		assert.NoError(t, err, name)
+		assert.NotEmpty(t, msg.Subject, name)
+		assert.NotEmpty(t, msg.Body, name)
+		assert.NotEmpty(t, msg.HTML, name)
+		subjects[msg.Subject] = true
+	}
+	// every template keeps its own subject
+	assert.Len(t, subjects, len(data))
+
+	_, err := Render("user@example.com", "unknown", nil)
+	assert.Error(t, err)
+}
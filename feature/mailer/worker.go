@@ -0,0 +1,140 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// deliveryLease is how long a claimed email is hidden from other passes. An
+// email claimed by a worker that died before recording the outcome is retried
+// once the lease is over.
+const deliveryLease = 5 * time.Minute
+
+// Worker delivers the emails of the outbox, retrying failures with an
+// exponential backoff until MaxAttempts is reached.
+type Worker struct {
+	db           *gorm.DB
+	mailer       Mailer
+	maxAttempts  int
+	pollInterval time.Duration
+	batchSize    int
+	backoff      func(attempt int) time.Duration
+}
+
+func NewWorker(db *gorm.DB, mailer Mailer, cfg Config) *Worker {
+	return &Worker{
+		db:           db,
+		mailer:       mailer,
+		maxAttempts:  cfg.MaxAttempts,
+		pollInterval: cfg.PollInterval,
+		batchSize:    20,
+		backoff:      exponentialBackoff,
+	}
+}
+
+// Run delivers pending emails every poll interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.DeliverPending(); err != nil {
+			log.Printf("deliver outbox emails: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverPending makes one pass over the emails that are due and returns how
+// many were sent.
+func (w *Worker) DeliverPending() (int, error) {
+	var emails []*OutboxEmail
+	err := w.db.Where("status = ? AND next_attempt_at <= ?", OutboxStatusPending, time.Now()).
+		Order("next_attempt_at, id").
+		Limit(w.batchSize).
+		Find(&emails).Error
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, email := range emails {
+		claimed, err := w.claim(email)
+		if err != nil {
+			return sent, err
+		}
+		if !claimed {
+			continue
+		}
+		if err := w.deliver(email); err != nil {
+			return sent, err
+		}
+		if email.Status == OutboxStatusSent {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// claim counts the attempt and hides the email for the lease. It reports false
+// when another worker claimed the email first.
+func (w *Worker) claim(email *OutboxEmail) (bool, error) {
+	result := w.db.Model(&OutboxEmail{}).
+		Where("id = ? AND status = ? AND attempts = ?", email.ID, OutboxStatusPending, email.Attempts).
+		Updates(map[string]interface{}{
+			"attempts":        email.Attempts + 1,
+			"next_attempt_at": time.Now().Add(deliveryLease),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	email.Attempts++
+	return result.RowsAffected > 0, nil
+}
+
+// deliver sends a claimed email and records the outcome.
+func (w *Worker) deliver(email *OutboxEmail) error {
+	sendErr := w.mailer.Send(Message{
+		To:      email.Recipient,
+		Subject: email.Subject,
+		Body:    email.TextBody,
+		HTML:    email.HTMLBody,
+	})
+
+	now := time.Now()
+	updates := map[string]interface{}{}
+	switch {
+	case sendErr == nil:
+		email.Status = OutboxStatusSent
+		updates["sent_at"] = now
+		updates["last_error"] = ""
+	case email.Attempts >= w.maxAttempts:
+		email.Status = OutboxStatusFailed
+		updates["last_error"] = sendErr.Error()
+		log.Printf("giving up on outbox email %d after %d attempts: %v", email.ID, email.Attempts, sendErr)
+	default:
+		updates["last_error"] = sendErr.Error()
+		updates["next_attempt_at"] = now.Add(w.backoff(email.Attempts))
+	}
+	updates["status"] = email.Status
+	return w.db.Model(&OutboxEmail{}).Where("id = ?", email.ID).Updates(updates).Error
+}
+
+// exponentialBackoff waits 1 minute after the first failure and doubles the
+// delay after each one, up to an hour.
+func exponentialBackoff(attempt int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		return time.Hour
+	}
+	return delay
+}
@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"errors"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type stubMailer struct {
+	err  error
+	sent []Message
+}
+
+func (m *stubMailer) Send(msg Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func setupOutboxDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &OutboxEmail{})
+	return db
+}
+
+func TestWorker_DeliverPending(t *testing.T) {
+	db := setupOutboxDB(t)
+	assert.NoError(t, NewOutbox(db).Send(Message{To: "user@example.com", Subject: "Hello", Body: "body", HTML: "<p>body</p>"}))
+	transport := &stubMailer{}
+	worker := NewWorker(db, transport, Config{MaxAttempts: 3, PollInterval: time.Second})
+
+	sent, err := worker.DeliverPending()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []Message{{To: "user@example.com", Subject: "Hello", Body: "body", HTML: "<p>body</p>"}}, transport.sent)
+
+	var email OutboxEmail
+	db.First(&email)
+	assert.Equal(t, OutboxStatusSent, email.Status)
+	assert.Equal(t, 1, email.Attempts)
+	assert.NotNil(t, email.SentAt)
+
+	// nothing left to deliver
+	sent, err = worker.DeliverPending()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
+
+func TestWorker_Retries(t *testing.T) {
+	db := setupOutboxDB(t)
+	assert.NoError(t, Enqueue(db, Message{To: "user@example.com", Subject: "Hello", Body: "body"}))
+	transport := &stubMailer{err: errors.New("connection refused")}
+	worker := NewWorker(db, transport, Config{MaxAttempts: 2, PollInterval: time.Second})
+
+	_, err := worker.DeliverPending()
+	assert.NoError(t, err)
+
+	var email OutboxEmail
+	db.First(&email)
+	assert.Equal(t, OutboxStatusPending, email.Status)
+	assert.Equal(t, 1, email.Attempts)
+	assert.Equal(t, "connection refused", email.LastError)
+	assert.True(t, email.NextAttemptAt.After(time.Now()))
+
+	// not due yet
+	_, err = worker.DeliverPending()
+	assert.NoError(t, err)
+	db.First(&email)
+	assert.Equal(t, 1, email.Attempts)
+
+	db.Model(&email).Update("next_attempt_at", time.Now().Add(-time.Second))
+	_, err = worker.DeliverPending()
+	assert.NoError(t, err)
+	db.First(&email)
+	assert.Equal(t, OutboxStatusFailed, email.Status)
+	assert.Equal(t, 2, email.Attempts)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, exponentialBackoff(1))
+	assert.Equal(t, 2*time.Minute, exponentialBackoff(2))
+	assert.Equal(t, 8*time.Minute, exponentialBackoff(4))
+	assert.Equal(t, time.Hour, exponentialBackoff(20))
+}
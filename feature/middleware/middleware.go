@@ -9,7 +9,13 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
-func AuthMiddleware(secretKey string) gin.HandlerFunc {
+// SessionChecker reports whether the session an access token was issued for
+// is still alive, so revoked sessions are rejected before the token expires.
+type SessionChecker interface {
+	IsSessionActive(sessionID string) (bool, error)
+}
+
+func AuthMiddleware(secretKey string, sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -26,21 +32,43 @@ func AuthMiddleware(secretKey string) gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		token, _ := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
 			return []byte(secretKey), nil
 		})
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("userId", int(claims["userId"].(float64)))
-			c.Set("roleId", int(claims["roleId"].(float64)))
-		} else {
+		if err != nil || !token.Valid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
+		userId, userOk := claims["userId"].(float64)
+		roleId, roleOk := claims["roleId"].(float64)
+		sessionId, sessionOk := claims["sid"].(string)
+		if !userOk || !roleOk || !sessionOk {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
+
+		active, err := sessions.IsSessionActive(sessionId)
+		if err != nil || !active {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+
+		c.Set("userId", int(userId))
+		c.Set("roleId", int(roleId))
+		c.Set("sessionId", sessionId)
 
 		c.Next()
 	}
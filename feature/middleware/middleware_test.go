@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "secret"
+
+type fakeSessions map[string]bool
+
+func (f fakeSessions) IsSessionActive(sessionID string) (bool, error) {
+	return f[sessionID], nil
+}
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	return token
+}
+
+func setupAuthRouter(sessions SessionChecker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/protected", AuthMiddleware(testSecret, sessions), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"userId": c.GetInt("userId"), "roleId": c.GetInt("roleId")})
+	})
+	return router
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	router := setupAuthRouter(fakeSessions{"active": true, "revoked": false})
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing header", "", http.StatusUnauthorized},
+		{"malformed header", "Token abc", http.StatusUnauthorized},
+		{"invalid token", "Bearer abc", http.StatusUnauthorized},
+		{"active session", "Bearer " + signToken(t, jwt.MapClaims{"userId": 1, "roleId": 2, "sid": "active", "exp": time.Now().Add(time.Minute).Unix()}), http.StatusOK},
+		{"revoked session", "Bearer " + signToken(t, jwt.MapClaims{"userId": 1, "roleId": 2, "sid": "revoked", "exp": time.Now().Add(time.Minute).Unix()}), http.StatusUnauthorized},
+		{"token without session", "Bearer " + signToken(t, jwt.MapClaims{"userId": 1, "roleId": 2, "exp": time.Now().Add(time.Minute).Unix()}), http.StatusUnauthorized},
+		{"expired token", "Bearer " + signToken(t, jwt.MapClaims{"userId": 1, "roleId": 2, "sid": "active", "exp": time.Now().Add(-time.Minute).Unix()}), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionChecker resolves whether a role has been granted a permission.
+type PermissionChecker interface {
+	RoleHasPermission(roleId uint, permission string) (bool, error)
+}
+
+// RequirePermission rejects the request unless the role set by AuthMiddleware
+// has been granted permission. It must be registered after AuthMiddleware.
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleId, exists := c.Get("roleId")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		allowed, err := checker.RoleHasPermission(uint(roleId.(int)), permission)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Permission denied"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePermissions struct {
+	grants map[uint][]string
+	err    error
+}
+
+func (f fakePermissions) RoleHasPermission(roleId uint, permission string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	for _, p := range f.grants[roleId] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func setupPermissionRouter(checker PermissionChecker, roleId *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/approve", func(c *gin.Context) {
+		if roleId != nil {
+			c.Set("roleId", *roleId)
+		}
+	}, RequirePermission(checker, "request:approve"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	return router
+}
+
+func TestRequirePermission(t *testing.T) {
+	admin, applicant := 1, 2
+	checker := fakePermissions{grants: map[uint][]string{1: {"request:read", "request:approve"}, 2: {"request:read"}}}
+
+	tests := []struct {
+		name    string
+		checker PermissionChecker
+		roleId  *int
+		want    int
+	}{
+		{"granted", checker, &admin, http.StatusOK},
+		{"not granted", checker, &applicant, http.StatusForbidden},
+		{"not authenticated", checker, nil, http.StatusUnauthorized},
+		{"checker error", fakePermissions{err: errors.New("db down")}, &admin, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupPermissionRouter(tt.checker, tt.roleId)
+			req, _ := http.NewRequest(http.MethodPost, "/approve", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+// Built-in role names, looked up by name instead of relying on database ids.
+const (
+	RoleAdmin     = "admin"
+	RoleApplicant = "applicant"
+	RoleVolunteer = "volunteer"
+)
+
+// Permission names checked by middleware.RequirePermission.
+const (
+	PermissionRequestRead     = "request:read"
+	PermissionRequestReview   = "request:review"
+	PermissionRequestApprove  = "request:approve"
+	PermissionRequestReject   = "request:reject"
+	PermissionRequestDelete   = "request:delete"
+	PermissionRoleManage      = "role:manage"
+	PermissionApplicantManage = "applicant:manage"
+	PermissionActivityManage  = "activity:manage"
+	PermissionHoursReview     = "hours:review"
+	PermissionSkillManage     = "skill:manage"
+	PermissionDepartmentAll   = "department:all"
+)
+
+// Permission struct represents a named action that can be granted to roles.
+type Permission struct {
+	Id          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"size:100;not null;unique" json:"name"`
+	Description string    `gorm:"size:255" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RolePermission struct maps a permission to a role.
+type RolePermission struct {
+	RoleId       uint      `gorm:"primaryKey" json:"role_id"`
+	PermissionId uint      `gorm:"primaryKey" json:"permission_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPositionNotFound = errors.New("position not found")
+	ErrPositionCycle    = errors.New("a position cannot report to itself or to one of its subordinates")
+	ErrPositionInUse    = errors.New("position has subordinate positions or volunteers holding it")
+)
+
+// Position is an entry of the volunteer position catalog, such as COM
+// (Committee) or CVL (Civil volunteer). Positions report to their parent and
+// are listed by rank, lowest first.
+type Position struct {
+	Id          uint      `gorm:"primaryKey" json:"id"`
+	Code        string    `gorm:"size:10;not null;unique" json:"code"`
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	Description string    `gorm:"size:255" json:"description"`
+	ParentId    *uint     `json:"parent_id"`
+	Rank        int       `gorm:"not null" json:"rank"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
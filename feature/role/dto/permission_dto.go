@@ -0,0 +1,12 @@
+package dto
+
+// PermissionCreateDTO represents the data transfer object for creating a permission.
+type PermissionCreateDTO struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// RolePermissionAssignDTO represents the data transfer object for granting a permission to a role.
+type RolePermissionAssignDTO struct {
+	PermissionId uint `json:"permission_id" binding:"required"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// PositionCreateDTO represents the data transfer object for creating a volunteer position.
+type PositionCreateDTO struct {
+	Code        string `json:"code" binding:"required,max=10"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=255"`
+	ParentId    *uint  `json:"parent_id"`
+	Rank        int    `json:"rank"`
+}
+
+// PositionUpdateDTO represents the data transfer object for updating a volunteer position.
+type PositionUpdateDTO struct {
+	Code        string `json:"code" binding:"required,max=10"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=255"`
+	ParentId    *uint  `json:"parent_id"`
+	Rank        int    `json:"rank"`
+}
+
+// PositionNodeDTO is a position with the positions reporting to it.
+type PositionNodeDTO struct {
+	Id          uint              `json:"id"`
+	Code        string            `json:"code"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Rank        int               `json:"rank"`
+	Children    []PositionNodeDTO `json:"children"`
+}
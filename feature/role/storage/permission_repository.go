@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"gorm.io/gorm"
+)
+
+// PermissionRepositoryInterface defines the methods that any permission repository implementation must provide.
+type PermissionRepositoryInterface interface {
+	Create(permission *domain.Permission) error
+	List() ([]domain.Permission, error)
+	Delete(id uint) error
+	GetByRoleID(roleId uint) ([]domain.Permission, error)
+	AssignToRole(roleId uint, permissionId uint) error
+	RevokeFromRole(roleId uint, permissionId uint) error
+	RoleHasPermission(roleId uint, permission string) (bool, error)
+}
+
+// PermissionRepository handles permissions and their mapping to roles.
+type PermissionRepository struct {
+	DB *gorm.DB
+}
+
+// NewPermissionRepository creates a new instance of PermissionRepository.
+func NewPermissionRepository(db *gorm.DB) *PermissionRepository {
+	return &PermissionRepository{DB: db}
+}
+
+// Create inserts a new permission record into the database.
+func (r *PermissionRepository) Create(permission *domain.Permission) error {
+	return r.DB.Create(permission).Error
+}
+
+// List retrieves all permissions ordered by name.
+func (r *PermissionRepository) List() ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	err := r.DB.Order("name").Find(&permissions).Error
+	return permissions, err
+}
+
+// Delete deletes a permission and its role mappings.
+func (r *PermissionRepository) Delete(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("permission_id = ?", id).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Permission{}, id).Error
+	})
+}
+
+// GetByRoleID retrieves the permissions granted to a role.
+func (r *PermissionRepository) GetByRoleID(roleId uint) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	err := r.DB.
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id = ?", roleId).
+		Order("permissions.name").
+		Find(&permissions).Error
+	return permissions, err
+}
+
+// AssignToRole grants a permission to a role, granting it twice is a no-op.
+func (r *PermissionRepository) AssignToRole(roleId uint, permissionId uint) error {
+	return r.DB.
+		Where(domain.RolePermission{RoleId: roleId, PermissionId: permissionId}).
+		FirstOrCreate(&domain.RolePermission{}).Error
+}
+
+// RevokeFromRole removes a permission from a role.
+func (r *PermissionRepository) RevokeFromRole(roleId uint, permissionId uint) error {
+	return r.DB.
+		Where("role_id = ? AND permission_id = ?", roleId, permissionId).
+		Delete(&domain.RolePermission{}).Error
+}
+
+// RoleHasPermission reports whether the role has been granted the named permission.
+func (r *PermissionRepository) RoleHasPermission(roleId uint, permission string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&domain.RolePermission{}).
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("role_permissions.role_id = ? AND permissions.name = ?", roleId, permission).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
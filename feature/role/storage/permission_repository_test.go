@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupPermissionDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.Permission{}, &domain.RolePermission{})
+	return db
+}
+
+func TestPermissionRepository_RoleHasPermission(t *testing.T) {
+	repo := NewPermissionRepository(setupPermissionDB(t))
+
+	read := &domain.Permission{Name: domain.PermissionRequestRead}
+	approve := &domain.Permission{Name: domain.PermissionRequestApprove}
+	assert.NoError(t, repo.Create(read))
+	assert.NoError(t, repo.Create(approve))
+
+	assert.NoError(t, repo.AssignToRole(1, read.Id))
+	// granting twice must not fail on the composite key
+	assert.NoError(t, repo.AssignToRole(1, read.Id))
+
+	granted, err := repo.RoleHasPermission(1, domain.PermissionRequestRead)
+	assert.NoError(t, err)
+	assert.True(t, granted)
+
+	granted, err = repo.RoleHasPermission(1, domain.PermissionRequestApprove)
+	assert.NoError(t, err)
+	assert.False(t, granted)
+
+	granted, err = repo.RoleHasPermission(2, domain.PermissionRequestRead)
+	assert.NoError(t, err)
+	assert.False(t, granted)
+
+	permissions, err := repo.GetByRoleID(1)
+	assert.NoError(t, err)
+	assert.Len(t, permissions, 1)
+	assert.Equal(t, domain.PermissionRequestRead, permissions[0].Name)
+
+	assert.NoError(t, repo.RevokeFromRole(1, read.Id))
+	granted, err = repo.RoleHasPermission(1, domain.PermissionRequestRead)
+	assert.NoError(t, err)
+	assert.False(t, granted)
+}
+
+func TestPermissionRepository_Delete(t *testing.T) {
+	db := setupPermissionDB(t)
+	repo := NewPermissionRepository(db)
+
+	permission := &domain.Permission{Name: domain.PermissionRequestDelete}
+	assert.NoError(t, repo.Create(permission))
+	assert.NoError(t, repo.AssignToRole(1, permission.Id))
+
+	assert.NoError(t, repo.Delete(permission.Id))
+
+	permissions, err := repo.List()
+	assert.NoError(t, err)
+	assert.Empty(t, permissions)
+
+	var mappings int64
+	db.Model(&domain.RolePermission{}).Count(&mappings)
+	assert.Zero(t, mappings)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"gorm.io/gorm"
+)
+
+// PositionRepositoryInterface defines the methods that any position repository implementation must provide.
+type PositionRepositoryInterface interface {
+	Create(position *domain.Position) error
+	List() ([]domain.Position, error)
+	GetByID(id uint) (*domain.Position, error)
+	Update(position *domain.Position) error
+	Delete(id uint) error
+}
+
+// PositionRepository handles the volunteer position catalog.
+type PositionRepository struct {
+	DB *gorm.DB
+}
+
+// NewPositionRepository creates a new instance of PositionRepository.
+func NewPositionRepository(db *gorm.DB) *PositionRepository {
+	return &PositionRepository{DB: db}
+}
+
+// Create inserts a new position record into the database.
+func (r *PositionRepository) Create(position *domain.Position) error {
+	return r.DB.Create(position).Error
+}
+
+// List retrieves all positions ordered by rank and code.
+func (r *PositionRepository) List() ([]domain.Position, error) {
+	var positions []domain.Position
+	err := r.DB.Order("rank").Order("code").Find(&positions).Error
+	return positions, err
+}
+
+// GetByID retrieves a position by its ID.
+func (r *PositionRepository) GetByID(id uint) (*domain.Position, error) {
+	var position domain.Position
+	if err := r.DB.First(&position, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPositionNotFound
+		}
+		return nil, err
+	}
+	return &position, nil
+}
+
+// Update updates a position record in the database.
+func (r *PositionRepository) Update(position *domain.Position) error {
+	return r.DB.Save(position).Error
+}
+
+// Delete deletes a position that no position reports to and that was never
+// held by a volunteer.
+func (r *PositionRepository) Delete(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var children, assignments int64
+		if err := tx.Model(&domain.Position{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("volunteer_positions").Where("position_id = ?", id).Count(&assignments).Error; err != nil {
+			return err
+		}
+		if children > 0 || assignments > 0 {
+			return domain.ErrPositionInUse
+		}
+		result := tx.Delete(&domain.Position{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPositionNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupPositionDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.Position{})
+	testdb.Exec(t, db, "CREATE TABLE volunteer_positions (id integer primary key, volunteer_id integer, position_id integer)")
+	return db
+}
+
+func TestPositionRepository_List(t *testing.T) {
+	repo := NewPositionRepository(setupPositionDB(t))
+
+	assert.NoError(t, repo.Create(&domain.Position{Code: "CVL", Name: "Civil volunteer", Rank: 3}))
+	assert.NoError(t, repo.Create(&domain.Position{Code: "COM", Name: "Committee", Rank: 1}))
+
+	positions, err := repo.List()
+	assert.NoError(t, err)
+	if assert.Len(t, positions, 2) {
+		assert.Equal(t, "COM", positions[0].Code)
+		assert.Equal(t, "CVL", positions[1].Code)
+	}
+
+	_, err = repo.GetByID(99)
+	assert.ErrorIs(t, err, domain.ErrPositionNotFound)
+}
+
+func TestPositionRepository_Delete(t *testing.T) {
+	db := setupPositionDB(t)
+	repo := NewPositionRepository(db)
+
+	committee := &domain.Position{Code: "COM", Name: "Committee", Rank: 1}
+	assert.NoError(t, repo.Create(committee))
+	manager := &domain.Position{Code: "MNVC", Name: "Manager of volunteer coordinators", ParentId: &committee.Id, Rank: 2}
+	assert.NoError(t, repo.Create(manager))
+	civil := &domain.Position{Code: "CVL", Name: "Civil volunteer", Rank: 3}
+	assert.NoError(t, repo.Create(civil))
+	db.Exec("INSERT INTO volunteer_positions (volunteer_id, position_id) VALUES (1, ?)", civil.Id)
+
+	assert.ErrorIs(t, repo.Delete(committee.Id), domain.ErrPositionInUse)
+	assert.ErrorIs(t, repo.Delete(civil.Id), domain.ErrPositionInUse)
+	assert.NoError(t, repo.Delete(manager.Id))
+	assert.NoError(t, repo.Delete(committee.Id))
+	assert.ErrorIs(t, repo.Delete(committee.Id), domain.ErrPositionNotFound)
+}
@@ -3,125 +3,75 @@ package storage
 import (
 	"testing"
 
-	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
-	}), &gorm.Config{})
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return gormDB, mock, nil
+func setupRoleDB(t *testing.T) *gorm.DB {
+	return testdb.Open(t, &domain.Role{})
 }
 
 func TestRoleRepository_Create(t *testing.T) {
-	gormDB, mock, err := setupMockDB()
-	if err != nil {
-		t.Fatalf("failed to setup mock db: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := gormDB.DB()
-		sqlDB.Close()
-	}()
-
-	repo := NewRoleRepository(gormDB)
+	repo := NewRoleRepository(setupRoleDB(t))
 
 	role := &domain.Role{
 		Name:   "Admin",
 		Status: 123,
 	}
 
-	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `roles`").WithArgs(role.Name, role.Status).WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
-
-	err = repo.Create(role)
+	err := repo.Create(role)
 	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.NotZero(t, role.Id)
 }
 
 func TestRoleRepository_GetByID(t *testing.T) {
-	gormDB, mock, err := setupMockDB()
-	if err != nil {
-		t.Fatalf("failed to setup mock db: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := gormDB.DB()
-		sqlDB.Close()
-	}()
-
-	repo := NewRoleRepository(gormDB)
+	repo := NewRoleRepository(setupRoleDB(t))
 
 	role := &domain.Role{
 		Name:   "Admin",
 		Status: 456,
 	}
+	assert.NoError(t, repo.Create(role))
 
-	rows := sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(1, role.Name, role.Status)
-	mock.ExpectQuery("SELECT * FROM `roles` WHERE `roles`.`id` = ?").WithArgs(1).WillReturnRows(rows)
-
-	result, err := repo.GetByID(1)
+	result, err := repo.GetByID(role.Id)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, role.Name, result.Name)
 	assert.Equal(t, role.Status, result.Status)
-	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.GetByID(role.Id + 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 }
 
 func TestRoleRepository_Update(t *testing.T) {
-	gormDB, mock, err := setupMockDB()
-	if err != nil {
-		t.Fatalf("failed to setup mock db: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := gormDB.DB()
-		sqlDB.Close()
-	}()
-
-	repo := NewRoleRepository(gormDB)
+	repo := NewRoleRepository(setupRoleDB(t))
 
 	role := &domain.Role{
 		Name:   "Admin",
 		Status: 789,
 	}
+	assert.NoError(t, repo.Create(role))
 
-	mock.ExpectBegin()
-	mock.ExpectExec("UPDATE `roles` SET `name`=?,`status`=? WHERE `id` = ?").WithArgs(role.Name, role.Status, role.Id).WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
+	role.Status = 1
+	err := repo.Update(role)
+	assert.NoError(t, err)
 
-	err = repo.Update(role)
+	result, err := repo.GetByID(role.Id)
 	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, uint(1), result.Status)
 }
 
 func TestRoleRepository_Delete(t *testing.T) {
-	gormDB, mock, err := setupMockDB()
-	if err != nil {
-		t.Fatalf("failed to setup mock db: %v", err)
-	}
-	defer func() {
-		sqlDB, _ := gormDB.DB()
-		sqlDB.Close()
-	}()
-	repo := NewRoleRepository(gormDB)
+	repo := NewRoleRepository(setupRoleDB(t))
 
-	mock.ExpectBegin()
-	mock.ExpectExec("DELETE FROM `roles` WHERE `id` = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
+	role := &domain.Role{Name: "Admin", Status: 1}
+	assert.NoError(t, repo.Create(role))
 
-	err = repo.Delete(1)
+	err := repo.Delete(role.Id)
 	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.GetByID(role.Id)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 }
@@ -0,0 +1,178 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionHandler handles the HTTP requests for permissions.
+type PermissionHandler struct {
+	usecase usecase.PermissionUsecaseInterface
+}
+
+// NewPermissionHandler creates a new instance of PermissionHandler.
+func NewPermissionHandler(usecase usecase.PermissionUsecaseInterface) *PermissionHandler {
+	return &PermissionHandler{usecase: usecase}
+}
+
+// CreatePermission handles the HTTP POST request to create a new permission.
+// CreatePermission godoc
+// @Summary Create permission
+// @Description Create permission
+// @Produce json
+// @Tags permission
+// @Security bearerToken
+// @Param request body dto.PermissionCreateDTO true "Create Permission Request"
+// @Success 201 {string} message "permission created successfully"
+// @Router /api/v1/permission/ [post]
+func (h *PermissionHandler) CreatePermission(c *gin.Context) {
+	var input dto.PermissionCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.CreatePermission(input); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "permission created successfully"})
+}
+
+// ListPermissions handles the HTTP GET request to list all permissions.
+// ListPermissions godoc
+// @Summary List permissions
+// @Description List permissions
+// @Produce json
+// @Tags permission
+// @Security bearerToken
+// @Success 200 {array} domain.Permission
+// @Router /api/v1/permission/ [get]
+func (h *PermissionHandler) ListPermissions(c *gin.Context) {
+	permissions, err := h.usecase.ListPermissions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// DeletePermission handles the HTTP DELETE request to delete a permission.
+// DeletePermission godoc
+// @Summary Delete permission
+// @Description Delete permission
+// @Produce json
+// @Tags permission
+// @Security bearerToken
+// @Param id path int true "Permission ID"
+// @Success 204
+// @Router /api/v1/permission/{id} [delete]
+func (h *PermissionHandler) DeletePermission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid permission ID"})
+		return
+	}
+
+	if err := h.usecase.DeletePermission(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetRolePermissions handles the HTTP GET request to list the permissions of a role.
+// GetRolePermissions godoc
+// @Summary Get role permissions
+// @Description Get role permissions
+// @Produce json
+// @Tags permission
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Success 200 {array} domain.Permission
+// @Router /api/v1/role/{id}/permissions [get]
+func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+
+	permissions, err := h.usecase.GetRolePermissions(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// AssignPermission handles the HTTP POST request to grant a permission to a role.
+// AssignPermission godoc
+// @Summary Assign permission to role
+// @Description Assign permission to role
+// @Produce json
+// @Tags permission
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Param request body dto.RolePermissionAssignDTO true "Assign Permission Request"
+// @Success 200 {string} message "permission assigned successfully"
+// @Router /api/v1/role/{id}/permissions [post]
+func (h *PermissionHandler) AssignPermission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+
+	var input dto.RolePermissionAssignDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.AssignPermission(uint(id), input); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "permission assigned successfully"})
+}
+
+// RevokePermission handles the HTTP DELETE request to remove a permission from a role.
+// RevokePermission godoc
+// @Summary Revoke permission from role
+// @Description Revoke permission from role
+// @Produce json
+// @Tags permission
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Param permissionId path int true "Permission ID"
+// @Success 204
+// @Router /api/v1/role/{id}/permissions/{permissionId} [delete]
+func (h *PermissionHandler) RevokePermission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+	permissionId, err := strconv.Atoi(c.Param("permissionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid permission ID"})
+		return
+	}
+
+	if err := h.usecase.RevokePermission(uint(id), uint(permissionId)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPermissionUsecase is a mock implementation of the PermissionUsecaseInterface.
+type MockPermissionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPermissionUsecase) CreatePermission(input dto.PermissionCreateDTO) error {
+	args := m.Called(input)
+	return args.Error(0)
+}
+
+func (m *MockPermissionUsecase) ListPermissions() ([]domain.Permission, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionUsecase) DeletePermission(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPermissionUsecase) GetRolePermissions(roleId uint) ([]domain.Permission, error) {
+	args := m.Called(roleId)
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionUsecase) AssignPermission(roleId uint, input dto.RolePermissionAssignDTO) error {
+	args := m.Called(roleId, input)
+	return args.Error(0)
+}
+
+func (m *MockPermissionUsecase) RevokePermission(roleId uint, permissionId uint) error {
+	args := m.Called(roleId, permissionId)
+	return args.Error(0)
+}
+
+func TestPermissionHandler_AssignPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockPermissionUsecase)
+	handler := NewPermissionHandler(mockUsecase)
+
+	router := gin.Default()
+	router.POST("/api/v1/role/:id/permissions", handler.AssignPermission)
+
+	input := dto.RolePermissionAssignDTO{PermissionId: 2}
+	mockUsecase.On("AssignPermission", uint(1), input).Return(nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(input)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/role/1/permissions", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "permission assigned successfully")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPermissionHandler_GetRolePermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("found", func(t *testing.T) {
+		mockUsecase := new(MockPermissionUsecase)
+		handler := NewPermissionHandler(mockUsecase)
+		router := gin.Default()
+		router.GET("/api/v1/role/:id/permissions", handler.GetRolePermissions)
+
+		permissions := []domain.Permission{{Id: 1, Name: "request:read"}}
+		mockUsecase.On("GetRolePermissions", uint(1)).Return(permissions, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/role/1/permissions", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "request:read")
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		mockUsecase := new(MockPermissionUsecase)
+		handler := NewPermissionHandler(mockUsecase)
+		router := gin.Default()
+		router.GET("/api/v1/role/:id/permissions", handler.GetRolePermissions)
+
+		mockUsecase.On("GetRolePermissions", uint(9)).Return([]domain.Permission(nil), errors.New("record not found"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/role/9/permissions", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPermissionHandler_RevokePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockPermissionUsecase)
+	handler := NewPermissionHandler(mockUsecase)
+
+	router := gin.Default()
+	router.DELETE("/api/v1/role/:id/permissions/:permissionId", handler.RevokePermission)
+
+	mockUsecase.On("RevokePermission", uint(1), uint(2)).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/role/1/permissions/2", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,166 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// PositionHandler handles the HTTP requests for the volunteer position catalog.
+type PositionHandler struct {
+	usecase usecase.PositionUsecaseInterface
+}
+
+// NewPositionHandler creates a new instance of PositionHandler.
+func NewPositionHandler(usecase usecase.PositionUsecaseInterface) *PositionHandler {
+	return &PositionHandler{usecase: usecase}
+}
+
+// CreatePosition handles the HTTP POST request to create a new position.
+// CreatePosition godoc
+// @Summary Create position
+// @Description Create a volunteer position, optionally reporting to a parent position
+// @Produce json
+// @Tags position
+// @Security bearerToken
+// @Param request body dto.PositionCreateDTO true "Create Position Request"
+// @Success 201 {object} domain.Position
+// @Failure 404 string error
+// @Router /api/v1/position/ [post]
+func (h *PositionHandler) CreatePosition(c *gin.Context) {
+	var input dto.PositionCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	position, err := h.usecase.CreatePosition(input)
+	if err != nil {
+		c.JSON(positionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, position)
+}
+
+// ListPositions handles the HTTP GET request to list the position catalog.
+// ListPositions godoc
+// @Summary List positions
+// @Description Get the volunteer positions as a tree, every level ordered by rank
+// @Produce json
+// @Tags position
+// @Success 200 {array} dto.PositionNodeDTO
+// @Router /api/v1/position/ [get]
+func (h *PositionHandler) ListPositions(c *gin.Context) {
+	positions, err := h.usecase.ListPositions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, positions)
+}
+
+// GetPositionByID handles the HTTP GET request to retrieve a position by its ID.
+// GetPositionByID godoc
+// @Summary Get position by ID
+// @Description Get position by ID
+// @Produce json
+// @Tags position
+// @Param id path int true "Position ID"
+// @Success 200 {object} domain.Position
+// @Failure 404 string error
+// @Router /api/v1/position/{id} [get]
+func (h *PositionHandler) GetPositionByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid position ID"})
+		return
+	}
+
+	position, err := h.usecase.GetPositionByID(uint(id))
+	if err != nil {
+		c.JSON(positionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+// UpdatePosition handles the HTTP PUT request to update a position.
+// UpdatePosition godoc
+// @Summary Update position
+// @Description Update a volunteer position, a position cannot report to one of its subordinates
+// @Produce json
+// @Tags position
+// @Security bearerToken
+// @Param id path int true "Position ID"
+// @Param request body dto.PositionUpdateDTO true "Update Position Request"
+// @Success 200 {string} message "position updated successfully"
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/position/{id} [put]
+func (h *PositionHandler) UpdatePosition(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid position ID"})
+		return
+	}
+
+	var input dto.PositionUpdateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.UpdatePosition(uint(id), input); err != nil {
+		c.JSON(positionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "position updated successfully"})
+}
+
+// DeletePosition handles the HTTP DELETE request to delete a position.
+// DeletePosition godoc
+// @Summary Delete position
+// @Description Delete a position that has no subordinate positions and was never held
+// @Produce json
+// @Tags position
+// @Security bearerToken
+// @Param id path int true "Position ID"
+// @Success 204
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/position/{id} [delete]
+func (h *PositionHandler) DeletePosition(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid position ID"})
+		return
+	}
+
+	if err := h.usecase.DeletePosition(uint(id)); err != nil {
+		c.JSON(positionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// positionErrorStatus maps position errors to HTTP status codes.
+func positionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPositionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrPositionCycle), errors.Is(err, domain.ErrPositionInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
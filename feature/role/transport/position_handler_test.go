@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPositionUsecase is a mock implementation of the PositionUsecaseInterface.
+type MockPositionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPositionUsecase) CreatePosition(input dto.PositionCreateDTO) (*domain.Position, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Position), args.Error(1)
+}
+
+func (m *MockPositionUsecase) ListPositions() ([]dto.PositionNodeDTO, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.PositionNodeDTO), args.Error(1)
+}
+
+func (m *MockPositionUsecase) GetPositionByID(id uint) (*domain.Position, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Position), args.Error(1)
+}
+
+func (m *MockPositionUsecase) UpdatePosition(id uint, input dto.PositionUpdateDTO) error {
+	args := m.Called(id, input)
+	return args.Error(0)
+}
+
+func (m *MockPositionUsecase) DeletePosition(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestPositionHandler_UpdatePosition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockPositionUsecase)
+	handler := NewPositionHandler(mockUsecase)
+
+	router := gin.Default()
+	router.PUT("/api/v1/position/:id", handler.UpdatePosition)
+
+	parent := uint(3)
+	input := dto.PositionUpdateDTO{Code: "COM", Name: "Committee", ParentId: &parent}
+	mockUsecase.On("UpdatePosition", uint(1), input).Return(domain.ErrPositionCycle)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(input)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/position/1", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPositionHandler_GetPositionByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockPositionUsecase)
+	handler := NewPositionHandler(mockUsecase)
+
+	router := gin.Default()
+	router.GET("/api/v1/position/:id", handler.GetPositionByID)
+
+	mockUsecase.On("GetPositionByID", uint(1)).Return(&domain.Position{Id: 1, Code: "COM", Name: "Committee"}, nil)
+	mockUsecase.On("GetPositionByID", uint(2)).Return(nil, domain.ErrPositionNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/position/1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"COM"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/position/2", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -41,7 +41,7 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "department created successfully"})
+	c.JSON(http.StatusCreated, gin.H{"message": "role created successfully"})
 }
 
 // GetRoleByID handles the HTTP GET request to retrieve a role by its ID.
@@ -62,7 +62,7 @@ func TestRoleHandler_CreateRole(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
-	assert.Contains(t, w.Body.String(), "Role created successfully")
+	assert.Contains(t, w.Body.String(), "role created successfully")
 	mockUsecase.AssertCalled(t, "CreateRole", input)
 }
 
@@ -114,7 +114,7 @@ func TestRoleHandler_UpdateRole(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "department updated successfully")
+	assert.Contains(t, w.Body.String(), "role updated successfully")
 	mockUsecase.AssertCalled(t, "UpdateRole", uint(1), input)
 }
 
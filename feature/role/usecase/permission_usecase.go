@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
+)
+
+// PermissionUsecaseInterface defines the methods that any permission use case implementation must provide.
+type PermissionUsecaseInterface interface {
+	CreatePermission(input dto.PermissionCreateDTO) error
+	ListPermissions() ([]domain.Permission, error)
+	DeletePermission(id uint) error
+	GetRolePermissions(roleId uint) ([]domain.Permission, error)
+	AssignPermission(roleId uint, input dto.RolePermissionAssignDTO) error
+	RevokePermission(roleId uint, permissionId uint) error
+}
+
+// PermissionUsecase handles the business logic for permissions.
+type PermissionUsecase struct {
+	PermissionRepo storage.PermissionRepositoryInterface
+	RoleRepo       storage.RoleRepositoryInterface
+}
+
+// NewPermissionUsecase creates a new instance of PermissionUsecase.
+func NewPermissionUsecase(permissionRepo storage.PermissionRepositoryInterface, roleRepo storage.RoleRepositoryInterface) *PermissionUsecase {
+	return &PermissionUsecase{PermissionRepo: permissionRepo, RoleRepo: roleRepo}
+}
+
+// CreatePermission creates a new permission using the provided DTO.
+func (u *PermissionUsecase) CreatePermission(input dto.PermissionCreateDTO) error {
+	permission := &domain.Permission{
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	return u.PermissionRepo.Create(permission)
+}
+
+// ListPermissions retrieves all permissions.
+func (u *PermissionUsecase) ListPermissions() ([]domain.Permission, error) {
+	return u.PermissionRepo.List()
+}
+
+// DeletePermission deletes a permission by its ID.
+func (u *PermissionUsecase) DeletePermission(id uint) error {
+	return u.PermissionRepo.Delete(id)
+}
+
+// GetRolePermissions retrieves the permissions granted to a role.
+func (u *PermissionUsecase) GetRolePermissions(roleId uint) ([]domain.Permission, error) {
+	if _, err := u.RoleRepo.GetByID(roleId); err != nil {
+		return nil, err
+	}
+	return u.PermissionRepo.GetByRoleID(roleId)
+}
+
+// AssignPermission grants a permission to a role.
+func (u *PermissionUsecase) AssignPermission(roleId uint, input dto.RolePermissionAssignDTO) error {
+	if _, err := u.RoleRepo.GetByID(roleId); err != nil {
+		return err
+	}
+	return u.PermissionRepo.AssignToRole(roleId, input.PermissionId)
+}
+
+// RevokePermission removes a permission from a role.
+func (u *PermissionUsecase) RevokePermission(roleId uint, permissionId uint) error {
+	return u.PermissionRepo.RevokeFromRole(roleId, permissionId)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPermissionRepository is a mock implementation of the PermissionRepositoryInterface.
+type MockPermissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionRepository) Create(permission *domain.Permission) error {
+	args := m.Called(permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) List() ([]domain.Permission, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) GetByRoleID(roleId uint) ([]domain.Permission, error) {
+	args := m.Called(roleId)
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) AssignToRole(roleId uint, permissionId uint) error {
+	args := m.Called(roleId, permissionId)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) RevokeFromRole(roleId uint, permissionId uint) error {
+	args := m.Called(roleId, permissionId)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) RoleHasPermission(roleId uint, permission string) (bool, error) {
+	args := m.Called(roleId, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestCreatePermission(t *testing.T) {
+	mockRepo := new(MockPermissionRepository)
+	usecase := NewPermissionUsecase(mockRepo, new(MockRoleRepository))
+
+	input := dto.PermissionCreateDTO{Name: "request:read", Description: "Read requests"}
+	permission := &domain.Permission{Name: input.Name, Description: input.Description}
+	mockRepo.On("Create", permission).Return(nil)
+
+	err := usecase.CreatePermission(input)
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Create", permission)
+}
+
+func TestAssignPermission(t *testing.T) {
+	t.Run("assigns to an existing role", func(t *testing.T) {
+		mockRepo := new(MockPermissionRepository)
+		mockRoleRepo := new(MockRoleRepository)
+		usecase := NewPermissionUsecase(mockRepo, mockRoleRepo)
+
+		mockRoleRepo.On("GetByID", uint(1)).Return(&domain.Role{Name: "admin"}, nil)
+		mockRepo.On("AssignToRole", uint(1), uint(2)).Return(nil)
+
+		err := usecase.AssignPermission(1, dto.RolePermissionAssignDTO{PermissionId: 2})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		mockRepo := new(MockPermissionRepository)
+		mockRoleRepo := new(MockRoleRepository)
+		usecase := NewPermissionUsecase(mockRepo, mockRoleRepo)
+
+		mockRoleRepo.On("GetByID", uint(9)).Return((*domain.Role)(nil), errors.New("record not found"))
+
+		err := usecase.AssignPermission(9, dto.RolePermissionAssignDTO{PermissionId: 2})
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "AssignToRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetRolePermissions(t *testing.T) {
+	mockRepo := new(MockPermissionRepository)
+	mockRoleRepo := new(MockRoleRepository)
+	usecase := NewPermissionUsecase(mockRepo, mockRoleRepo)
+
+	permissions := []domain.Permission{{Id: 1, Name: "request:read"}}
+	mockRoleRepo.On("GetByID", uint(1)).Return(&domain.Role{Name: "admin"}, nil)
+	mockRepo.On("GetByRoleID", uint(1)).Return(permissions, nil)
+
+	result, err := usecase.GetRolePermissions(1)
+	assert.NoError(t, err)
+	assert.Equal(t, permissions, result)
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
+)
+
+// PositionUsecaseInterface defines the methods that any position use case implementation must provide.
+type PositionUsecaseInterface interface {
+	CreatePosition(input dto.PositionCreateDTO) (*domain.Position, error)
+	ListPositions() ([]dto.PositionNodeDTO, error)
+	GetPositionByID(id uint) (*domain.Position, error)
+	UpdatePosition(id uint, input dto.PositionUpdateDTO) error
+	DeletePosition(id uint) error
+}
+
+// PositionUsecase handles the business logic for the volunteer position catalog.
+type PositionUsecase struct {
+	PositionRepo storage.PositionRepositoryInterface
+}
+
+// NewPositionUsecase creates a new instance of PositionUsecase.
+func NewPositionUsecase(positionRepo storage.PositionRepositoryInterface) *PositionUsecase {
+	return &PositionUsecase{PositionRepo: positionRepo}
+}
+
+// CreatePosition creates a new position under its parent, if any.
+func (u *PositionUsecase) CreatePosition(input dto.PositionCreateDTO) (*domain.Position, error) {
+	if input.ParentId != nil {
+		if _, err := u.PositionRepo.GetByID(*input.ParentId); err != nil {
+			return nil, err
+		}
+	}
+	position := &domain.Position{
+		Code:        input.Code,
+		Name:        input.Name,
+		Description: input.Description,
+		ParentId:    input.ParentId,
+		Rank:        input.Rank,
+	}
+	if err := u.PositionRepo.Create(position); err != nil {
+		return nil, err
+	}
+	return position, nil
+}
+
+// ListPositions returns the catalog as a tree, every level ordered by rank.
+func (u *PositionUsecase) ListPositions() ([]dto.PositionNodeDTO, error) {
+	positions, err := u.PositionRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[uint][]domain.Position)
+	roots := make([]domain.Position, 0)
+	for _, position := range positions {
+		if position.ParentId == nil {
+			roots = append(roots, position)
+			continue
+		}
+		children[*position.ParentId] = append(children[*position.ParentId], position)
+	}
+	return positionNodes(roots, children), nil
+}
+
+func positionNodes(positions []domain.Position, children map[uint][]domain.Position) []dto.PositionNodeDTO {
+	nodes := make([]dto.PositionNodeDTO, 0, len(positions))
+	for _, position := range positions {
+		nodes = append(nodes, dto.PositionNodeDTO{
+			Id:          position.Id,
+			Code:        position.Code,
+			Name:        position.Name,
+			Description: position.Description,
+			Rank:        position.Rank,
+			Children:    positionNodes(children[position.Id], children),
+		})
+	}
+	return nodes
+}
+
+// GetPositionByID retrieves a position by its ID.
+func (u *PositionUsecase) GetPositionByID(id uint) (*domain.Position, error) {
+	return u.PositionRepo.GetByID(id)
+}
+
+// UpdatePosition updates a position. Moving it under itself or under one of
+// its subordinates is refused.
+func (u *PositionUsecase) UpdatePosition(id uint, input dto.PositionUpdateDTO) error {
+	position, err := u.PositionRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	// walk up from the new parent, the position must not be met on the way
+	for parentId := input.ParentId; parentId != nil; {
+		if *parentId == id {
+			return domain.ErrPositionCycle
+		}
+		parent, err := u.PositionRepo.GetByID(*parentId)
+		if err != nil {
+			return err
+		}
+		parentId = parent.ParentId
+	}
+	position.Code = input.Code
+	position.Name = input.Name
+	position.Description = input.Description
+	position.ParentId = input.ParentId
+	position.Rank = input.Rank
+	return u.PositionRepo.Update(position)
+}
+
+// DeletePosition deletes a position by its ID.
+func (u *PositionUsecase) DeletePosition(id uint) error {
+	return u.PositionRepo.Delete(id)
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPositionRepository is a mock implementation of the PositionRepositoryInterface.
+type MockPositionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPositionRepository) Create(position *domain.Position) error {
+	args := m.Called(position)
+	return args.Error(0)
+}
+
+func (m *MockPositionRepository) List() ([]domain.Position, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Position), args.Error(1)
+}
+
+func (m *MockPositionRepository) GetByID(id uint) (*domain.Position, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Position), args.Error(1)
+}
+
+func (m *MockPositionRepository) Update(position *domain.Position) error {
+	args := m.Called(position)
+	return args.Error(0)
+}
+
+func (m *MockPositionRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestListPositions(t *testing.T) {
+	mockRepo := new(MockPositionRepository)
+	usecase := NewPositionUsecase(mockRepo)
+
+	mockRepo.On("List").Return([]domain.Position{
+		{Id: 1, Code: "COM", Rank: 1},
+		{Id: 2, Code: "MNVC", ParentId: uintPtr(1), Rank: 2},
+		{Id: 3, Code: "CVL", ParentId: uintPtr(2), Rank: 3},
+		{Id: 4, Code: "GST", Rank: 9},
+	}, nil)
+
+	tree, err := usecase.ListPositions()
+
+	assert.NoError(t, err)
+	if assert.Len(t, tree, 2) {
+		assert.Equal(t, "COM", tree[0].Code)
+		assert.Equal(t, "MNVC", tree[0].Children[0].Code)
+		assert.Equal(t, "CVL", tree[0].Children[0].Children[0].Code)
+		assert.Empty(t, tree[1].Children)
+	}
+}
+
+func TestCreatePosition_UnknownParent(t *testing.T) {
+	mockRepo := new(MockPositionRepository)
+	usecase := NewPositionUsecase(mockRepo)
+
+	mockRepo.On("GetByID", uint(9)).Return(nil, domain.ErrPositionNotFound)
+
+	_, err := usecase.CreatePosition(dto.PositionCreateDTO{Code: "CVL", Name: "Civil volunteer", ParentId: uintPtr(9)})
+
+	assert.ErrorIs(t, err, domain.ErrPositionNotFound)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdatePosition_Cycle(t *testing.T) {
+	mockRepo := new(MockPositionRepository)
+	usecase := NewPositionUsecase(mockRepo)
+
+	mockRepo.On("GetByID", uint(1)).Return(&domain.Position{Id: 1, Code: "COM"}, nil)
+	mockRepo.On("GetByID", uint(2)).Return(&domain.Position{Id: 2, Code: "MNVC", ParentId: uintPtr(1)}, nil)
+	mockRepo.On("GetByID", uint(3)).Return(&domain.Position{Id: 3, Code: "CVL", ParentId: uintPtr(2)}, nil)
+
+	err := usecase.UpdatePosition(1, dto.PositionUpdateDTO{Code: "COM", Name: "Committee", ParentId: uintPtr(3)})
+	assert.ErrorIs(t, err, domain.ErrPositionCycle)
+
+	err = usecase.UpdatePosition(1, dto.PositionUpdateDTO{Code: "COM", Name: "Committee", ParentId: uintPtr(1)})
+	assert.ErrorIs(t, err, domain.ErrPositionCycle)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+
+	mockRepo.On("Update", mock.Anything).Return(nil)
+	err = usecase.UpdatePosition(3, dto.PositionUpdateDTO{Code: "CVL", Name: "Civil volunteer", ParentId: uintPtr(1), Rank: 3})
+	assert.NoError(t, err)
+}
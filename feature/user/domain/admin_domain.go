@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	ID                 int       `gorm:"primaryKey"`
@@ -16,28 +20,39 @@ type User struct {
 	CountryID          int       `gorm:"index"`
 	ResidentCountryID  int       `gorm:"index"`
 	Avatar             *string
-	VerificationStatus int       `gorm:"default:0"`
-	Status             int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	VerificationStatus int            `gorm:"default:0"`
+	Status             int            `gorm:"not null"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
 }
 
+// Request is a registration or verification request. A resubmission links to
+// the rejected request it replaces through PreviousRequestID and keeps that
+// request's notes in PreviousRejectNotes. ClaimedBy is the reviewer holding
+// the request until ClaimExpiresAt.
 type Request struct {
-	ID          int    `gorm:"primaryKey"`
-	UserID      uint   `gorm:"index"`
-	Type        string `gorm:"not null"`
-	Status      int    `gorm:"not null"`
-	RejectNotes string
-	VerifierID  int       `gorm:"index"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	ID                  int    `gorm:"primaryKey"`
+	UserID              uint   `gorm:"index"`
+	Type                string `gorm:"not null"`
+	Status              int    `gorm:"not null"`
+	RejectNotes         string
+	VerifierID          int  `gorm:"index"`
+	PreviousRequestID   *int `gorm:"index"`
+	PreviousRejectNotes string
+	ClaimedBy           *int `gorm:"index"`
+	ClaimExpiresAt      *time.Time
+	CreatedAt           time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
 }
 
 type VolunteerDetail struct {
-	ID           int       `gorm:"primaryKey"`
-	UserID       uint      `gorm:"index"`
-	DepartmentID int       `gorm:"index"`
-	Status       int       `gorm:"not null"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	ID           int            `gorm:"primaryKey"`
+	UserID       uint           `gorm:"index"`
+	DepartmentID int            `gorm:"index"`
+	Status       int            `gorm:"not null"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
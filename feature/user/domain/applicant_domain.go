@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type ApplicantDomain struct {
 	ID                 int `gorm:"primaryKey"`
@@ -16,8 +20,14 @@ type ApplicantDomain struct {
 	CountryID          int       `gorm:"not null"`
 	ResidentCountryID  int       `gorm:"not null"`
 	Avatar             string
-	VerificationStatus int       `gorm:"default:0"`
-	Status             int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	VerificationStatus int            `gorm:"default:0"`
+	Status             int            `gorm:"not null"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (ApplicantDomain) TableName() string {
+	return "users"
 }
@@ -10,3 +10,8 @@ type ApplicantRequestDomain struct {
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
+
+// TableName overrides the default table name used by GORM.
+func (ApplicantRequestDomain) TableName() string {
+	return "requests"
+}
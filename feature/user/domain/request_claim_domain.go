@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RequestView records the last time an admin opened a request.
+type RequestView struct {
+	RequestID int       `gorm:"primaryKey;autoIncrement:false"`
+	AdminID   int       `gorm:"primaryKey;autoIncrement:false"`
+	ViewedAt  time.Time `gorm:"not null"`
+}
+
+// RequestClaimedError is returned when a reviewer acts on a request another
+// reviewer holds an unexpired claim on.
+type RequestClaimedError struct {
+	ClaimedBy int
+	ExpiresAt time.Time
+}
+
+func (e *RequestClaimedError) Error() string {
+	return fmt.Sprintf("request is claimed by reviewer %d until %s", e.ClaimedBy, e.ExpiresAt.UTC().Format(time.RFC3339))
+}
+
+// IsOpen reports whether the request is waiting for a decision.
+func (r *Request) IsOpen() bool {
+	return r.Status == RequestStatusPending || r.Status == RequestStatusInReview
+}
+
+// ClaimHolder returns the reviewer holding an unexpired claim on the request
+// at the given time.
+func (r *Request) ClaimHolder(now time.Time) (int, bool) {
+	if r.ClaimedBy == nil || r.ClaimExpiresAt == nil || !r.ClaimExpiresAt.After(now) {
+		return 0, false
+	}
+	return *r.ClaimedBy, true
+}
+
+// CheckClaim returns a *RequestClaimedError when the request is claimed by
+// a reviewer other than reviewerID.
+func (r *Request) CheckClaim(reviewerID int, now time.Time) error {
+	if holder, ok := r.ClaimHolder(now); ok && holder != reviewerID {
+		return &RequestClaimedError{ClaimedBy: holder, ExpiresAt: *r.ClaimExpiresAt}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Request event actions.
+const (
+	RequestEventSubmit   = "submit"
+	RequestEventReview   = "review"
+	RequestEventApprove  = "approve"
+	RequestEventReject   = "reject"
+	RequestEventNote     = "note"
+	RequestEventCancel   = "cancel"
+	RequestEventResubmit = "resubmit"
+)
+
+// RequestEvent is an append-only record of something that happened to a
+// request: a status transition or a note, who did it and when.
+type RequestEvent struct {
+	ID         int    `gorm:"primaryKey"`
+	RequestID  int    `gorm:"index;not null"`
+	ActorID    *int   `gorm:"index"`
+	Action     string `gorm:"not null"`
+	FromStatus *int
+	ToStatus   int `gorm:"not null"`
+	Notes      string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// RequestMessage is a message in the conversation between the admins and the
+// owner of a request. FromAdmin tells which side wrote it, ReadAt is set once
+// the other side has read it.
+type RequestMessage struct {
+	ID        int    `gorm:"primaryKey"`
+	RequestID int    `gorm:"index;not null"`
+	SenderID  int    `gorm:"index;not null"`
+	FromAdmin bool   `gorm:"not null"`
+	Body      string `gorm:"not null"`
+	ReadAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// RequestUnreadCount is the number of unread messages in the thread of a
+// request.
+type RequestUnreadCount struct {
+	RequestID int
+	Unread    int64
+}
+
+// AcceptsMessages reports whether new messages can be posted to the thread of
+// the request. Threads of approved, cancelled and resubmitted requests are
+// read-only.
+func (r *Request) AcceptsMessages() bool {
+	switch r.Status {
+	case RequestStatusPending, RequestStatusInReview, RequestStatusRejected:
+		return true
+	}
+	return false
+}
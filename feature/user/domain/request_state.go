@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Request statuses. The numeric values are stored in requests.status, 0-2 keep
+// the meaning they had before the lifecycle was introduced.
+const (
+	RequestStatusPending     = 0
+	RequestStatusApproved    = 1
+	RequestStatusRejected    = 2
+	RequestStatusInReview    = 3
+	RequestStatusResubmitted = 4
+	RequestStatusCancelled   = 5
+)
+
+// Request types.
+const (
+	RequestTypeRegistration = "registration"
+	RequestTypeVerification = "verification"
+)
+
+var (
+	ErrRequestNotFound    = errors.New("request not found")
+	ErrInvalidRequestType = errors.New("invalid request type")
+	ErrDepartmentRequired = errors.New("user has no department")
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrRequestClosed      = errors.New("request no longer accepts messages")
+	ErrRequestNotOpen     = errors.New("request is not waiting for a decision")
+	ErrNotesClosed        = errors.New("request no longer accepts reject notes")
+	ErrReviewerNotFound   = errors.New("reviewer not found")
+	ErrBulkNoSelection    = errors.New("either ids or a filter is required")
+	ErrBulkTooLarge       = errors.New("too many requests selected")
+	ErrApplicantNotFound  = errors.New("applicant not found")
+)
+
+// InvalidTransitionError is returned when a request cannot move from its
+// current status to the requested one.
+type InvalidTransitionError struct {
+	From int
+	To   int
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("request cannot move from %s to %s", RequestStatusName(e.From), RequestStatusName(e.To))
+}
+
+var requestStatusNames = map[int]string{
+	RequestStatusPending:     "pending",
+	RequestStatusApproved:    "approved",
+	RequestStatusRejected:    "rejected",
+	RequestStatusInReview:    "in_review",
+	RequestStatusResubmitted: "resubmitted",
+	RequestStatusCancelled:   "cancelled",
+}
+
+// requestTransitions lists, for every status, the statuses it may move to.
+// Requests are decided in review: pending → in_review → approved/rejected.
+// Approved, resubmitted (superseded by a new submission) and cancelled
+// requests are final.
+var requestTransitions = map[int][]int{
+	RequestStatusPending:  {RequestStatusInReview, RequestStatusCancelled},
+	RequestStatusInReview: {RequestStatusApproved, RequestStatusRejected, RequestStatusCancelled},
+	RequestStatusRejected: {RequestStatusResubmitted, RequestStatusCancelled},
+}
+
+// RequestStatusName returns the lifecycle name of a status.
+func RequestStatusName(status int) string {
+	if name, ok := requestStatusNames[status]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", status)
+}
+
+// CanTransition reports whether a request may move from one status to another.
+func CanTransition(from, to int) bool {
+	for _, next := range requestTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the request to the given status, or returns an
+// *InvalidTransitionError leaving the request untouched.
+func (r *Request) TransitionTo(to int) error {
+	if !CanTransition(r.Status, to) {
+		return &InvalidTransitionError{From: r.Status, To: to}
+	}
+	r.Status = to
+	return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to int
+		want     bool
+	}{
+		{RequestStatusPending, RequestStatusInReview, true},
+		{RequestStatusPending, RequestStatusApproved, false},
+		{RequestStatusPending, RequestStatusRejected, false},
+		{RequestStatusInReview, RequestStatusRejected, true},
+		{RequestStatusRejected, RequestStatusResubmitted, true},
+		{RequestStatusResubmitted, RequestStatusApproved, false},
+		{RequestStatusRejected, RequestStatusCancelled, true},
+		{RequestStatusApproved, RequestStatusRejected, false},
+		{RequestStatusRejected, RequestStatusApproved, false},
+		{RequestStatusCancelled, RequestStatusPending, false},
+		{RequestStatusInReview, RequestStatusPending, false},
+	}
+	for _, tt := range tests {
+		t.Run(RequestStatusName(tt.from)+"->"+RequestStatusName(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestRequest_TransitionTo(t *testing.T) {
+	request := &Request{Status: RequestStatusApproved}
+
+	err := request.TransitionTo(RequestStatusRejected)
+
+	var transitionErr *InvalidTransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, RequestStatusApproved, transitionErr.From)
+	assert.Equal(t, RequestStatusRejected, transitionErr.To)
+	assert.Equal(t, "request cannot move from approved to rejected", err.Error())
+	assert.Equal(t, RequestStatusApproved, request.Status)
+
+	request.Status = RequestStatusPending
+	assert.NoError(t, request.TransitionTo(RequestStatusInReview))
+	assert.Equal(t, RequestStatusInReview, request.Status)
+}
@@ -10,3 +10,8 @@ type VolunteerRequest struct {
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
+
+// TableName overrides the default table name used by GORM.
+func (VolunteerRequest) TableName() string {
+	return "requests"
+}
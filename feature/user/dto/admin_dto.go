@@ -1,10 +1,6 @@
 package dto
 
-import (
-	"time"
-
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
-)
+import "time"
 
 type PendingRequest struct {
 	ID       int       `json:"id"`
@@ -14,21 +10,152 @@ type PendingRequest struct {
 	UpdateAt time.Time `json:"update_at"`
 }
 
+// RequestResponse describes a request. PreviousRequestID and
+// PreviousRejectNotes are only set on resubmissions, ClaimedBy and
+// ClaimExpiresAt while a reviewer holds the request.
 type RequestResponse struct {
-	ID          int       `json:"id"`
-	UserID      uint      `json:"user_id"`
-	Type        string    `json:"type"`
-	Status      int       `json:"status"`
-	RejectNotes string    `json:"reject_notes"`
-	VerifierID  int       `json:"verifier_id"`
-	CreateAt    time.Time `json:"create_at"`
-	UpdateAt    time.Time `json:"update_at"`
+	ID                  int        `json:"id"`
+	UserID              uint       `json:"user_id"`
+	Type                string     `json:"type"`
+	Status              int        `json:"status"`
+	RejectNotes         string     `json:"reject_notes"`
+	VerifierID          int        `json:"verifier_id"`
+	PreviousRequestID   *int       `json:"previous_request_id,omitempty"`
+	PreviousRejectNotes string     `json:"previous_reject_notes,omitempty"`
+	ClaimedBy           *int       `json:"claimed_by,omitempty"`
+	ClaimExpiresAt      *time.Time `json:"claim_expires_at,omitempty"`
+	CreateAt            time.Time  `json:"create_at"`
+	UpdateAt            time.Time  `json:"update_at"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ListRequestQuery holds the pagination, filters and sort accepted by the
+// admin request listing. Sort is a column name, prefixed with "-" for
+// descending order.
+type ListRequestQuery struct {
+	Page        int        `form:"page" binding:"omitempty,min=1"`
+	PageSize    int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Type        string     `form:"type"`
+	Status      *int       `form:"status"`
+	VerifierID  *int       `form:"verifier_id"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02"`
+	Email       string     `form:"email"`
+	Name        string     `form:"name"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=id -id created_at -created_at updated_at -updated_at status -status type -type"`
 }
 
 type ListRequest struct {
-	Requests []*domain.Request `json:"requests"`
+	Requests   []*RequestResponse `json:"requests"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	Total      int64              `json:"total"`
+	TotalPages int                `json:"total_pages"`
 }
 
 type AddRejectNoteRequest struct {
 	Notes string `json:"notes"`
 }
+
+type RequestEventResponse struct {
+	ID         int       `json:"id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    *int      `json:"actor_id"`
+	Notes      string    `json:"notes,omitempty"`
+	CreateAt   time.Time `json:"create_at"`
+}
+
+type RequestHistoryResponse struct {
+	RequestID int                    `json:"request_id"`
+	Events    []RequestEventResponse `json:"events"`
+}
+
+type SendRequestMessageRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// RequestMessageResponse describes a message of a request thread. ReadAt is
+// set once the other side has read it.
+type RequestMessageResponse struct {
+	ID        int        `json:"id"`
+	RequestID int        `json:"request_id"`
+	SenderID  int        `json:"sender_id"`
+	FromAdmin bool       `json:"from_admin"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreateAt  time.Time  `json:"create_at"`
+}
+
+type RequestMessagesResponse struct {
+	RequestID int                      `json:"request_id"`
+	Messages  []RequestMessageResponse `json:"messages"`
+}
+
+type RequestUnreadCount struct {
+	RequestID int   `json:"request_id"`
+	Unread    int64 `json:"unread"`
+}
+
+// UnreadMessagesResponse lists the requests with unread messages and the
+// total number of unread messages across them.
+type UnreadMessagesResponse struct {
+	Total    int64                `json:"total"`
+	Requests []RequestUnreadCount `json:"requests"`
+}
+
+type AssignRequestRequest struct {
+	ReviewerID int `json:"reviewer_id" binding:"required,min=1"`
+}
+
+type RequestViewResponse struct {
+	AdminID  int       `json:"admin_id"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+type RequestViewsResponse struct {
+	RequestID int                   `json:"request_id"`
+	Views     []RequestViewResponse `json:"views"`
+}
+
+// Results of the items of a bulk action.
+const (
+	BulkResultSucceeded = "succeeded"
+	BulkResultSkipped   = "skipped"
+	BulkResultFailed    = "failed"
+)
+
+// BulkRequestFilter selects requests with the filters of the request listing.
+// Dates are formatted as YYYY-MM-DD.
+type BulkRequestFilter struct {
+	Type        string `json:"type"`
+	Status      *int   `json:"status"`
+	VerifierID  *int   `json:"verifier_id"`
+	CreatedFrom string `json:"created_from" binding:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string `json:"created_to" binding:"omitempty,datetime=2006-01-02"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+}
+
+// BulkRequestRequest selects the requests of a bulk action, either by id or
+// through a filter.
+type BulkRequestRequest struct {
+	IDs    []int              `json:"ids" binding:"omitempty,max=500,dive,min=1"`
+	Filter *BulkRequestFilter `json:"filter"`
+}
+
+// BulkRequestItem is the outcome of a bulk action on one request. Error tells
+// why the request was skipped or failed.
+type BulkRequestItem struct {
+	ID     int    `json:"id"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkRequestResponse struct {
+	Succeeded int               `json:"succeeded"`
+	Skipped   int               `json:"skipped"`
+	Failed    int               `json:"failed"`
+	Items     []BulkRequestItem `json:"items"`
+}
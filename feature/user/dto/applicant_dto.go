@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type ApplicantCreateDTO struct {
 	Email   string `json:"email" binding:"required"`
 	Name    string `json:"name" binding:"required"`
@@ -20,15 +22,28 @@ type ApplicantUpdateDTO struct {
 }
 
 type ApplicantResponseDTO struct {
-	ID                int    `json:"id"`
-	Email             string `json:"email"`
-	Name              string `json:"name"`
-	Surname           string `json:"surname"`
+	ID                int        `json:"id"`
+	Email             string     `json:"email"`
+	Name              string     `json:"name"`
+	Surname           string     `json:"surname"`
+	Gender            string     `json:"gender"`
+	DOB               string     `json:"dob"`
+	Mobile            string     `json:"mobile"`
+	RoleID            int        `json:"role_id"`
+	CountryID         int        `json:"country_id"`
+	ResidentCountryID int        `json:"resident_country_id"`
+	DepartmentID      int        `json:"department_id"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ApplicantProfileUpdateDTO is what applicants may change on their own
+// profile. Email, role and department are not part of it.
+type ApplicantProfileUpdateDTO struct {
+	Name              string `json:"name" binding:"required"`
+	Surname           string `json:"surname" binding:"required"`
 	Gender            string `json:"gender"`
 	DOB               string `json:"dob"`
 	Mobile            string `json:"mobile"`
-	RoleID            int    `json:"role_id"`
 	CountryID         int    `json:"country_id"`
 	ResidentCountryID int    `json:"resident_country_id"`
-	DepartmentID      int    `json:"department_id"`
 }
@@ -1,7 +1,13 @@
 package dto
 
+// ApplicantRequestCreatingDTO submits a request for a user. Requests always
+// start pending.
 type ApplicantRequestCreatingDTO struct {
 	UserID int    `json:"user_id" binding:"required"`
 	Type   string `json:"type" binding:"required"`
-	Status int    `json:"status" binding:"required"`
+}
+
+// MyRequestCreatingDTO submits a request for the authenticated user.
+type MyRequestCreatingDTO struct {
+	Type string `json:"type" binding:"required,oneof=registration verification"`
 }
@@ -1,7 +1,8 @@
 package dto
 
+// VoluteerRequestCreatingDTO submits a request for a user. Requests always
+// start pending.
 type VoluteerRequestCreatingDTO struct {
 	UserID int    `json:"user_id" binding:"required"`
 	Type   string `json:"type" binding:"required"`
-	Status int    `json:"status" binding:"required"`
 }
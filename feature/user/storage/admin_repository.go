@@ -1,22 +1,44 @@
 package storage
 
 import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"gorm.io/gorm"
-	"strings"
+	"gorm.io/gorm/clause"
 )
 
 type AdminRepositoryInterface interface {
-	GetListPendingRequest() ([]*domain.Request, string)
+	ListRequests(query dto.ListRequestQuery) ([]*domain.Request, int64, string)
+	ListRequestIDs(query dto.ListRequestQuery, limit int) ([]int, error)
 	GetPendingRequestByID(id int) (*domain.Request, string)
-	GetListAllRequest() ([]*domain.Request, string)
 	GetRequestByID(id int) (*domain.Request, string)
-	ApproveRequest(id int, verifier_id int) string
-	RejectRequest(id int, verifier_id int) string
-	AddRejectNotes(id int, notes string) string
+	ReviewRequest(id int, verifier_id int) error
+	ApproveRequest(id int, verifier_id int) error
+	RejectRequest(id int, verifier_id int) error
+	AddRejectNotes(id int, verifier_id int, notes string) error
+	GetRequestHistory(id int) ([]*domain.RequestEvent, string)
 	DeleteRequest(id int) string
+	ListDeletedRequests() ([]*domain.Request, error)
+	RestoreRequest(id int) error
+	ListRequestMessages(id int) ([]*domain.RequestMessage, error)
+	SendRequestMessage(id int, adminID int, body string) (*domain.RequestMessage, error)
+	CountUnreadMessages() ([]domain.RequestUnreadCount, error)
+	MarkRequestViewed(id int, adminID int) error
+	ListRequestViews(id int) ([]*domain.RequestView, error)
+	ClaimRequest(id int, adminID int, reviewerID int, expiresAt time.Time) (*domain.Request, error)
+	ReleaseRequest(id int, adminID int) error
 }
 
+// MsgDeleteRequestSuccess is returned by DeleteRequest once the request is
+// gone.
+const MsgDeleteRequestSuccess = "Delete request success"
+
 type AdminRepository struct {
 	db *gorm.DB
 }
@@ -24,39 +46,106 @@ type AdminRepository struct {
 func NewAdminRepository(db *gorm.DB) *AdminRepository {
 	return &AdminRepository{db: db}
 }
-func (r *AdminRepository) GetListPendingRequest() ([]*domain.Request, string) {
-	var listRequest []*domain.Request
-	result := r.db.Where("status = ?", 0).Find(&listRequest)
+
+// requestSortColumns maps the sort keys accepted by ListRequests to columns.
+var requestSortColumns = map[string]string{
+	"id":         "requests.id",
+	"created_at": "requests.created_at",
+	"updated_at": "requests.updated_at",
+	"status":     "requests.status",
+	"type":       "requests.type",
+}
+
+// ListRequests returns one page of requests matching the query, newest first
+// unless a sort is given, together with the number of matching requests.
+// Page and PageSize must already be set.
+func (r *AdminRepository) ListRequests(query dto.ListRequestQuery) ([]*domain.Request, int64, string) {
+	db := filterRequests(r.db.Model(&domain.Request{}), query)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err.Error()
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = "-created_at"
+	}
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	column, ok := requestSortColumns[sort]
+	if !ok {
+		return nil, 0, "Invalid sort field"
+	}
+
+	listRequest := make([]*domain.Request, 0)
+	result := db.Select("requests.*").
+		Order(column + " " + direction).
+		Order("requests.id " + direction).
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize).
+		Find(&listRequest)
 	if result.Error != nil {
-		return nil, result.Error.Error()
+		return nil, 0, result.Error.Error()
 	}
-	if len(listRequest) == 0 {
-		return nil, "No request pending"
+	return listRequest, total, ""
+}
+
+// ListRequestIDs returns the ids of at most limit requests matching the
+// filters of the query, lowest first. Pagination and sort are ignored.
+func (r *AdminRepository) ListRequestIDs(query dto.ListRequestQuery, limit int) ([]int, error) {
+	ids := make([]int, 0)
+	err := filterRequests(r.db.Model(&domain.Request{}), query).
+		Order("requests.id").
+		Limit(limit).
+		Pluck("requests.id", &ids).Error
+	return ids, err
+}
+
+// filterRequests narrows db down to the requests matching the filters of the
+// query.
+func filterRequests(db *gorm.DB, query dto.ListRequestQuery) *gorm.DB {
+	if query.Type != "" {
+		db = db.Where("requests.type = ?", query.Type)
+	}
+	if query.Status != nil {
+		db = db.Where("requests.status = ?", *query.Status)
+	}
+	if query.VerifierID != nil {
+		db = db.Where("requests.verifier_id = ?", *query.VerifierID)
+	}
+	if query.CreatedFrom != nil {
+		db = db.Where("requests.created_at >= ?", *query.CreatedFrom)
 	}
-	return listRequest, ""
+	if query.CreatedTo != nil {
+		// the bound is a date, include the whole day
+		db = db.Where("requests.created_at < ?", query.CreatedTo.AddDate(0, 0, 1))
+	}
+	if query.Email != "" || query.Name != "" {
+		db = db.Joins("JOIN users ON users.id = requests.user_id")
+		if query.Email != "" {
+			db = db.Where("LOWER(users.email) LIKE ?", "%"+strings.ToLower(query.Email)+"%")
+		}
+		if query.Name != "" {
+			name := "%" + strings.ToLower(query.Name) + "%"
+			db = db.Where("LOWER(users.name) LIKE ? OR LOWER(users.surname) LIKE ?", name, name)
+		}
+	}
+	return db
 }
 
 func (r *AdminRepository) GetPendingRequestByID(id int) (*domain.Request, string) {
 	var request domain.Request
-	result := r.db.Where("id = ? and status = 0", id).First(&request)
+	result := r.db.Where("id = ? and status = ?", id, domain.RequestStatusPending).First(&request)
 	if result.Error != nil {
 		return nil, result.Error.Error()
 	}
 	return &request, ""
 }
 
-func (r *AdminRepository) GetListAllRequest() ([]*domain.Request, string) {
-	var listRequest []*domain.Request
-	result := r.db.Find(&listRequest)
-	if result.Error != nil {
-		return nil, result.Error.Error()
-	}
-	if len(listRequest) == 0 {
-		return nil, "No request found"
-	}
-	return listRequest, ""
-}
-
 func (r *AdminRepository) GetRequestByID(id int) (*domain.Request, string) {
 	var request domain.Request
 	result := r.db.Where("id = ?", id).First(&request)
@@ -66,95 +155,393 @@ func (r *AdminRepository) GetRequestByID(id int) (*domain.Request, string) {
 	return &request, ""
 }
 
-// ApproveRequest change status of request to 1 (approved)
-// change verifier_id to admin id
-// if requestType is registration, change user role to 1 (applicant)
-// else if requestType is verification, change user role to 2 (volunteer) and change verification status to 1 (active)
-// and insert this user to volunteer_details table
-func (r *AdminRepository) ApproveRequest(id int, verifier_id int) string {
-	// get request type
-	request := r.getRequestByRequestID(id)
-	if request == nil {
-		return "Request not found"
+// MarkRequestViewed records that an admin has opened a request. Viewing it
+// again moves the time forward.
+func (r *AdminRepository) MarkRequestViewed(id int, adminID int) error {
+	if err := r.db.First(&domain.Request{}, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrRequestNotFound
+		}
+		return err
 	}
-	if request.Status != 0 {
-		return "Request already processed"
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "request_id"}, {Name: "admin_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"viewed_at"}),
+	}).Create(&domain.RequestView{RequestID: id, AdminID: adminID, ViewedAt: time.Now()}).Error
+}
+
+// ListRequestViews returns the admins who have opened a request, most recent
+// view first.
+func (r *AdminRepository) ListRequestViews(id int) ([]*domain.RequestView, error) {
+	if err := r.db.First(&domain.Request{}, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRequestNotFound
+		}
+		return nil, err
 	}
-	userID := request.UserID
-	if strings.TrimSpace(request.Type) == "registration" {
-		result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 1).Update("verifier_id", verifier_id)
+	views := make([]*domain.RequestView, 0)
+	err := r.db.Where("request_id = ?", id).Order("viewed_at DESC, admin_id").Find(&views).Error
+	return views, err
+}
+
+// ClaimRequest gives reviewerID the request until expiresAt. adminID claims
+// for themselves by passing their own id as reviewerID, or assigns the request
+// to another reviewer. A request claimed by someone else is only taken over
+// once the claim has expired; renewing a claim extends it.
+func (r *AdminRepository) ClaimRequest(id int, adminID int, reviewerID int, expiresAt time.Time) (*domain.Request, error) {
+	var request domain.Request
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if reviewerID != adminID {
+			if err := tx.First(&domain.User{}, reviewerID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return domain.ErrReviewerNotFound
+				}
+				return err
+			}
+		}
+		// the update is guarded by the current claim, so two reviewers racing
+		// for the same request cannot both get it
+		now := time.Now()
+		result := tx.Model(&domain.Request{}).
+			Where("id = ? AND status IN ?", id, []int{domain.RequestStatusPending, domain.RequestStatusInReview}).
+			Where("claimed_by IS NULL OR claimed_by IN ? OR claim_expires_at <= ?", []int{adminID, reviewerID}, now).
+			Updates(map[string]interface{}{"claimed_by": reviewerID, "claim_expires_at": expiresAt})
 		if result.Error != nil {
-			return result.Error.Error()
+			return result.Error
 		}
-		// change user role to 1 (applicant)
-		s, done := updateRoleId(result, r, userID, 1)
-		if done {
-			return s
+		if err := tx.First(&request, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrRequestNotFound
+			}
+			return err
 		}
-		return "Approve request success"
-	} else if strings.TrimSpace(request.Type) == "verification" {
-		result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 1).Update("verifier_id", verifier_id)
-		if result.Error != nil {
-			return result.Error.Error()
-		}
-		// change user role to 2 (volunteer)
-		s, done := updateRoleId(result, r, userID, 2)
-		if done {
-			return s
-		}
-		// insert to volunteer_details
-		departmentID := r.getDeptIdFromUser(userID)
-		volunteerDetail := domain.VolunteerDetail{
-			UserID:       userID,
-			DepartmentID: *departmentID,
-			Status:       1,
+		if result.RowsAffected > 0 {
+			return nil
 		}
-		result = r.db.Create(&volunteerDetail)
-		if result.Error != nil {
-			return result.Error.Error()
+		if !request.IsOpen() {
+			return domain.ErrRequestNotOpen
+		}
+		if err := request.CheckClaim(adminID, now); err != nil {
+			return err
 		}
-		return "Approve request success"
+		return request.CheckClaim(reviewerID, now)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return "Invalid request type"
+	return &request, nil
 }
-func (r *AdminRepository) RejectRequest(id int, verifier_id int) string {
-	result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 2).Update("verifier_id", verifier_id)
-	if result.Error != nil {
-		return result.Error.Error()
-	}
-	return "Reject request success"
+
+// ReleaseRequest drops the claim on a request. Only the reviewer holding the
+// claim may release it before it expires.
+func (r *AdminRepository) ReleaseRequest(id int, adminID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var request domain.Request
+		if err := tx.First(&request, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrRequestNotFound
+			}
+			return err
+		}
+		if err := request.CheckClaim(adminID, time.Now()); err != nil {
+			return err
+		}
+		return tx.Model(&domain.Request{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"claimed_by": nil, "claim_expires_at": nil}).Error
+	})
 }
-func (r *AdminRepository) AddRejectNotes(id int, notes string) string {
-	result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("reject_notes", notes)
-	if result.Error != nil {
-		return result.Error.Error()
+
+// ReviewRequest moves a request into review and records the reviewing admin.
+func (r *AdminRepository) ReviewRequest(id int, verifier_id int) error {
+	return r.transition(id, domain.RequestStatusInReview, verifier_id, domain.RequestEventReview, nil)
+}
+
+// ApproveRequest approves a request and records the approving admin.
+// A registration promotes the user to applicant, a verification promotes the
+// user to volunteer, marks them verified and inserts their volunteer_details row.
+// Everything happens in one transaction, so a failing step leaves no trace,
+// and the approval email is queued in the same transaction.
+func (r *AdminRepository) ApproveRequest(id int, verifier_id int) error {
+	return r.transition(id, domain.RequestStatusApproved, verifier_id, domain.RequestEventApprove, func(tx *gorm.DB, request *domain.Request) error {
+		if err := promoteUser(tx, request); err != nil {
+			return err
+		}
+		return enqueueRequestEmail(tx, request, mailer.TemplateRequestApproved)
+	})
+}
+
+// RejectRequest rejects a request, records the rejecting admin and queues the
+// rejection email with the notes added so far.
+func (r *AdminRepository) RejectRequest(id int, verifier_id int) error {
+	return r.transition(id, domain.RequestStatusRejected, verifier_id, domain.RequestEventReject, func(tx *gorm.DB, request *domain.Request) error {
+		return enqueueRequestEmail(tx, request, mailer.TemplateRequestRejected)
+	})
+}
+
+// AddRejectNotes replaces the current reject notes of a pending, in review or
+// rejected request. Earlier notes stay available in the request history.
+// Notes added to a rejected request are emailed to the applicant. Like a
+// decision, it is refused on a request claimed by another reviewer.
+func (r *AdminRepository) AddRejectNotes(id int, verifier_id int, notes string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var request domain.Request
+		if err := tx.First(&request, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrRequestNotFound
+			}
+			return err
+		}
+		if !request.IsOpen() && request.Status != domain.RequestStatusRejected {
+			return domain.ErrNotesClosed
+		}
+		if err := request.CheckClaim(verifier_id, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Request{}).Where("id = ?", id).Update("reject_notes", notes).Error; err != nil {
+			return err
+		}
+		if err := recordRequestEvent(tx, &request, verifier_id, domain.RequestEventNote, request.Status, notes); err != nil {
+			return err
+		}
+		// the applicant was already told about the rejection, tell them why
+		if request.Status != domain.RequestStatusRejected {
+			return nil
+		}
+		request.RejectNotes = notes
+		return enqueueRequestEmail(tx, &request, mailer.TemplateRequestRejected)
+	})
+}
+
+// GetRequestHistory returns every event recorded for a request, oldest first.
+func (r *AdminRepository) GetRequestHistory(id int) ([]*domain.RequestEvent, string) {
+	var request domain.Request
+	if err := r.db.First(&request, id).Error; err != nil {
+		return nil, "Request not found"
+	}
+	events, err := listRequestEvents(r.db, id)
+	if err != nil {
+		return nil, err.Error()
 	}
-	return "Add reject notes success"
+	return events, ""
 }
 func (r *AdminRepository) DeleteRequest(id int) string {
 	result := r.db.Where("id = ?", id).Delete(&domain.Request{})
 	if result.Error != nil {
 		return result.Error.Error()
 	}
-	return "Delete request success"
+	if result.RowsAffected == 0 {
+		return domain.ErrRequestNotFound.Error()
+	}
+	return MsgDeleteRequestSuccess
 }
 
-func (r *AdminRepository) getRequestByRequestID(requestID int) *domain.Request {
-	var request *domain.Request
-	r.db.First(&request, requestID)
-	return request
+// ListRequestMessages returns the thread of a request and marks the messages
+// of its owner as read.
+func (r *AdminRepository) ListRequestMessages(id int) ([]*domain.RequestMessage, error) {
+	var messages []*domain.RequestMessage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&domain.Request{}, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrRequestNotFound
+			}
+			return err
+		}
+		var err error
+		messages, err = readRequestMessages(tx, id, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
 }
 
-func (r *AdminRepository) getDeptIdFromUser(id uint) *int {
-	var user domain.User
-	r.db.First(&user, id)
-	return user.DepartmentID
+// SendRequestMessage posts a message from an admin to the owner of a request
+// and emails it to them.
+func (r *AdminRepository) SendRequestMessage(id int, adminID int, body string) (*domain.RequestMessage, error) {
+	var message *domain.RequestMessage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var request domain.Request
+		if err := tx.First(&request, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrRequestNotFound
+			}
+			return err
+		}
+		var err error
+		message, err = postRequestMessage(tx, &request, adminID, true, body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// CountUnreadMessages returns the number of unread messages from owners, per
+// request. Admins share one inbox, a message read by one admin is read for all.
+func (r *AdminRepository) CountUnreadMessages() ([]domain.RequestUnreadCount, error) {
+	return countUnreadMessages(r.db, false, nil)
+}
+
+// ListDeletedRequests returns the soft deleted requests, most recently
+// deleted first.
+func (r *AdminRepository) ListDeletedRequests() ([]*domain.Request, error) {
+	requests := make([]*domain.Request, 0)
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id DESC").Find(&requests).Error
+	return requests, err
 }
 
-func updateRoleId(result *gorm.DB, r *AdminRepository, userID uint, roleId int) (string, bool) {
-	result = r.db.Model(&domain.User{}).Where("id = ?", userID).Update("role_id", roleId)
+// RestoreRequest brings back a soft deleted request.
+func (r *AdminRepository) RestoreRequest(id int) error {
+	result := r.db.Unscoped().Model(&domain.Request{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
 	if result.Error != nil {
-		return result.Error.Error(), true
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRequestNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedRequests permanently removes the requests soft deleted before
+// the given time, together with their history, messages and views.
+func (r *AdminRepository) PurgeDeletedRequests(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Unscoped().Model(&domain.Request{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		var err error
+		purged, err = purgeRequests(tx, ids)
+		return err
+	})
+	return purged, err
+}
+
+// purgeRequests permanently removes the given requests and the rows recorded
+// about them.
+func purgeRequests(tx *gorm.DB, ids []int) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	// resubmissions outlive the request they replaced
+	if err := tx.Unscoped().Model(&domain.Request{}).Where("previous_request_id IN ?", ids).Update("previous_request_id", nil).Error; err != nil {
+		return 0, err
+	}
+	for _, model := range []interface{}{&domain.RequestEvent{}, &domain.RequestMessage{}, &domain.RequestView{}} {
+		if err := tx.Where("request_id IN ?", ids).Delete(model).Error; err != nil {
+			return 0, err
+		}
+	}
+	result := tx.Unscoped().Where("id IN ?", ids).Delete(&domain.Request{})
+	return result.RowsAffected, result.Error
+}
+
+// transition moves a request to the given status inside a transaction,
+// running apply for the side effects of the transition first and recording
+// the transition in the request history. A decision on a pending request
+// first moves it into review, in the same transaction. The status update
+// is guarded by the status that was read, so two admins racing on the same
+// request cannot both succeed. A request claimed by another reviewer is
+// refused with a *RequestClaimedError, and a decided request loses its claim.
+func (r *AdminRepository) transition(id int, to int, verifier_id int, action string, apply func(tx *gorm.DB, request *domain.Request) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var request domain.Request
+		if err := tx.First(&request, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrRequestNotFound
+			}
+			return err
+		}
+		if err := request.CheckClaim(verifier_id, time.Now()); err != nil {
+			return err
+		}
+		if request.Status == domain.RequestStatusPending && to != domain.RequestStatusInReview && domain.CanTransition(domain.RequestStatusInReview, to) {
+			if err := request.TransitionTo(domain.RequestStatusInReview); err != nil {
+				return err
+			}
+			review := map[string]interface{}{"verifier_id": verifier_id}
+			if err := saveTransition(tx, &request, domain.RequestStatusPending, verifier_id, domain.RequestEventReview, review); err != nil {
+				return err
+			}
+		}
+		from := request.Status
+		if err := request.TransitionTo(to); err != nil {
+			return err
+		}
+		if apply != nil {
+			if err := apply(tx, &request); err != nil {
+				return err
+			}
+		}
+		columns := map[string]interface{}{"verifier_id": verifier_id}
+		if !request.IsOpen() {
+			columns["claimed_by"] = nil
+			columns["claim_expires_at"] = nil
+		}
+		return saveTransition(tx, &request, from, verifier_id, action, columns)
+	})
+}
+
+// promoteUser gives the owner of an approved request the role the request
+// was for.
+func promoteUser(tx *gorm.DB, request *domain.Request) error {
+	switch strings.TrimSpace(request.Type) {
+	case domain.RequestTypeRegistration:
+		return updateRoleId(tx, request.UserID, roleDomain.RoleApplicant)
+	case domain.RequestTypeVerification:
+		var user domain.User
+		if err := tx.First(&user, request.UserID).Error; err != nil {
+			return err
+		}
+		if user.DepartmentID == nil {
+			return domain.ErrDepartmentRequired
+		}
+		if err := updateRoleId(tx, request.UserID, roleDomain.RoleVolunteer); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.User{}).Where("id = ?", request.UserID).Update("verification_status", 1).Error; err != nil {
+			return err
+		}
+		return tx.Create(&domain.VolunteerDetail{
+			UserID:       request.UserID,
+			DepartmentID: *user.DepartmentID,
+			Status:       1,
+		}).Error
+	}
+	return domain.ErrInvalidRequestType
+}
+
+// enqueueRequestEmail queues the email telling the owner of a request about
+// its outcome.
+func enqueueRequestEmail(tx *gorm.DB, request *domain.Request, template string) error {
+	var user domain.User
+	if err := tx.First(&user, request.UserID).Error; err != nil {
+		return err
+	}
+	msg, err := mailer.Render(user.Email, template, mailer.RequestData{
+		Name:      user.Name,
+		RequestID: request.ID,
+		Type:      request.Type,
+		Notes:     request.RejectNotes,
+	})
+	if err != nil {
+		return err
+	}
+	return mailer.Enqueue(tx, msg)
+}
+
+func updateRoleId(tx *gorm.DB, userID uint, roleName string) error {
+	var role roleDomain.Role
+	if err := tx.Where("name = ?", roleName).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrRoleNotFound
+		}
+		return err
 	}
-	return "", false
+	return tx.Model(&domain.User{}).Where("id = ?", userID).Update("role_id", role.Id).Error
 }
@@ -1,190 +1,473 @@
 package storage
 
 import (
-	"regexp"
 	"testing"
+	"time"
 
-	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, func()) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("failed to open sqlmock database: %v", err)
-	}
+func TestListRequests(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
 
-	gdb, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
-	}), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-	if err != nil {
-		t.Fatalf("failed to open gorm db: %v", err)
+	day := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	users := []*domain.User{
+		{Email: "alice@example.com", Password: "secret", Name: "Alice", Surname: "Nguyen", Status: 1},
+		{Email: "bob@example.com", Password: "secret", Name: "Bob", Surname: "Tran", Status: 1},
+	}
+	for _, user := range users {
+		db.Create(user)
+	}
+	verifier := 9
+	requests := []*domain.Request{
+		{UserID: uint(users[0].ID), Type: domain.RequestTypeRegistration, Status: domain.RequestStatusPending, CreatedAt: day},
+		{UserID: uint(users[0].ID), Type: domain.RequestTypeVerification, Status: domain.RequestStatusApproved, VerifierID: verifier, CreatedAt: day.AddDate(0, 0, 1)},
+		{UserID: uint(users[1].ID), Type: domain.RequestTypeRegistration, Status: domain.RequestStatusPending, CreatedAt: day.AddDate(0, 0, 2)},
+	}
+	for _, request := range requests {
+		db.Create(request)
 	}
 
-	cleanup := func() {
-		db.Close()
+	pending := domain.RequestStatusPending
+	from := day.AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	to := day.AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	tests := []struct {
+		name  string
+		query dto.ListRequestQuery
+		ids   []int
+		total int64
+	}{
+		{"newest first by default", dto.ListRequestQuery{Page: 1, PageSize: 10}, []int{3, 2, 1}, 3},
+		{"second page", dto.ListRequestQuery{Page: 2, PageSize: 2}, []int{1}, 3},
+		{"sort ascending", dto.ListRequestQuery{Page: 1, PageSize: 10, Sort: "created_at"}, []int{1, 2, 3}, 3},
+		{"status", dto.ListRequestQuery{Page: 1, PageSize: 10, Status: &pending}, []int{3, 1}, 2},
+		{"type", dto.ListRequestQuery{Page: 1, PageSize: 10, Type: domain.RequestTypeVerification}, []int{2}, 1},
+		{"verifier", dto.ListRequestQuery{Page: 1, PageSize: 10, VerifierID: &verifier}, []int{2}, 1},
+		{"created range", dto.ListRequestQuery{Page: 1, PageSize: 10, CreatedFrom: &from, CreatedTo: &to}, []int{2}, 1},
+		{"email", dto.ListRequestQuery{Page: 1, PageSize: 10, Email: "BOB@"}, []int{3}, 1},
+		{"name", dto.ListRequestQuery{Page: 1, PageSize: 10, Name: "nguyen", Status: &pending}, []int{1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, total, msg := repo.ListRequests(tt.query)
+			assert.Empty(t, msg)
+			assert.Equal(t, tt.total, total)
+			ids := make([]int, 0, len(result))
+			for _, request := range result {
+				ids = append(ids, request.ID)
+			}
+			assert.Equal(t, tt.ids, ids)
+		})
 	}
 
-	return gdb, mock, cleanup
+	t.Run("no match is an empty page", func(t *testing.T) {
+		result, total, msg := repo.ListRequests(dto.ListRequestQuery{Page: 1, PageSize: 10, Email: "nobody"})
+		assert.Empty(t, msg)
+		assert.Zero(t, total)
+		assert.NotNil(t, result)
+		assert.Empty(t, result)
+	})
 }
 
-func TestGetListPendingRequest(t *testing.T) {
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+func TestGetPendingRequestByID(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
 
+	result, msg := repo.GetPendingRequestByID(request.ID)
+	assert.Empty(t, msg)
+	assert.Equal(t, request.ID, result.ID)
+	assert.Equal(t, domain.RequestTypeRegistration, result.Type)
+
+	db.Model(request).Update("status", domain.RequestStatusApproved)
+	result, msg = repo.GetPendingRequestByID(request.ID)
+	assert.Nil(t, result)
+	assert.NotEmpty(t, msg)
+}
+
+func TestGetRequestByID(t *testing.T) {
+	db := setupLifecycleDB(t)
 	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+
+	result, msg := repo.GetRequestByID(request.ID)
+	assert.Empty(t, msg)
+	assert.Equal(t, request.ID, result.ID)
+	assert.Equal(t, request.UserID, result.UserID)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "requests" WHERE status = $1`)).
-		WithArgs(0).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
-			AddRow(1, 1, "registration", 0))
+	result, msg = repo.GetRequestByID(999)
+	assert.Nil(t, result)
+	assert.NotEmpty(t, msg)
+}
 
-	requests, err := repo.GetListPendingRequest()
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
+func setupLifecycleDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.Request{}, &domain.RequestEvent{}, &domain.RequestMessage{}, &domain.RequestView{}, &domain.User{}, &domain.VolunteerDetail{}, &roleDomain.Role{}, &mailer.OutboxEmail{})
+	for _, name := range []string{roleDomain.RoleAdmin, roleDomain.RoleApplicant, roleDomain.RoleVolunteer} {
+		db.Create(&roleDomain.Role{Name: name, Status: 1})
 	}
+	return db
+}
 
-	if len(requests) != 1 {
-		t.Errorf("expected 1 request, got %d", len(requests))
+func outboxEmails(t *testing.T, db *gorm.DB) []*mailer.OutboxEmail {
+	var emails []*mailer.OutboxEmail
+	if err := db.Order("id").Find(&emails).Error; err != nil {
+		t.Fatalf("could not read outbox: %v", err)
 	}
+	return emails
 }
 
-func TestGetPendingRequestByID(t *testing.T) {
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+func seedRequest(t *testing.T, db *gorm.DB, requestType string, departmentID *int) *domain.Request {
+	user := &domain.User{Email: "user@example.com", Password: "secret", DepartmentID: departmentID, Status: 1}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("could not seed user: %v", err)
+	}
+	request := &domain.Request{UserID: uint(user.ID), Type: requestType, Status: domain.RequestStatusPending}
+	if err := db.Create(request).Error; err != nil {
+		t.Fatalf("could not seed request: %v", err)
+	}
+	return request
+}
 
-	repo := NewAdminRepository(db)
+func TestApproveRequest(t *testing.T) {
+	t.Run("registration promotes the user to applicant", func(t *testing.T) {
+		db := setupLifecycleDB(t)
+		repo := NewAdminRepository(db)
+		request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+
+		assert.NoError(t, repo.ApproveRequest(request.ID, 7))
+
+		var stored domain.Request
+		db.First(&stored, request.ID)
+		assert.Equal(t, domain.RequestStatusApproved, stored.Status)
+		assert.Equal(t, 7, stored.VerifierID)
+
+		// the pending request went through review first
+		events, msg := repo.GetRequestHistory(request.ID)
+		assert.Empty(t, msg)
+		assert.Len(t, events, 2)
+		assert.Equal(t, domain.RequestEventReview, events[0].Action)
+		assert.Equal(t, domain.RequestStatusInReview, events[0].ToStatus)
+		assert.Equal(t, domain.RequestEventApprove, events[1].Action)
+		assert.Equal(t, domain.RequestStatusInReview, *events[1].FromStatus)
+		assert.Equal(t, domain.RequestStatusApproved, events[1].ToStatus)
+
+		var user domain.User
+		db.First(&user, request.UserID)
+		assert.Equal(t, 2, user.RoleID)
+
+		emails := outboxEmails(t, db)
+		assert.Len(t, emails, 1)
+		assert.Equal(t, "user@example.com", emails[0].Recipient)
+		assert.Equal(t, "Your registration request has been approved", emails[0].Subject)
+		assert.Equal(t, mailer.OutboxStatusPending, emails[0].Status)
+	})
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "requests" WHERE id = $1 AND status = $2 ORDER BY "requests"."id" LIMIT 1`)).
-		WithArgs(1, 0).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
-			AddRow(1, 1, "registration", 0))
+	t.Run("verification promotes the user to volunteer", func(t *testing.T) {
+		db := setupLifecycleDB(t)
+		repo := NewAdminRepository(db)
+		departmentID := 3
+		request := seedRequest(t, db, domain.RequestTypeVerification, &departmentID)
 
-	request, err := repo.GetPendingRequestByID(1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
-	}
+		assert.NoError(t, repo.ApproveRequest(request.ID, 7))
 
-	if request.ID != 1 {
-		t.Errorf("expected request ID to be 1, got %d", request.ID)
-	}
-}
+		var user domain.User
+		db.First(&user, request.UserID)
+		assert.Equal(t, 3, user.RoleID)
+		assert.Equal(t, 1, user.VerificationStatus)
 
-func TestGetListAllRequest(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewAdminRepository(&mockDB.DB)
+		var detail domain.VolunteerDetail
+		assert.NoError(t, db.Where("user_id = ?", request.UserID).First(&detail).Error)
+		assert.Equal(t, departmentID, detail.DepartmentID)
+	})
 
-	expectedRequests := []*domain.Request{
-		{ID: 1, UserID: 1, Type: "type1", Status: 0},
-		{ID: 2, UserID: 2, Type: "type2", Status: 1},
-	}
-	mockDB.On("Find", &[]*domain.Request{}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*[]*domain.Request)
-		*arg = expectedRequests
-	}).Return(mockDB)
+	t.Run("verification without department rolls back", func(t *testing.T) {
+		db := setupLifecycleDB(t)
+		repo := NewAdminRepository(db)
+		request := seedRequest(t, db, domain.RequestTypeVerification, nil)
 
-	result, msg := repo.GetListAllRequest()
+		err := repo.ApproveRequest(request.ID, 7)
+		assert.ErrorIs(t, err, domain.ErrDepartmentRequired)
 
-	assert.Equal(t, expectedRequests, result)
-	assert.Empty(t, msg)
-	mockDB.AssertExpectations(t)
+		var stored domain.Request
+		db.First(&stored, request.ID)
+		assert.Equal(t, domain.RequestStatusPending, stored.Status)
+		events, _ := repo.GetRequestHistory(request.ID)
+		assert.Empty(t, events)
+
+		var user domain.User
+		db.First(&user, request.UserID)
+		assert.Equal(t, 0, user.RoleID)
+		assert.Empty(t, outboxEmails(t, db))
+	})
+
+	t.Run("already processed", func(t *testing.T) {
+		db := setupLifecycleDB(t)
+		repo := NewAdminRepository(db)
+		request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+		assert.NoError(t, repo.RejectRequest(request.ID, 7))
+
+		err := repo.ApproveRequest(request.ID, 7)
+
+		var transitionErr *domain.InvalidTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, domain.RequestStatusRejected, transitionErr.From)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		repo := NewAdminRepository(setupLifecycleDB(t))
+
+		assert.ErrorIs(t, repo.ApproveRequest(42, 7), domain.ErrRequestNotFound)
+	})
 }
 
-func TestGetRequestByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewAdminRepository(&mockDB.DB)
+func TestRejectRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
 
-	expectedRequest := &domain.Request{ID: 1, UserID: 1, Type: "type1", Status: 0}
-	mockDB.On("Where", "id = ?", 1).Return(mockDB)
-	mockDB.On("First", &domain.Request{}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*domain.Request)
-		*arg = *expectedRequest
-	}).Return(mockDB)
+	assert.NoError(t, repo.ReviewRequest(request.ID, 7))
+	assert.NoError(t, repo.RejectRequest(request.ID, 7))
 
-	result, msg := repo.GetRequestByID(1)
+	var stored domain.Request
+	db.First(&stored, request.ID)
+	assert.Equal(t, domain.RequestStatusRejected, stored.Status)
 
-	assert.Equal(t, expectedRequest, result)
-	assert.Empty(t, msg)
-	mockDB.AssertExpectations(t)
+	var transitionErr *domain.InvalidTransitionError
+	assert.ErrorAs(t, repo.RejectRequest(request.ID, 7), &transitionErr)
 }
 
-func TestApproveRequest(t *testing.T) {
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+func TestAddRejectNotes(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	assert.NoError(t, repo.RejectRequest(request.ID, 7))
+
+	assert.NoError(t, repo.AddRejectNotes(request.ID, 7, "missing passport"))
+	assert.NoError(t, repo.AddRejectNotes(request.ID, 7, "passport expired"))
+	assert.ErrorIs(t, repo.AddRejectNotes(42, 7, "notes"), domain.ErrRequestNotFound)
+
+	var stored domain.Request
+	db.First(&stored, request.ID)
+	assert.Equal(t, "passport expired", stored.RejectNotes)
+
+	// the rejection, then each note once the request is rejected
+	emails := outboxEmails(t, db)
+	assert.Len(t, emails, 3)
+	assert.Equal(t, "Your registration request has been rejected", emails[0].Subject)
+	assert.NotContains(t, emails[0].TextBody, "Reviewer notes")
+	assert.Contains(t, emails[2].TextBody, "passport expired")
+	assert.Contains(t, emails[2].HTMLBody, "passport expired")
+
+	// decided requests keep the notes they had
+	approved := &domain.Request{UserID: request.UserID, Type: domain.RequestTypeRegistration, Status: domain.RequestStatusPending}
+	assert.NoError(t, db.Create(approved).Error)
+	assert.NoError(t, repo.ApproveRequest(approved.ID, 7))
+	assert.ErrorIs(t, repo.AddRejectNotes(approved.ID, 7, "too late"), domain.ErrNotesClosed)
+	var decided domain.Request
+	db.First(&decided, approved.ID)
+	assert.Empty(t, decided.RejectNotes)
+}
 
+func TestGetRequestHistory(t *testing.T) {
+	db := setupLifecycleDB(t)
 	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "requests" WHERE id = $1 ORDER BY "requests"."id" LIMIT 1`)).
-		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
-			AddRow(1, 1, "registration", 0))
-
-	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "requests" SET "status"=$1,"verifier_id"=$2 WHERE "id" = $3`)).
-		WithArgs(1, 1, 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "role_id"=$1 WHERE "id" = $2`)).
-		WithArgs(1, 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
-
-	err := repo.ApproveRequest(1, 1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
-	}
-}
+	assert.NoError(t, repo.ReviewRequest(request.ID, 7))
+	assert.NoError(t, repo.RejectRequest(request.ID, 8))
+	assert.NoError(t, repo.AddRejectNotes(request.ID, 8, "missing passport"))
+	// a refused transition must not leave an event behind
+	assert.Error(t, repo.ApproveRequest(request.ID, 8))
 
-func TestRejectRequest(t *testing.T) {
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+	events, msg := repo.GetRequestHistory(request.ID)
+	assert.Empty(t, msg)
+	assert.Len(t, events, 3)
+
+	assert.Equal(t, domain.RequestEventReview, events[0].Action)
+	assert.Equal(t, domain.RequestStatusPending, *events[0].FromStatus)
+	assert.Equal(t, domain.RequestStatusInReview, events[0].ToStatus)
+	assert.Equal(t, 7, *events[0].ActorID)
+
+	assert.Equal(t, domain.RequestEventReject, events[1].Action)
+	assert.Equal(t, domain.RequestStatusRejected, events[1].ToStatus)
+	assert.Equal(t, 8, *events[1].ActorID)
+
+	assert.Equal(t, domain.RequestEventNote, events[2].Action)
+	assert.Equal(t, domain.RequestStatusRejected, events[2].ToStatus)
+	assert.Equal(t, "missing passport", events[2].Notes)
 
+	_, msg = repo.GetRequestHistory(42)
+	assert.Equal(t, "Request not found", msg)
+}
+
+func TestDeleteRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
 	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "requests" SET "status"=$1,"verifier_id"=$2 WHERE "id" = $3`)).
-		WithArgs(2, 1, 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.Equal(t, MsgDeleteRequestSuccess, repo.DeleteRequest(request.ID))
+	assert.ErrorIs(t, db.First(&domain.Request{}, request.ID).Error, gorm.ErrRecordNotFound)
+
+	// already in the trash, or never there
+	assert.Equal(t, domain.ErrRequestNotFound.Error(), repo.DeleteRequest(request.ID))
+	assert.Equal(t, domain.ErrRequestNotFound.Error(), repo.DeleteRequest(999))
+}
 
-	err := repo.RejectRequest(1, 1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
+func TestClaimRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	later := time.Now().Add(time.Hour)
+
+	claimed, err := repo.ClaimRequest(request.ID, 7, 7, later)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, *claimed.ClaimedBy)
+
+	// another reviewer can neither take the request over nor decide on it
+	_, err = repo.ClaimRequest(request.ID, 8, 8, later)
+	var claimedErr *domain.RequestClaimedError
+	if assert.ErrorAs(t, err, &claimedErr) {
+		assert.Equal(t, 7, claimedErr.ClaimedBy)
 	}
+	assert.ErrorAs(t, repo.ApproveRequest(request.ID, 8), &claimedErr)
+	assert.ErrorAs(t, repo.RejectRequest(request.ID, 8), &claimedErr)
+	assert.ErrorAs(t, repo.ReleaseRequest(request.ID, 8), &claimedErr)
+	assert.ErrorAs(t, repo.AddRejectNotes(request.ID, 8, "missing passport"), &claimedErr)
+
+	// assigning needs an existing reviewer
+	_, err = repo.ClaimRequest(request.ID, 7, 999, later)
+	assert.ErrorIs(t, err, domain.ErrReviewerNotFound)
+
+	// the holder decides, which drops the claim
+	assert.NoError(t, repo.RejectRequest(request.ID, 7))
+	var stored domain.Request
+	db.First(&stored, request.ID)
+	assert.Nil(t, stored.ClaimedBy)
+	assert.Nil(t, stored.ClaimExpiresAt)
+
+	_, err = repo.ClaimRequest(request.ID, 7, 7, later)
+	assert.ErrorIs(t, err, domain.ErrRequestNotOpen)
+	_, err = repo.ClaimRequest(request.ID+1, 7, 7, later)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
 }
 
-func TestAddRejectNotes(t *testing.T) {
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+func TestClaimRequest_Expired(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+
+	_, err := repo.ClaimRequest(request.ID, 7, 7, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+
+	// an expired claim neither blocks decisions nor new claims
+	claimed, err := repo.ClaimRequest(request.ID, 8, 8, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, *claimed.ClaimedBy)
 
+	assert.NoError(t, repo.ReleaseRequest(request.ID, 8))
+	assert.NoError(t, repo.ReviewRequest(request.ID, 7))
+}
+
+func TestMarkRequestViewed(t *testing.T) {
+	db := setupLifecycleDB(t)
 	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "requests" SET "reject_notes"=$1 WHERE "id" = $2`)).
-		WithArgs("some notes", 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.NoError(t, repo.MarkRequestViewed(request.ID, 7))
+	assert.NoError(t, repo.MarkRequestViewed(request.ID, 8))
+	assert.NoError(t, repo.MarkRequestViewed(request.ID, 7))
 
-	err := repo.AddRejectNotes(1, "some notes")
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
+	views, err := repo.ListRequestViews(request.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, views, 2) {
+		assert.Equal(t, 7, views[0].AdminID)
+		assert.Equal(t, 8, views[1].AdminID)
 	}
+
+	assert.ErrorIs(t, repo.MarkRequestViewed(request.ID+1, 7), domain.ErrRequestNotFound)
 }
 
-func TestDeleteRequest(t *testing.T) {
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+func TestListRequestIDs(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	for _, request := range []*domain.Request{
+		{UserID: 1, Type: domain.RequestTypeRegistration, Status: domain.RequestStatusPending},
+		{UserID: 1, Type: domain.RequestTypeVerification, Status: domain.RequestStatusPending},
+		{UserID: 2, Type: domain.RequestTypeRegistration, Status: domain.RequestStatusApproved},
+		{UserID: 3, Type: domain.RequestTypeRegistration, Status: domain.RequestStatusPending},
+	} {
+		db.Create(request)
+	}
+
+	pending := domain.RequestStatusPending
+	ids, err := repo.ListRequestIDs(dto.ListRequestQuery{Type: domain.RequestTypeRegistration, Status: &pending}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 4}, ids)
 
+	ids, err = repo.ListRequestIDs(dto.ListRequestQuery{Status: &pending}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestRestoreRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
 	repo := NewAdminRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
 
-	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "requests" WHERE "id" = $1`)).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.Equal(t, MsgDeleteRequestSuccess, repo.DeleteRequest(request.ID))
+	_, msg := repo.GetRequestByID(request.ID)
+	assert.NotEmpty(t, msg)
 
-	err := repo.DeleteRequest(1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
+	deleted, err := repo.ListDeletedRequests()
+	assert.NoError(t, err)
+	if assert.Len(t, deleted, 1) {
+		assert.Equal(t, request.ID, deleted[0].ID)
+		assert.True(t, deleted[0].DeletedAt.Valid)
 	}
+
+	assert.NoError(t, repo.RestoreRequest(request.ID))
+	restored, msg := repo.GetRequestByID(request.ID)
+	assert.Empty(t, msg)
+	assert.Equal(t, request.ID, restored.ID)
+
+	assert.ErrorIs(t, repo.RestoreRequest(request.ID), domain.ErrRequestNotFound)
+	assert.ErrorIs(t, repo.RestoreRequest(99), domain.ErrRequestNotFound)
+}
+
+func TestPurgeDeletedRequests(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewAdminRepository(db)
+	old := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	resubmitted := &domain.Request{UserID: old.UserID, Type: old.Type, Status: domain.RequestStatusPending, PreviousRequestID: &old.ID}
+	db.Create(resubmitted)
+	recent := &domain.Request{UserID: old.UserID, Type: old.Type, Status: domain.RequestStatusPending}
+	db.Create(recent)
+	db.Create(&domain.RequestEvent{RequestID: old.ID, ToStatus: domain.RequestStatusPending, Action: "created"})
+
+	repo.DeleteRequest(old.ID)
+	repo.DeleteRequest(recent.ID)
+	db.Unscoped().Model(&domain.Request{}).Where("id = ?", old.ID).Update("deleted_at", time.Now().Add(-48*time.Hour))
+
+	purged, err := repo.PurgeDeletedRequests(time.Now().Add(-24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var count int64
+	db.Unscoped().Model(&domain.Request{}).Where("id = ?", old.ID).Count(&count)
+	assert.Zero(t, count)
+	db.Model(&domain.RequestEvent{}).Where("request_id = ?", old.ID).Count(&count)
+	assert.Zero(t, count)
+	db.Unscoped().Model(&domain.Request{}).Where("id = ?", recent.ID).Count(&count)
+	assert.Equal(t, int64(1), count, "recently deleted requests stay in the trash")
+
+	var stored domain.Request
+	db.First(&stored, resubmitted.ID)
+	assert.Nil(t, stored.PreviousRequestID)
 }
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"errors"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 
 	"gorm.io/gorm"
@@ -8,6 +10,14 @@ import (
 
 type ApplicantRequestRepositoryInterface interface {
 	CreateApplicantRequest(request *domain.ApplicantRequestDomain) error
+	ListRequestsByUser(userID int) ([]*domain.Request, error)
+	GetRequestByUser(userID int, requestID int) (*domain.Request, error)
+	GetRequestHistory(userID int, requestID int) ([]*domain.RequestEvent, error)
+	CancelRequest(userID int, requestID int) error
+	ResubmitRequest(userID int, requestID int) (*domain.Request, error)
+	ListRequestMessages(userID int, requestID int) ([]*domain.RequestMessage, error)
+	SendRequestMessage(userID int, requestID int, body string) (*domain.RequestMessage, error)
+	CountUnreadMessages(userID int) ([]domain.RequestUnreadCount, error)
 }
 
 type ApplicantRequestRepository struct {
@@ -18,6 +28,137 @@ func NewApplicantRequestRepository(db *gorm.DB) *ApplicantRequestRepository {
 	return &ApplicantRequestRepository{DB: db}
 }
 
+// CreateApplicantRequest inserts a request and records its submission in the
+// request history.
 func (r *ApplicantRequestRepository) CreateApplicantRequest(request *domain.ApplicantRequestDomain) error {
-	return r.DB.Create(request).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+		return recordSubmission(tx, request.ID, request.UserID)
+	})
+}
+
+// ListRequestsByUser returns the user's own requests, newest first.
+func (r *ApplicantRequestRepository) ListRequestsByUser(userID int) ([]*domain.Request, error) {
+	requests := make([]*domain.Request, 0)
+	err := r.DB.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&requests).Error
+	return requests, err
+}
+
+// GetRequestByUser returns one of the user's own requests. Requests of other
+// users are reported as not found.
+func (r *ApplicantRequestRepository) GetRequestByUser(userID int, requestID int) (*domain.Request, error) {
+	var request domain.Request
+	err := r.DB.Where("id = ? AND user_id = ?", requestID, userID).First(&request).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetRequestHistory returns the history of one of the user's own requests.
+func (r *ApplicantRequestRepository) GetRequestHistory(userID int, requestID int) ([]*domain.RequestEvent, error) {
+	if _, err := r.GetRequestByUser(userID, requestID); err != nil {
+		return nil, err
+	}
+	return listRequestEvents(r.DB, requestID)
+}
+
+// CancelRequest withdraws one of the user's own requests.
+func (r *ApplicantRequestRepository) CancelRequest(userID int, requestID int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		request, err := NewApplicantRequestRepository(tx).GetRequestByUser(userID, requestID)
+		if err != nil {
+			return err
+		}
+		from := request.Status
+		if err := request.TransitionTo(domain.RequestStatusCancelled); err != nil {
+			return err
+		}
+		// nobody has to decide on a cancelled request any more
+		return saveTransition(tx, request, from, userID, domain.RequestEventCancel, map[string]interface{}{"claimed_by": nil, "claim_expires_at": nil})
+	})
+}
+
+// ResubmitRequest replaces one of the user's own rejected requests with a new
+// pending request of the same type. The new request links to the rejected one,
+// carries its reject notes over and starts its history with its submission.
+func (r *ApplicantRequestRepository) ResubmitRequest(userID int, requestID int) (*domain.Request, error) {
+	var resubmission *domain.Request
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		request, err := NewApplicantRequestRepository(tx).GetRequestByUser(userID, requestID)
+		if err != nil {
+			return err
+		}
+		from := request.Status
+		if err := request.TransitionTo(domain.RequestStatusResubmitted); err != nil {
+			return err
+		}
+		if err := saveTransition(tx, request, from, userID, domain.RequestEventResubmit, nil); err != nil {
+			return err
+		}
+		resubmission = &domain.Request{
+			UserID:              request.UserID,
+			Type:                request.Type,
+			Status:              domain.RequestStatusPending,
+			PreviousRequestID:   &request.ID,
+			PreviousRejectNotes: request.RejectNotes,
+		}
+		if err := tx.Create(resubmission).Error; err != nil {
+			return err
+		}
+		return recordSubmission(tx, resubmission.ID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resubmission, nil
+}
+
+// ListRequestMessages returns the thread of one of the user's own requests and
+// marks the messages of the admins as read.
+func (r *ApplicantRequestRepository) ListRequestMessages(userID int, requestID int) ([]*domain.RequestMessage, error) {
+	var messages []*domain.RequestMessage
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := NewApplicantRequestRepository(tx).GetRequestByUser(userID, requestID); err != nil {
+			return err
+		}
+		var err error
+		messages, err = readRequestMessages(tx, requestID, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// SendRequestMessage posts a reply of the user to the thread of one of their
+// own requests.
+func (r *ApplicantRequestRepository) SendRequestMessage(userID int, requestID int, body string) (*domain.RequestMessage, error) {
+	var message *domain.RequestMessage
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		request, err := NewApplicantRequestRepository(tx).GetRequestByUser(userID, requestID)
+		if err != nil {
+			return err
+		}
+		message, err = postRequestMessage(tx, request, userID, false, body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// CountUnreadMessages returns the number of unread admin messages on the
+// user's own requests, per request.
+func (r *ApplicantRequestRepository) CountUnreadMessages(userID int) ([]domain.RequestUnreadCount, error) {
+	return countUnreadMessages(r.DB, true, func(db *gorm.DB) *gorm.DB {
+		return db.Where("requests.user_id = ?", userID)
+	})
 }
@@ -5,21 +5,123 @@ import (
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/gorm"
 )
 
 func TestCreateApplicantRequest(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRequestRepository(&mockDB.DB)
+	db := setupLifecycleDB(t)
+	repo := NewApplicantRequestRepository(db)
 	appliRequest := &domain.ApplicantRequestDomain{
-		ID:     1,
 		UserID: 2,
 		Type:   "Application",
 		Status: 0,
 	}
-	mockDB.On("Create", appliRequest).Return(&gorm.DB{Error: nil})
 
 	err := repo.CreateApplicantRequest(appliRequest)
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+
+	// the history starts with the submission by the requester
+	events, err := repo.GetRequestHistory(2, appliRequest.ID)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.RequestEventSubmit, events[0].Action)
+	assert.Nil(t, events[0].FromStatus)
+	assert.Equal(t, domain.RequestStatusPending, events[0].ToStatus)
+	assert.Equal(t, 2, *events[0].ActorID)
+}
+
+func TestApplicantGetRequestHistory(t *testing.T) {
+	db := setupLifecycleDB(t)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	assert.NoError(t, NewAdminRepository(db).RejectRequest(request.ID, 7))
+
+	repo := NewApplicantRequestRepository(db)
+
+	events, err := repo.GetRequestHistory(int(request.UserID), request.ID)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, domain.RequestEventReview, events[0].Action)
+	assert.Equal(t, domain.RequestEventReject, events[1].Action)
+
+	// another user's request is reported as missing
+	_, err = repo.GetRequestHistory(int(request.UserID)+1, request.ID)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+}
+
+func TestRequestsByUser(t *testing.T) {
+	db := setupLifecycleDB(t)
+	own := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	other := &domain.Request{UserID: own.UserID + 1, Type: domain.RequestTypeRegistration}
+	db.Create(other)
+
+	repo := NewApplicantRequestRepository(db)
+
+	requests, err := repo.ListRequestsByUser(int(own.UserID))
+	assert.NoError(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, own.ID, requests[0].ID)
+
+	request, err := repo.GetRequestByUser(int(own.UserID), own.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, own.ID, request.ID)
+
+	_, err = repo.GetRequestByUser(int(own.UserID), other.ID)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+}
+
+func TestApplicantCancelRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	repo := NewApplicantRequestRepository(db)
+	userID := int(request.UserID)
+
+	assert.ErrorIs(t, repo.CancelRequest(userID+1, request.ID), domain.ErrRequestNotFound)
+	assert.NoError(t, repo.CancelRequest(userID, request.ID))
+
+	var stored domain.Request
+	db.First(&stored, request.ID)
+	assert.Equal(t, domain.RequestStatusCancelled, stored.Status)
+
+	var transitionErr *domain.InvalidTransitionError
+	assert.ErrorAs(t, repo.CancelRequest(userID, request.ID), &transitionErr)
+
+	events, _ := listRequestEvents(db, request.ID)
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.RequestEventCancel, events[0].Action)
+	assert.Equal(t, userID, *events[0].ActorID)
+}
+
+func TestApplicantResubmitRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
+	request := seedRequest(t, db, domain.RequestTypeVerification, nil)
+	repo := NewApplicantRequestRepository(db)
+	userID := int(request.UserID)
+
+	// only rejected requests can be resubmitted
+	var transitionErr *domain.InvalidTransitionError
+	_, err := repo.ResubmitRequest(userID, request.ID)
+	assert.ErrorAs(t, err, &transitionErr)
+
+	admin := NewAdminRepository(db)
+	assert.NoError(t, admin.RejectRequest(request.ID, 7))
+	assert.NoError(t, admin.AddRejectNotes(request.ID, 7, "identity document expired"))
+
+	resubmission, err := repo.ResubmitRequest(userID, request.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RequestStatusPending, resubmission.Status)
+	assert.Equal(t, domain.RequestTypeVerification, resubmission.Type)
+	assert.Equal(t, request.ID, *resubmission.PreviousRequestID)
+	assert.Equal(t, "identity document expired", resubmission.PreviousRejectNotes)
+	events, err := repo.GetRequestHistory(userID, resubmission.ID)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.RequestEventSubmit, events[0].Action)
+	assert.Equal(t, userID, *events[0].ActorID)
+
+	var stored domain.Request
+	db.First(&stored, request.ID)
+	assert.Equal(t, domain.RequestStatusResubmitted, stored.Status)
+
+	// the rejected request can only be replaced once
+	_, err = repo.ResubmitRequest(userID, request.ID)
+	assert.ErrorAs(t, err, &transitionErr)
 }
@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 
 	"gorm.io/gorm"
@@ -10,7 +14,10 @@ type ApplicantRepositoryInterface interface {
 	CreateApplicant(user *domain.ApplicantDomain) error
 	UpdateApplicant(user *domain.ApplicantDomain) error
 	DeleteApplicant(id int) error
+	ListDeletedApplicants() ([]*domain.ApplicantDomain, error)
+	RestoreApplicant(id int) error
 	FindApplicantByID(id int) (*domain.ApplicantDomain, error)
+	UpdateApplicantProfile(user *domain.ApplicantDomain) error
 }
 
 type ApplicantRepository struct {
@@ -40,3 +47,97 @@ func (r *ApplicantRepository) FindApplicantByID(id int) (*domain.ApplicantDomain
 	}
 	return &user, nil
 }
+
+// UpdateApplicantProfile writes the self-editable profile columns only, so
+// applicants cannot change their email, role, status or department through
+// it.
+func (r *ApplicantRepository) UpdateApplicantProfile(user *domain.ApplicantDomain) error {
+	return r.DB.Model(&domain.ApplicantDomain{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+		"name":                user.Name,
+		"surname":             user.Surname,
+		"gender":              user.Gender,
+		"dob":                 user.DOB,
+		"mobile":              user.Mobile,
+		"country_id":          user.CountryID,
+		"resident_country_id": user.ResidentCountryID,
+	}).Error
+}
+
+// ListDeletedApplicants returns the soft deleted users, most recently deleted
+// first.
+func (r *ApplicantRepository) ListDeletedApplicants() ([]*domain.ApplicantDomain, error) {
+	users := make([]*domain.ApplicantDomain, 0)
+	err := r.DB.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id DESC").Find(&users).Error
+	return users, err
+}
+
+// RestoreApplicant brings back a soft deleted user.
+func (r *ApplicantRepository) RestoreApplicant(id int) error {
+	result := r.DB.Unscoped().Model(&domain.ApplicantDomain{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrApplicantNotFound
+	}
+	return nil
+}
+
+// userTables hold rows that belong to a user without being modelled here.
+// They go away with the user when it is purged.
+var userTables = []string{"user_identities", "refresh_tokens", "password_reset_tokens", "login_in", "calendar_feeds"}
+
+// volunteerTables hold the history, activity sign-ups, attendance, logged
+// hours, availability, skills and certificates of the volunteer details of a
+// user.
+var volunteerTables = []string{"volunteer_positions", "volunteer_status_changes", "activity_signups",
+	"activity_attendances", "attendance_token_uses", "volunteer_hours",
+	"volunteer_availability_slots", "volunteer_availability_exceptions", "volunteer_skills",
+	"volunteer_certificates"}
+
+// PurgeDeletedApplicants permanently removes the users soft deleted before the
+// given time with everything that belongs to them, one user per transaction.
+// A user that cannot be removed, for instance because they reviewed requests
+// of others, is kept and reported in the returned error.
+func (r *ApplicantRepository) PurgeDeletedApplicants(before time.Time) (int64, error) {
+	var ids []int
+	if err := r.DB.Unscoped().Model(&domain.ApplicantDomain{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	var purged int64
+	var errs []error
+	for _, id := range ids {
+		if err := r.DB.Transaction(func(tx *gorm.DB) error { return purgeUser(tx, id) }); err != nil {
+			errs = append(errs, fmt.Errorf("applicant %d: %w", id, err))
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+func purgeUser(tx *gorm.DB, id int) error {
+	var requestIDs []int
+	if err := tx.Unscoped().Model(&domain.Request{}).Where("user_id = ?", id).Pluck("id", &requestIDs).Error; err != nil {
+		return err
+	}
+	if _, err := purgeRequests(tx, requestIDs); err != nil {
+		return err
+	}
+	for _, table := range volunteerTables {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE volunteer_id IN (SELECT id FROM volunteer_details WHERE user_id = ?)", id).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Unscoped().Where("user_id = ?", id).Delete(&domain.VolunteerDetail{}).Error; err != nil {
+		return err
+	}
+	for _, table := range userTables {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", id).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Delete(&domain.ApplicantDomain{}, id).Error
+}
@@ -4,17 +4,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
-func TestCreateApplicant(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
+func setupApplicantDB(t *testing.T) *gorm.DB {
+	return testdb.Open(t, &domain.ApplicantDomain{})
+}
 
-	applicant := &domain.ApplicantDomain{
+func newApplicant() *domain.ApplicantDomain {
+	return &domain.ApplicantDomain{
 		ID:                 1,
 		RoleID:             1,
 		DepartmentID:       2,
@@ -31,97 +32,127 @@ func TestCreateApplicant(t *testing.T) {
 		VerificationStatus: 0,
 		Status:             0,
 	}
-	mockDB.On("Create", applicant).Return(&gorm.DB{Error: nil})
+}
+
+func TestCreateApplicant(t *testing.T) {
+	db := setupApplicantDB(t)
+	repo := NewApplicantRepository(db)
 
-	err := repo.CreateApplicant(applicant)
+	err := repo.CreateApplicant(newApplicant())
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	var stored domain.ApplicantDomain
+	assert.NoError(t, db.First(&stored, 1).Error)
+	assert.Equal(t, "test@example.com", stored.Email)
+	assert.Equal(t, "Johnny", stored.Name)
 }
 
 func TestUpdateApplicant(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
-
-	applicant := &domain.ApplicantDomain{
-		ID:                 1,
-		RoleID:             1,
-		DepartmentID:       2,
-		Email:              "test@example.com",
-		Password:           "123456",
-		Name:               "Johnny",
-		Surname:            "Hoang",
-		Gender:             "Male",
-		DOB:                time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC),
-		Mobile:             "0912345987",
-		CountryID:          3,
-		ResidentCountryID:  5,
-		Avatar:             "http://linktoavatar.com",
-		VerificationStatus: 0,
-		Status:             1,
-	}
-	mockDB.On("Save", applicant).Return(&gorm.DB{Error: nil})
+	db := setupApplicantDB(t)
+	repo := NewApplicantRepository(db)
+	applicant := newApplicant()
+	assert.NoError(t, repo.CreateApplicant(applicant))
 
+	applicant.Status = 1
 	err := repo.UpdateApplicant(applicant)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	var stored domain.ApplicantDomain
+	assert.NoError(t, db.First(&stored, 1).Error)
+	assert.Equal(t, 1, stored.Status)
 }
 
 func TestDeleteApplicant(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
-
-	mockDB.On("Delete", &domain.ApplicantDomain{}, 1).Return(&gorm.DB{Error: nil})
+	db := setupApplicantDB(t)
+	repo := NewApplicantRepository(db)
+	assert.NoError(t, repo.CreateApplicant(newApplicant()))
 
 	err := repo.DeleteApplicant(1)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.ErrorIs(t, db.First(&domain.ApplicantDomain{}, 1).Error, gorm.ErrRecordNotFound)
 }
 
 func TestFindApplicantByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
-
-	applicant := &domain.ApplicantDomain{
-		ID:                 1,
-		RoleID:             1,
-		DepartmentID:       2,
-		Email:              "test@example.com",
-		Password:           "123456",
-		Name:               "Johnny",
-		Surname:            "Hoang",
-		Gender:             "Male",
-		DOB:                time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC),
-		Mobile:             "0912345987",
-		CountryID:          3,
-		ResidentCountryID:  5,
-		Avatar:             "http://linktoavatar.com",
-		VerificationStatus: 0,
-		Status:             0,
-	}
-	mockDB.On("First", &applicant, 1).Return(&gorm.DB{Error: nil}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(**domain.ApplicantDomain)
-		*arg = applicant
-	})
+	repo := NewApplicantRepository(setupApplicantDB(t))
+	applicant := newApplicant()
+	assert.NoError(t, repo.CreateApplicant(applicant))
 
 	result, err := repo.FindApplicantByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, applicant, result)
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, applicant.Email, result.Email)
+	assert.True(t, applicant.DOB.Equal(result.DOB))
 }
 
 func TestFindApplicantByID_NotFound(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
-
-	mockDB.On("First", mock.Anything, 1).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	repo := NewApplicantRepository(setupApplicantDB(t))
 
 	result, err := repo.FindApplicantByID(1)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	mockDB.AssertExpectations(t)
+}
+
+func TestRestoreApplicant(t *testing.T) {
+	db := setupLifecycleDB(t)
+	repo := NewApplicantRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	id := int(request.UserID)
+
+	assert.NoError(t, repo.DeleteApplicant(id))
+	_, err := repo.FindApplicantByID(id)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	deleted, err := repo.ListDeletedApplicants()
+	assert.NoError(t, err)
+	if assert.Len(t, deleted, 1) {
+		assert.Equal(t, id, deleted[0].ID)
+	}
+
+	assert.NoError(t, repo.RestoreApplicant(id))
+	_, err = repo.FindApplicantByID(id)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, repo.RestoreApplicant(id), domain.ErrApplicantNotFound)
+}
+
+func TestPurgeDeletedApplicants(t *testing.T) {
+	db := setupLifecycleDB(t)
+	for _, table := range userTables {
+		db.Exec("CREATE TABLE " + table + " (id integer primary key, user_id integer)")
+		db.Exec("INSERT INTO "+table+" (user_id) VALUES (?)", 1)
+	}
+	for _, table := range volunteerTables {
+		db.Exec("CREATE TABLE " + table + " (id integer primary key, volunteer_id integer)")
+	}
+	repo := NewApplicantRepository(db)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	id := int(request.UserID)
+	volunteer := &domain.VolunteerDetail{UserID: request.UserID, DepartmentID: 1}
+	db.Create(volunteer)
+	for _, table := range volunteerTables {
+		db.Exec("INSERT INTO "+table+" (volunteer_id) VALUES (?)", volunteer.ID)
+	}
+	other := &domain.User{Email: "other@example.com", Password: "secret", Status: 1}
+	db.Create(other)
+
+	repo.DeleteApplicant(id)
+	repo.DeleteApplicant(other.ID)
+	db.Unscoped().Model(&domain.ApplicantDomain{}).Where("id = ?", id).Update("deleted_at", time.Now().Add(-48*time.Hour))
+
+	purged, err := repo.PurgeDeletedApplicants(time.Now().Add(-24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var count int64
+	db.Unscoped().Model(&domain.ApplicantDomain{}).Count(&count)
+	assert.Equal(t, int64(1), count, "recently deleted users stay in the trash")
+	db.Unscoped().Model(&domain.Request{}).Count(&count)
+	assert.Zero(t, count)
+	db.Unscoped().Model(&domain.VolunteerDetail{}).Count(&count)
+	assert.Zero(t, count)
+	for _, table := range append(userTables, volunteerTables...) {
+		db.Table(table).Count(&count)
+		assert.Zero(t, count, table)
+	}
 }
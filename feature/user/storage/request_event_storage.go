@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"gorm.io/gorm"
+)
+
+// recordRequestEvent appends an event to the history of a request. It is
+// called with the transaction that performs the change it describes.
+func recordRequestEvent(tx *gorm.DB, request *domain.Request, actorID int, action string, from int, notes string) error {
+	return tx.Create(&domain.RequestEvent{
+		RequestID:  request.ID,
+		ActorID:    &actorID,
+		Action:     action,
+		FromStatus: &from,
+		ToStatus:   request.Status,
+		Notes:      notes,
+	}).Error
+}
+
+// recordSubmission records that a user submitted a new pending request. It is
+// called with the transaction that inserts the request.
+func recordSubmission(tx *gorm.DB, requestID int, userID int) error {
+	return tx.Create(&domain.RequestEvent{
+		RequestID: requestID,
+		ActorID:   &userID,
+		Action:    domain.RequestEventSubmit,
+		ToStatus:  domain.RequestStatusPending,
+	}).Error
+}
+
+// saveTransition persists a request that has already been moved out of status
+// from, together with any extra columns, and records the event. The update is
+// conditional on the stored status still being from, so a concurrent change
+// is reported as an *InvalidTransitionError instead of being overwritten.
+func saveTransition(tx *gorm.DB, request *domain.Request, from int, actorID int, action string, columns map[string]interface{}) error {
+	updates := map[string]interface{}{"status": request.Status}
+	for column, value := range columns {
+		updates[column] = value
+	}
+	result := tx.Model(&domain.Request{}).
+		Where("id = ? AND status = ?", request.ID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &domain.InvalidTransitionError{From: from, To: request.Status}
+	}
+	return recordRequestEvent(tx, request, actorID, action, from, "")
+}
+
+// listRequestEvents returns the history of a request, oldest first.
+func listRequestEvents(db *gorm.DB, requestID int) ([]*domain.RequestEvent, error) {
+	var events []*domain.RequestEvent
+	err := db.Where("request_id = ?", requestID).Order("created_at, id").Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"gorm.io/gorm"
+)
+
+// postRequestMessage adds a message to the thread of a request and queues the
+// email telling the other side about it. It is called with the transaction
+// the request was read in.
+func postRequestMessage(tx *gorm.DB, request *domain.Request, senderID int, fromAdmin bool, body string) (*domain.RequestMessage, error) {
+	if !request.AcceptsMessages() {
+		return nil, domain.ErrRequestClosed
+	}
+	message := &domain.RequestMessage{
+		RequestID: request.ID,
+		SenderID:  senderID,
+		FromAdmin: fromAdmin,
+		Body:      body,
+	}
+	if err := tx.Create(message).Error; err != nil {
+		return nil, err
+	}
+	if err := enqueueMessageEmail(tx, request, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// enqueueMessageEmail queues the email announcing a new message. Messages from
+// the admins go to the owner of the request, replies go to the admin who
+// reviewed the request, if any; other admins see them in their unread counts.
+func enqueueMessageEmail(tx *gorm.DB, request *domain.Request, message *domain.RequestMessage) error {
+	recipientID := int(request.UserID)
+	if !message.FromAdmin {
+		recipientID = request.VerifierID
+	}
+	if recipientID == 0 || recipientID == message.SenderID {
+		return nil
+	}
+	var recipient, sender domain.User
+	if err := tx.First(&recipient, recipientID).Error; err != nil {
+		return err
+	}
+	if err := tx.First(&sender, message.SenderID).Error; err != nil {
+		return err
+	}
+	senderName := sender.Name
+	if senderName == "" {
+		senderName = sender.Email
+	}
+	msg, err := mailer.Render(recipient.Email, mailer.TemplateRequestMessage, mailer.MessageData{
+		Name:      recipient.Name,
+		Sender:    senderName,
+		RequestID: request.ID,
+		Type:      request.Type,
+		Body:      message.Body,
+	})
+	if err != nil {
+		return err
+	}
+	return mailer.Enqueue(tx, msg)
+}
+
+// readRequestMessages returns the thread of a request, oldest first, after
+// marking the messages written by the other side as read.
+func readRequestMessages(db *gorm.DB, requestID int, readerIsAdmin bool) ([]*domain.RequestMessage, error) {
+	err := db.Model(&domain.RequestMessage{}).
+		Where("request_id = ? AND from_admin = ? AND read_at IS NULL", requestID, !readerIsAdmin).
+		Update("read_at", time.Now()).Error
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*domain.RequestMessage, 0)
+	err = db.Where("request_id = ?", requestID).Order("created_at, id").Find(&messages).Error
+	return messages, err
+}
+
+// countUnreadMessages returns, per request, the number of messages written by
+// the admins (fromAdmin) or by the owners that have not been read yet. scope
+// narrows down the requests taken into account.
+func countUnreadMessages(db *gorm.DB, fromAdmin bool, scope func(db *gorm.DB) *gorm.DB) ([]domain.RequestUnreadCount, error) {
+	query := db.Model(&domain.RequestMessage{}).
+		Select("request_messages.request_id, COUNT(*) AS unread").
+		Joins("JOIN requests ON requests.id = request_messages.request_id").
+		Where("request_messages.from_admin = ? AND request_messages.read_at IS NULL", fromAdmin)
+	if scope != nil {
+		query = scope(query)
+	}
+	counts := make([]domain.RequestUnreadCount, 0)
+	err := query.Group("request_messages.request_id").Order("request_messages.request_id").Scan(&counts).Error
+	return counts, err
+}
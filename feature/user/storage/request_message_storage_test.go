@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMessages(t *testing.T) {
+	db := setupLifecycleDB(t)
+	request := seedRequest(t, db, domain.RequestTypeVerification, nil)
+	owner := int(request.UserID)
+	admin := &domain.User{Email: "admin@example.com", Password: "secret", Name: "Bao", Status: 1}
+	db.Create(admin)
+
+	adminRepo := NewAdminRepository(db)
+	ownerRepo := NewApplicantRequestRepository(db)
+
+	message, err := adminRepo.SendRequestMessage(request.ID, admin.ID, "Which passport did you upload?")
+	assert.NoError(t, err)
+	assert.True(t, message.FromAdmin)
+
+	// the owner is told about the message
+	emails := outboxEmails(t, db)
+	if assert.Len(t, emails, 1) {
+		assert.Equal(t, "user@example.com", emails[0].Recipient)
+		assert.Contains(t, emails[0].TextBody, "Which passport did you upload?")
+		assert.Contains(t, emails[0].TextBody, "Bao sent a new message")
+	}
+
+	unread, err := ownerRepo.CountUnreadMessages(owner)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RequestUnreadCount{{RequestID: request.ID, Unread: 1}}, unread)
+
+	// reading the thread marks the admin message as read
+	messages, err := ownerRepo.ListRequestMessages(owner, request.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 1) {
+		assert.NotNil(t, messages[0].ReadAt)
+	}
+	unread, err = ownerRepo.CountUnreadMessages(owner)
+	assert.NoError(t, err)
+	assert.Empty(t, unread)
+
+	_, err = ownerRepo.SendRequestMessage(owner, request.ID, "The new one")
+	assert.NoError(t, err)
+	// nobody reviews the request yet, so the reply is not emailed
+	assert.Len(t, outboxEmails(t, db), 1)
+
+	unread, err = adminRepo.CountUnreadMessages()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RequestUnreadCount{{RequestID: request.ID, Unread: 1}}, unread)
+
+	messages, err = adminRepo.ListRequestMessages(request.ID)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	unread, err = adminRepo.CountUnreadMessages()
+	assert.NoError(t, err)
+	assert.Empty(t, unread)
+
+	// another user's request is reported as missing
+	_, err = ownerRepo.SendRequestMessage(owner+1, request.ID, "hello")
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	_, err = adminRepo.ListRequestMessages(request.ID + 1)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+}
+
+func TestRequestMessages_ReplyNotifiesReviewer(t *testing.T) {
+	db := setupLifecycleDB(t)
+	request := seedRequest(t, db, domain.RequestTypeVerification, nil)
+	admin := &domain.User{Email: "admin@example.com", Password: "secret", Name: "Bao", Status: 1}
+	db.Create(admin)
+	assert.NoError(t, NewAdminRepository(db).ReviewRequest(request.ID, admin.ID))
+
+	_, err := NewApplicantRequestRepository(db).SendRequestMessage(int(request.UserID), request.ID, "Any news?")
+	assert.NoError(t, err)
+
+	emails := outboxEmails(t, db)
+	if assert.Len(t, emails, 1) {
+		assert.Equal(t, "admin@example.com", emails[0].Recipient)
+	}
+}
+
+func TestRequestMessages_ClosedRequest(t *testing.T) {
+	db := setupLifecycleDB(t)
+	request := seedRequest(t, db, domain.RequestTypeRegistration, nil)
+	admin := &domain.User{Email: "admin@example.com", Password: "secret", Name: "Bao", Status: 1}
+	db.Create(admin)
+	repo := NewAdminRepository(db)
+	assert.NoError(t, repo.ApproveRequest(request.ID, admin.ID))
+
+	_, err := repo.SendRequestMessage(request.ID, admin.ID, "hello")
+	assert.ErrorIs(t, err, domain.ErrRequestClosed)
+
+	// the thread can still be read
+	messages, err := repo.ListRequestMessages(request.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+}
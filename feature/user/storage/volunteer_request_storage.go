@@ -17,6 +17,13 @@ func NewVolunteerRequestRepository(db *gorm.DB) *VolunteerRequestRepository {
 	return &VolunteerRequestRepository{DB: db}
 }
 
+// CreateVolunteerRequest inserts a request and records its submission in the
+// request history.
 func (r *VolunteerRequestRepository) CreateVolunteerRequest(volunteerRequest *domain.VolunteerRequest) error {
-	return r.DB.Create(volunteerRequest).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(volunteerRequest).Error; err != nil {
+			return err
+		}
+		return recordSubmission(tx, volunteerRequest.ID, volunteerRequest.UserID)
+	})
 }
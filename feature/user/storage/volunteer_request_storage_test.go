@@ -5,21 +5,23 @@ import (
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/gorm"
 )
 
 func TestCreateVolunteerRequest(t *testing.T)  {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRequestRepository(&mockDB.DB)
+	db := setupLifecycleDB(t)
+	repo := NewVolunteerRequestRepository(db)
 	volRequest := &domain.VolunteerRequest{
-		ID:     1,
 		UserID: 2,
 		Type:   "Application",
 		Status: 0,
 	}
-	mockDB.On("Create", volRequest).Return(&gorm.DB{Error: nil})
 
 	err := repo.CreateVolunteerRequest(volRequest)
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+
+	events, err := listRequestEvents(db, volRequest.ID)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.RequestEventSubmit, events[0].Action)
+	assert.Equal(t, 2, *events[0].ActorID)
 }
\ No newline at end of file
@@ -1,11 +1,14 @@
 package transport
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
 	"github.com/gin-gonic/gin"
-	"net/http"
-	"strconv"
 )
 
 type AdminHandler struct {
@@ -18,16 +21,27 @@ func NewAuthenticationHandler(usecase usecase.AdminUsecaseInterface) *AdminHandl
 
 // GetListPendingRequest godoc
 // @Summary Get list pending request
-// @Description Get list pending request
+// @Description Get a page of pending requests, accepts the same filters and sort as list-request except status
 // @Produce json
 // @Tags admin
 // @Security bearerToken
+// @Param page query int false "Page, starting at 1"
+// @Param page_size query int false "Page size, at most 100"
+// @Param type query string false "Request type"
+// @Param sort query string false "Sort field, prefix with - for descending" Enums(id, -id, created_at, -created_at, updated_at, -updated_at, type, -type)
 // @Success 200 {object} dto.ListRequest{}
 // @Router /api/v1/admin/list-pending-request [get]
 func (h *AdminHandler) GetListPendingRequest(c *gin.Context) {
-	resp, msg := h.usecase.GetListPendingRequest()
+	var query dto.ListRequestQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	status := domain.RequestStatusPending
+	query.Status = &status
+	resp, msg := h.usecase.ListRequests(query)
 	if msg != "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -58,16 +72,31 @@ func (h *AdminHandler) GetPendingRequestById(c *gin.Context) {
 
 // GetListRequest godoc
 // @Summary Get list request
-// @Description Get list request
+// @Description Get a page of requests matching the filters, newest first by default
 // @Produce json
 // @Tags admin
 // @Security bearerToken
+// @Param page query int false "Page, starting at 1"
+// @Param page_size query int false "Page size, at most 100"
+// @Param type query string false "Request type"
+// @Param status query int false "Request status"
+// @Param verifier_id query int false "Verifier ID"
+// @Param created_from query string false "Created on or after, YYYY-MM-DD"
+// @Param created_to query string false "Created on or before, YYYY-MM-DD"
+// @Param email query string false "Applicant email contains"
+// @Param name query string false "Applicant name or surname contains"
+// @Param sort query string false "Sort field, prefix with - for descending" Enums(id, -id, created_at, -created_at, updated_at, -updated_at, status, -status, type, -type)
 // @Success 200 {object} dto.ListRequest{}
 // @Router /api/v1/admin/list-request [get]
 func (h *AdminHandler) GetListRequest(c *gin.Context) {
-	resp, msg := h.usecase.GetListRequest()
+	var query dto.ListRequestQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resp, msg := h.usecase.ListRequests(query)
 	if msg != "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -96,6 +125,58 @@ func (h *AdminHandler) GetRequestById(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetRequestHistory godoc
+// @Summary Get request history
+// @Description Get every status change and note recorded for a request, oldest first
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestHistoryResponse{}
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/history [get]
+func (h *AdminHandler) GetRequestHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	resp, msg := h.usecase.GetRequestHistory(id)
+	if msg != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReviewRequest godoc
+// @Summary Start reviewing request
+// @Description Move a pending request into review
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 string message
+// @Failure 404 string error
+// @Failure 409 string error
+// @Security bearerToken
+// @Router /api/v1/admin/review-request/{id} [post]
+func (h *AdminHandler) ReviewRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if err := h.usecase.ReviewRequest(id, userId.(int)); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Review request started"})
+}
+
 // ApproveRequest godoc
 // @Summary Approve request
 // @Description Approve request
@@ -103,6 +184,8 @@ func (h *AdminHandler) GetRequestById(c *gin.Context) {
 // @Tags admin
 // @Param id path int true "Request ID"
 // @Success 200 string message
+// @Failure 404 string error
+// @Failure 409 string error
 // @Security bearerToken
 // @Router /api/v1/admin/approve-request/{id} [post]
 func (h *AdminHandler) ApproveRequest(c *gin.Context) {
@@ -116,8 +199,11 @@ func (h *AdminHandler) ApproveRequest(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
-	msg := h.usecase.ApproveRequest(id, userId.(int))
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	if err := h.usecase.ApproveRequest(id, userId.(int)); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Approve request success"})
 }
 
 // RejectRequest godoc
@@ -127,6 +213,8 @@ func (h *AdminHandler) ApproveRequest(c *gin.Context) {
 // @Tags admin
 // @Param id path int true "Request ID"
 // @Success 200 string message
+// @Failure 404 string error
+// @Failure 409 string error
 // @Security bearerToken
 // @Router /api/v1/admin/reject-request/{id} [post]
 func (h *AdminHandler) RejectRequest(c *gin.Context) {
@@ -140,8 +228,11 @@ func (h *AdminHandler) RejectRequest(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
-	msg := h.usecase.RejectRequest(id, userId.(int))
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	if err := h.usecase.RejectRequest(id, userId.(int)); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Reject request success"})
 }
 
 // AddRejectNotes godoc
@@ -152,6 +243,8 @@ func (h *AdminHandler) RejectRequest(c *gin.Context) {
 // @Param id path int true "Request ID"
 // @Param notes body dto.AddRejectNoteRequest true "Add Reject Note Request"
 // @Success 200 string message
+// @Failure 404 string error
+// @Failure 409 string error
 // @Security bearerToken
 // @Router /api/v1/admin/add-reject-notes/{id} [post]
 func (h *AdminHandler) AddRejectNotes(c *gin.Context) {
@@ -165,17 +258,26 @@ func (h *AdminHandler) AddRejectNotes(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	msg := h.usecase.AddRejectNotes(id, req.Notes)
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if err := h.usecase.AddRejectNotes(id, userId.(int), req.Notes); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Add reject notes success"})
 }
 
 // DeleteRequest godoc
 // @Summary Delete request
-// @Description Delete request
+// @Description Move the request to the trash, it can be restored until it is purged
 // @Produce json
 // @Tags admin
 // @Param id path int true "Request ID"
 // @Success 200 string message
+// @Failure 404 {object} map[string]string
 // @Security bearerToken
 // @Router /api/v1/admin/delete-request/{id} [delete]
 func (h *AdminHandler) DeleteRequest(c *gin.Context) {
@@ -185,5 +287,364 @@ func (h *AdminHandler) DeleteRequest(c *gin.Context) {
 		return
 	}
 	msg := h.usecase.DeleteRequest(id)
+	if msg == domain.ErrRequestNotFound.Error() {
+		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": msg})
 }
+
+// ListDeletedRequests godoc
+// @Summary List deleted requests
+// @Description Get the requests in the trash, most recently deleted first
+// @Produce json
+// @Tags admin
+// @Success 200 {array} dto.RequestResponse{}
+// @Security bearerToken
+// @Router /api/v1/admin/trash/requests [get]
+func (h *AdminHandler) ListDeletedRequests(c *gin.Context) {
+	resp, err := h.usecase.ListDeletedRequests()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RestoreRequest godoc
+// @Summary Restore request
+// @Description Take a deleted request out of the trash
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 string message
+// @Failure 404 string error
+// @Security bearerToken
+// @Router /api/v1/admin/trash/requests/{id}/restore [post]
+func (h *AdminHandler) RestoreRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	if err := h.usecase.RestoreRequest(id); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request restored successfully"})
+}
+
+// BulkApproveRequests godoc
+// @Summary Approve requests in bulk
+// @Description Approve the requests given by id or matching a filter, at most 500. Every request is approved in its own transaction and reported as succeeded, skipped when it was already processed, or failed with the reason.
+// @Produce json
+// @Tags admin
+// @Param request body dto.BulkRequestRequest true "Requests"
+// @Success 200 {object} dto.BulkRequestResponse{}
+// @Failure 422 string error
+// @Security bearerToken
+// @Router /api/v1/admin/approve-requests [post]
+func (h *AdminHandler) BulkApproveRequests(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	h.bulk(c, func(request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+		return h.usecase.BulkApproveRequests(userId.(int), request)
+	})
+}
+
+// BulkRejectRequests godoc
+// @Summary Reject requests in bulk
+// @Description Reject the requests given by id or matching a filter, at most 500. Every request is rejected in its own transaction and reported as succeeded, skipped when it was already processed, or failed with the reason.
+// @Produce json
+// @Tags admin
+// @Param request body dto.BulkRequestRequest true "Requests"
+// @Success 200 {object} dto.BulkRequestResponse{}
+// @Failure 422 string error
+// @Security bearerToken
+// @Router /api/v1/admin/reject-requests [post]
+func (h *AdminHandler) BulkRejectRequests(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	h.bulk(c, func(request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+		return h.usecase.BulkRejectRequests(userId.(int), request)
+	})
+}
+
+// BulkDeleteRequests godoc
+// @Summary Delete requests in bulk
+// @Description Delete the requests given by id or matching a filter, at most 500, and report the outcome of each
+// @Produce json
+// @Tags admin
+// @Param request body dto.BulkRequestRequest true "Requests"
+// @Success 200 {object} dto.BulkRequestResponse{}
+// @Failure 422 string error
+// @Security bearerToken
+// @Router /api/v1/admin/delete-requests [post]
+func (h *AdminHandler) BulkDeleteRequests(c *gin.Context) {
+	h.bulk(c, h.usecase.BulkDeleteRequests)
+}
+
+// bulk binds the selection of a bulk action, runs it and writes the report.
+func (h *AdminHandler) bulk(c *gin.Context, run func(request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error)) {
+	var request dto.BulkRequestRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resp, err := run(request)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// MarkRequestViewed godoc
+// @Summary Mark request as viewed
+// @Description Record that the authenticated admin has viewed a request
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 string message
+// @Failure 404 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/view [post]
+func (h *AdminHandler) MarkRequestViewed(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if err := h.usecase.MarkRequestViewed(id, userId.(int)); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request marked as viewed"})
+}
+
+// ListRequestViews godoc
+// @Summary List request views
+// @Description Get the admins who have viewed a request, most recent first
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestViewsResponse{}
+// @Failure 404 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/views [get]
+func (h *AdminHandler) ListRequestViews(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	resp, err := h.usecase.ListRequestViews(id)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ClaimRequest godoc
+// @Summary Claim request
+// @Description Hold a pending or in review request for the authenticated admin until the claim expires. Claiming it again extends the claim.
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestResponse{}
+// @Failure 404 string error
+// @Failure 409 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/claim [post]
+func (h *AdminHandler) ClaimRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	resp, err := h.usecase.ClaimRequest(id, userId.(int))
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AssignRequest godoc
+// @Summary Assign request
+// @Description Hold a pending or in review request for another reviewer until the claim expires
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param request body dto.AssignRequestRequest true "Reviewer"
+// @Success 200 {object} dto.RequestResponse{}
+// @Failure 404 string error
+// @Failure 409 string error
+// @Failure 422 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/assign [post]
+func (h *AdminHandler) AssignRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	var request dto.AssignRequestRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resp, err := h.usecase.AssignRequest(id, userId.(int), request)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReleaseRequest godoc
+// @Summary Release request
+// @Description Drop the claim the authenticated admin holds on a request
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 string message
+// @Failure 404 string error
+// @Failure 409 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/claim [delete]
+func (h *AdminHandler) ReleaseRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if err := h.usecase.ReleaseRequest(id, userId.(int)); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request released"})
+}
+
+// ListRequestMessages godoc
+// @Summary List request messages
+// @Description Get the conversation with the owner of a request, oldest first. Messages of the owner are marked as read.
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestMessagesResponse{}
+// @Failure 404 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/messages [get]
+func (h *AdminHandler) ListRequestMessages(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	resp, err := h.usecase.ListRequestMessages(id)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SendRequestMessage godoc
+// @Summary Send message to requester
+// @Description Post a message to the owner of a pending, in review or rejected request. The owner is notified by email.
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param request body dto.SendRequestMessageRequest true "Message"
+// @Success 201 {object} dto.RequestMessageResponse{}
+// @Failure 404 string error
+// @Failure 409 string error
+// @Security bearerToken
+// @Router /api/v1/admin/request/{id}/messages [post]
+func (h *AdminHandler) SendRequestMessage(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	var request dto.SendRequestMessageRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	resp, err := h.usecase.SendRequestMessage(id, userId.(int), request)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// CountUnreadMessages godoc
+// @Summary Count unread messages
+// @Description Get the number of unread messages from requesters, per request
+// @Produce json
+// @Tags admin
+// @Success 200 {object} dto.UnreadMessagesResponse{}
+// @Security bearerToken
+// @Router /api/v1/admin/messages/unread [get]
+func (h *AdminHandler) CountUnreadMessages(c *gin.Context) {
+	resp, err := h.usecase.CountUnreadMessages()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// requestErrorStatus maps request lifecycle errors to HTTP status codes.
+func requestErrorStatus(err error) int {
+	var transitionErr *domain.InvalidTransitionError
+	var claimedErr *domain.RequestClaimedError
+	switch {
+	case errors.Is(err, domain.ErrRequestNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &transitionErr), errors.As(err, &claimedErr),
+		errors.Is(err, domain.ErrRequestClosed), errors.Is(err, domain.ErrRequestNotOpen),
+		errors.Is(err, domain.ErrNotesClosed):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrDepartmentRequired), errors.Is(err, domain.ErrInvalidRequestType),
+		errors.Is(err, domain.ErrReviewerNotFound), errors.Is(err, domain.ErrBulkNoSelection),
+		errors.Is(err, domain.ErrBulkTooLarge):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
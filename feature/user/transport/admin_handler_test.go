@@ -1,10 +1,14 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -16,9 +20,12 @@ type MockAdminUsecase struct {
 	mock.Mock
 }
 
-func (m *MockAdminUsecase) GetListPendingRequest() (*dto.ListRequest, string) {
-	args := m.Called()
-	return args.Get(0).(*dto.ListRequest), args.String(1)
+func (m *MockAdminUsecase) ListRequests(query dto.ListRequestQuery) (*dto.ListRequest, string) {
+	args := m.Called(query)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ListRequest), args.String(1)
+	}
+	return nil, args.String(1)
 }
 
 func (m *MockAdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, string) {
@@ -26,29 +33,37 @@ func (m *MockAdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse,
 	return args.Get(0).(*dto.RequestResponse), args.String(1)
 }
 
-func (m *MockAdminUsecase) GetListRequest() (*dto.ListRequest, string) {
-	args := m.Called()
-	return args.Get(0).(*dto.ListRequest), args.String(1)
-}
-
 func (m *MockAdminUsecase) GetRequestById(id int) (*dto.RequestResponse, string) {
 	args := m.Called(id)
 	return args.Get(0).(*dto.RequestResponse), args.String(1)
 }
 
-func (m *MockAdminUsecase) ApproveRequest(id, userId int) string {
+func (m *MockAdminUsecase) ReviewRequest(id, userId int) error {
 	args := m.Called(id, userId)
-	return args.String(0)
+	return args.Error(0)
 }
 
-func (m *MockAdminUsecase) RejectRequest(id, userId int) string {
+func (m *MockAdminUsecase) ApproveRequest(id, userId int) error {
 	args := m.Called(id, userId)
-	return args.String(0)
+	return args.Error(0)
 }
 
-func (m *MockAdminUsecase) AddRejectNotes(id int, notes string) string {
-	args := m.Called(id, notes)
-	return args.String(0)
+func (m *MockAdminUsecase) RejectRequest(id, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+func (m *MockAdminUsecase) AddRejectNotes(id int, userId int, notes string) error {
+	args := m.Called(id, userId, notes)
+	return args.Error(0)
+}
+
+func (m *MockAdminUsecase) GetRequestHistory(id int) (*dto.RequestHistoryResponse, string) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestHistoryResponse), args.String(1)
+	}
+	return nil, args.String(1)
 }
 
 func (m *MockAdminUsecase) DeleteRequest(id int) string {
@@ -56,6 +71,98 @@ func (m *MockAdminUsecase) DeleteRequest(id int) string {
 	return args.String(0)
 }
 
+func (m *MockAdminUsecase) ListDeletedRequests() ([]*dto.RequestResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]*dto.RequestResponse), args.Error(1)
+}
+
+func (m *MockAdminUsecase) RestoreRequest(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAdminUsecase) BulkApproveRequests(adminID int, request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+	args := m.Called(adminID, request)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.BulkRequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) BulkRejectRequests(adminID int, request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+	args := m.Called(adminID, request)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.BulkRequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) BulkDeleteRequests(request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+	args := m.Called(request)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.BulkRequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) ListRequestMessages(id int) (*dto.RequestMessagesResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestMessagesResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) SendRequestMessage(id int, adminID int, request dto.SendRequestMessageRequest) (*dto.RequestMessageResponse, error) {
+	args := m.Called(id, adminID, request)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestMessageResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) CountUnreadMessages() (*dto.UnreadMessagesResponse, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.UnreadMessagesResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) MarkRequestViewed(id int, adminID int) error {
+	args := m.Called(id, adminID)
+	return args.Error(0)
+}
+
+func (m *MockAdminUsecase) ListRequestViews(id int) (*dto.RequestViewsResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestViewsResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) ClaimRequest(id int, adminID int) (*dto.RequestResponse, error) {
+	args := m.Called(id, adminID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) AssignRequest(id int, adminID int, request dto.AssignRequestRequest) (*dto.RequestResponse, error) {
+	args := m.Called(id, adminID, request)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) ReleaseRequest(id int, adminID int) error {
+	args := m.Called(id, adminID)
+	return args.Error(0)
+}
+
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.Default()
@@ -68,15 +175,56 @@ func TestGetListPendingRequest(t *testing.T) {
 	router := setupRouter()
 	router.GET("/api/v1/admin/list-pending-request", handler.GetListPendingRequest)
 
-	mockUsecase.On("GetListPendingRequest").Return([]dto.RequestResponse{}, "")
+	pending := domain.RequestStatusPending
+	mockUsecase.On("ListRequests", dto.ListRequestQuery{Status: &pending}).Return(&dto.ListRequest{Requests: []*dto.RequestResponse{}, Page: 1, PageSize: 20}, "")
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/list-pending-request", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "[]")
+	assert.Contains(t, w.Body.String(), `"requests":[]`)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetListRequest(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.GET("/api/v1/admin/list-request", handler.GetListRequest)
+
+	status := domain.RequestStatusRejected
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	query := dto.ListRequestQuery{Page: 2, PageSize: 10, Status: &status, CreatedFrom: &from, Email: "alice", Sort: "-status"}
+	mockUsecase.On("ListRequests", mock.MatchedBy(func(q dto.ListRequestQuery) bool {
+		return q.Page == query.Page && q.PageSize == query.PageSize && *q.Status == status &&
+			q.CreatedFrom.Equal(from) && q.Email == query.Email && q.Sort == query.Sort
+	})).Return(&dto.ListRequest{Requests: []*dto.RequestResponse{{ID: 1}}, Page: 2, PageSize: 10, Total: 11, TotalPages: 2}, "")
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/list-request?page=2&page_size=10&status=2&created_from=2024-05-01&email=alice&sort=-status", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":11`)
 	mockUsecase.AssertExpectations(t)
+
+	t.Run("invalid sort", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/list-request?sort=password", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("page size too large", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/list-request?page_size=1000", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestGetPendingRequestById(t *testing.T) {
@@ -86,14 +234,14 @@ func TestGetPendingRequestById(t *testing.T) {
 	router := setupRouter()
 	router.GET("/api/v1/admin/pending-request/:id", handler.GetPendingRequestById)
 
-	mockUsecase.On("GetPendingRequestById", 1).Return(dto.RequestResponse{}, "")
+	mockUsecase.On("GetPendingRequestById", 1).Return(&dto.RequestResponse{ID: 1}, "")
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/pending-request/1", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "{}")
+	assert.Contains(t, w.Body.String(), `"id":1`)
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -109,14 +257,14 @@ func TestApproveRequest(t *testing.T) {
 		handler.ApproveRequest(c)
 	})
 
-	mockUsecase.On("ApproveRequest", 1, 1).Return("Request approved")
+	mockUsecase.On("ApproveRequest", 1, 1).Return(nil)
 
 	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/approve-request/1", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "Request approved")
+	assert.Contains(t, w.Body.String(), "Approve request success")
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -130,13 +278,234 @@ func TestRejectRequest(t *testing.T) {
 		handler.RejectRequest(c)
 	})
 
-	mockUsecase.On("RejectRequest", 1, 1).Return("Request rejected")
+	mockUsecase.On("RejectRequest", 1, 1).Return(nil)
 
 	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reject-request/1", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "Request rejected")
+	assert.Contains(t, w.Body.String(), "Reject request success")
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestAddRejectNotes(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.POST("/api/v1/admin/add-reject-notes/:id", func(c *gin.Context) {
+		c.Set("userId", 1)
+		handler.AddRejectNotes(c)
+	})
+
+	mockUsecase.On("AddRejectNotes", 1, 1, "missing passport").Return(nil)
+	mockUsecase.On("AddRejectNotes", 2, 1, "missing passport").Return(domain.ErrNotesClosed)
+	mockUsecase.On("AddRejectNotes", 3, 1, "missing passport").Return(domain.ErrRequestNotFound)
+
+	tests := []struct {
+		id     string
+		status int
+	}{
+		{"1", http.StatusOK},
+		{"2", http.StatusConflict},
+		{"3", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/add-reject-notes/"+tt.id, strings.NewReader(`{"notes":"missing passport"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.status, w.Code)
+	}
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestRequestTransitionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not found", domain.ErrRequestNotFound, http.StatusNotFound},
+		{"already processed", &domain.InvalidTransitionError{From: domain.RequestStatusApproved, To: domain.RequestStatusApproved}, http.StatusConflict},
+		{"missing department", domain.ErrDepartmentRequired, http.StatusUnprocessableEntity},
+		{"database failure", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockAdminUsecase)
+			handler := NewAuthenticationHandler(mockUsecase)
+
+			router := setupRouter()
+			router.POST("/api/v1/admin/approve-request/:id", func(c *gin.Context) {
+				c.Set("userId", 1)
+				handler.ApproveRequest(c)
+			})
+
+			mockUsecase.On("ApproveRequest", 1, 1).Return(tt.err)
+
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/approve-request/1", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.err.Error())
+		})
+	}
+}
+
+func TestGetRequestHistory(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.GET("/api/v1/admin/request/:id/history", handler.GetRequestHistory)
+
+	history := &dto.RequestHistoryResponse{
+		RequestID: 1,
+		Events:    []dto.RequestEventResponse{{ID: 1, Action: "reject", FromStatus: "pending", ToStatus: "rejected"}},
+	}
+	mockUsecase.On("GetRequestHistory", 1).Return(history, "")
+	mockUsecase.On("GetRequestHistory", 2).Return(nil, "Request not found")
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/request/1/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"to_status":"rejected"`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/request/2/history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSendRequestMessage(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.POST("/api/v1/admin/request/:id/messages", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.SendRequestMessage(c)
+	})
+
+	body := dto.SendRequestMessageRequest{Body: "Which passport?"}
+	mockUsecase.On("SendRequestMessage", 1, 7, body).Return(&dto.RequestMessageResponse{ID: 3, RequestID: 1, SenderID: 7, FromAdmin: true, Body: body.Body}, nil)
+	mockUsecase.On("SendRequestMessage", 2, 7, body).Return(nil, domain.ErrRequestClosed)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/request/1/messages", strings.NewReader(`{"body":"Which passport?"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"from_admin":true`)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/request/2/messages", strings.NewReader(`{"body":"Which passport?"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/request/1/messages", strings.NewReader(`{"body":""}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestClaimRequest(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	setUser := func(c *gin.Context) { c.Set("userId", 7) }
+	router.POST("/api/v1/admin/request/:id/claim", setUser, handler.ClaimRequest)
+	router.POST("/api/v1/admin/request/:id/assign", setUser, handler.AssignRequest)
+
+	reviewer := 7
+	mockUsecase.On("ClaimRequest", 1, 7).Return(&dto.RequestResponse{ID: 1, ClaimedBy: &reviewer}, nil)
+	mockUsecase.On("ClaimRequest", 2, 7).Return(nil, &domain.RequestClaimedError{ClaimedBy: 8, ExpiresAt: time.Now().Add(time.Minute)})
+	mockUsecase.On("AssignRequest", 1, 7, dto.AssignRequestRequest{ReviewerID: 99}).Return(nil, domain.ErrReviewerNotFound)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/request/1/claim", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"claimed_by":7`)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/request/2/claim", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "claimed by reviewer 8")
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/request/1/assign", strings.NewReader(`{"reviewer_id":99}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestBulkApproveRequests(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.POST("/api/v1/admin/approve-requests", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.BulkApproveRequests(c)
+	})
+
+	mockUsecase.On("BulkApproveRequests", 7, dto.BulkRequestRequest{IDs: []int{1, 2}}).Return(&dto.BulkRequestResponse{
+		Succeeded: 1,
+		Skipped:   1,
+		Items:     []dto.BulkRequestItem{{ID: 1, Result: dto.BulkResultSucceeded}, {ID: 2, Result: dto.BulkResultSkipped, Error: "request cannot move from approved to approved"}},
+	}, nil)
+	mockUsecase.On("BulkApproveRequests", 7, dto.BulkRequestRequest{}).Return(nil, domain.ErrBulkNoSelection)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/approve-requests", strings.NewReader(`{"ids":[1,2]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"result":"skipped"`)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/approve-requests", strings.NewReader(`{}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/approve-requests", strings.NewReader(`{"ids":[0]}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRestoreRequest(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.GET("/api/v1/admin/trash/requests", handler.ListDeletedRequests)
+	router.POST("/api/v1/admin/trash/requests/:id/restore", handler.RestoreRequest)
+
+	deletedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockUsecase.On("ListDeletedRequests").Return([]*dto.RequestResponse{{ID: 1, DeletedAt: &deletedAt}}, nil)
+	mockUsecase.On("RestoreRequest", 1).Return(nil)
+	mockUsecase.On("RestoreRequest", 2).Return(domain.ErrRequestNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/trash/requests", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"deleted_at":"2024-05-01T00:00:00Z"`)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/trash/requests/1/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/trash/requests/2/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
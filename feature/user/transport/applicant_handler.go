@@ -1,13 +1,16 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ApplicantHandler struct {
@@ -101,6 +104,7 @@ func (h *ApplicantHandler) DeleteApplicant(c *gin.Context) {
 // @Tags applicant
 // @Param id path int true "Applicant ID"
 // @Success 200 {object} dto.ApplicantResponseDTO
+// @Failure 404 string error
 // @Router /api/v1/applicant/{id} [get]
 func (h *ApplicantHandler) FindApplicantByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -111,9 +115,132 @@ func (h *ApplicantHandler) FindApplicantByID(c *gin.Context) {
 
 	user, err := h.ApplicantUseCaseH.FindApplicantByID(id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, user)
 }
+
+// ListDeletedApplicants godoc
+// @Summary List deleted applicants
+// @Description Get the applicants in the trash, most recently deleted first
+// @Produce json
+// @Tags admin
+// @Success 200 {array} dto.ApplicantResponseDTO
+// @Security bearerToken
+// @Router /api/v1/admin/trash/applicants [get]
+func (h *ApplicantHandler) ListDeletedApplicants(c *gin.Context) {
+	users, err := h.ApplicantUseCaseH.ListDeletedApplicants()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// RestoreApplicant godoc
+// @Summary Restore applicant
+// @Description Take a deleted applicant out of the trash
+// @Produce json
+// @Tags admin
+// @Param id path int true "Applicant ID"
+// @Success 200 string message
+// @Failure 404 string error
+// @Security bearerToken
+// @Router /api/v1/admin/trash/applicants/{id}/restore [post]
+func (h *ApplicantHandler) RestoreApplicant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.ApplicantUseCaseH.RestoreApplicant(id); err != nil {
+		if errors.Is(err, domain.ErrApplicantNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User restored successfully"})
+}
+
+// GetMe godoc
+// @Summary Get my profile
+// @Description Get the profile of the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Success 200 {object} dto.ApplicantResponseDTO
+// @Router /api/v1/me [get]
+func (h *ApplicantHandler) GetMe(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	user, err := h.ApplicantUseCaseH.FindApplicantByID(userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateMe godoc
+// @Summary Update my profile
+// @Description Update the profile of the authenticated user, email and role cannot be changed here
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param request body dto.ApplicantProfileUpdateDTO true "Update Profile Request"
+// @Success 200 {string} message "User updated successfully"
+// @Router /api/v1/me [put]
+func (h *ApplicantHandler) UpdateMe(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request dto.ApplicantProfileUpdateDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.ApplicantUseCaseH.UpdateApplicantProfile(userId, request); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
+}
+
+// currentUserID returns the id of the authenticated user set by AuthMiddleware.
+func currentUserID(c *gin.Context) (int, bool) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		return 0, false
+	}
+	id, ok := userId.(int)
+	return id, ok
+}
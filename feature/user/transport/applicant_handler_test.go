@@ -1,7 +1,6 @@
 package transport
 
 import (
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 )
@@ -33,11 +33,29 @@ func (m *MockApplicantUsecase) DeleteApplicant(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockApplicantUsecase) ListDeletedApplicants() ([]*dto.ApplicantResponseDTO, error) {
+	args := m.Called()
+	return args.Get(0).([]*dto.ApplicantResponseDTO), args.Error(1)
+}
+
+func (m *MockApplicantUsecase) RestoreApplicant(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *MockApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*dto.ApplicantResponseDTO), args.Error(1)
 }
 
+func (m *MockApplicantUsecase) UpdateApplicantProfile(id int, input dto.ApplicantProfileUpdateDTO) error {
+	args := m.Called(id, input)
+	return args.Error(0)
+}
+
 func TestCreateApplicant(t *testing.T) {
 	mockUsecase := new(MockApplicantUsecase)
 	handler := NewApplicantHandler(mockUsecase)
@@ -95,7 +113,7 @@ func TestUpdateApplicant(t *testing.T) {
 			Name:              "Tony",
 			Surname:           "Quang",
 			Gender:            "Male",
-			DOB:               "time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC)",
+			DOB:               "2002-09-20",
 			Mobile:            "0913895987",
 			CountryID:         2,
 			ResidentCountryID: 7,
@@ -103,16 +121,16 @@ func TestUpdateApplicant(t *testing.T) {
 		mockUsecase.On("UpdateApplicant", 1, mockInput).Return(nil)
 
 		body := `{
-			"RoleID": 1, 
-			"DepartmentID": 2, 
-			"Email": "test@example.com", 
-			"Name": "Tony", 
-			"Surname": "Quang", 
-			"Gender": "Male", 
-			"DOB": "2002-09-20 00:00:00 +0000 UTC",
-			"Mobile": "0913895987",
-			"CountryID": 2,
-			"ResidentCountryID": 7,
+			"role_id": 1,
+			"department_id": 2,
+			"email": "test@example.com",
+			"name": "Tony",
+			"surname": "Quang",
+			"gender": "Male",
+			"dob": "2002-09-20",
+			"mobile": "0913895987",
+			"country_id": 2,
+			"resident_country_id": 7
 		}`
 		req, err := http.NewRequest(http.MethodPut, "/api/v1/applicant/1", strings.NewReader(body))
 		assert.NoError(t, err)
@@ -216,9 +234,9 @@ func TestFindApplicantByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUsecase.On("FindApplicantByID", 1).Return(nil, errors.New("Applicant not found"))
+		mockUsecase.On("FindApplicantByID", 2).Return(nil, gorm.ErrRecordNotFound)
 
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/applicant/1", nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/applicant/2", nil)
 		assert.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -227,3 +245,61 @@ func TestFindApplicantByID(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 }
+
+func TestGetMe(t *testing.T) {
+	mockUsecase := new(MockApplicantUsecase)
+	handler := NewApplicantHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/me", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.GetMe(c)
+	})
+	r.GET("/api/v1/anonymous/me", handler.GetMe)
+
+	t.Run("uses the id from the token", func(t *testing.T) {
+		mockUsecase.On("FindApplicantByID", 7).Return(&dto.ApplicantResponseDTO{ID: 7, Email: "me@example.com"}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/me", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "me@example.com")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/anonymous/me", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestUpdateMe(t *testing.T) {
+	mockUsecase := new(MockApplicantUsecase)
+	handler := NewApplicantHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PUT("/api/v1/me", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.UpdateMe(c)
+	})
+
+	input := dto.ApplicantProfileUpdateDTO{Name: "Johnny", Surname: "Hoang", DOB: "2002-09-20"}
+	mockUsecase.On("UpdateApplicantProfile", 7, input).Return(nil)
+
+	// role_id in the body is not part of the profile and is ignored
+	body := `{"name":"Johnny","surname":"Hoang","dob":"2002-09-20","role_id":1}`
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/me", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockUsecase.AssertExpectations(t)
+}
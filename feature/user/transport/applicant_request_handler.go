@@ -2,6 +2,7 @@ package transport
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
@@ -39,3 +40,268 @@ func (h *RequestHandler) CreateApplicantRequest(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Request created successfully"})
 }
+
+// GetRequestHistory godoc
+// @Summary Get request history
+// @Description Get the status changes and reject notes of one of your own requests
+// @Produce json
+// @Tags request
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestHistoryResponse{}
+// @Security bearerToken
+// @Router /api/v1/applicant-request/{id}/history [get]
+// @Router /api/v1/me/requests/{id}/history [get]
+func (h *RequestHandler) GetRequestHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	resp, err := h.RequestUsecase.GetRequestHistory(userId, id)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateMyRequest godoc
+// @Summary Submit my request
+// @Description Submit a registration or verification request for the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param request body dto.MyRequestCreatingDTO true "Create Request Request"
+// @Success 201 {string} message "Request created successfully"
+// @Router /api/v1/me/requests [post]
+func (h *RequestHandler) CreateMyRequest(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request dto.MyRequestCreatingDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.RequestUsecase.CreateMyRequest(userId, request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Request created successfully"})
+}
+
+// ListMyRequests godoc
+// @Summary List my requests
+// @Description List the requests of the authenticated user, newest first
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Success 200 {array} dto.RequestResponse
+// @Router /api/v1/me/requests [get]
+func (h *RequestHandler) ListMyRequests(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	resp, err := h.RequestUsecase.ListMyRequests(userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetMyRequest godoc
+// @Summary Get my request
+// @Description Get one of the requests of the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestResponse
+// @Router /api/v1/me/requests/{id} [get]
+func (h *RequestHandler) GetMyRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	resp, err := h.RequestUsecase.GetMyRequest(userId, id)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CancelMyRequest godoc
+// @Summary Cancel my request
+// @Description Withdraw a pending, in review or rejected request of the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {string} message "Request cancelled successfully"
+// @Router /api/v1/me/requests/{id}/cancel [post]
+func (h *RequestHandler) CancelMyRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.RequestUsecase.CancelMyRequest(userId, id); err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Request cancelled successfully"})
+}
+
+// ResubmitMyRequest godoc
+// @Summary Resubmit my request
+// @Description Submit a rejected request of the authenticated user again. The new request links to the rejected one and carries its reject notes.
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Rejected request ID"
+// @Success 201 {object} dto.RequestResponse
+// @Router /api/v1/me/requests/{id}/resubmit [post]
+func (h *RequestHandler) ResubmitMyRequest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	resp, err := h.RequestUsecase.ResubmitMyRequest(userId, id)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListMyRequestMessages godoc
+// @Summary List my request messages
+// @Description Get the conversation with the admins about one of your own requests, oldest first. Admin messages are marked as read.
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestMessagesResponse
+// @Router /api/v1/me/requests/{id}/messages [get]
+func (h *RequestHandler) ListMyRequestMessages(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	resp, err := h.RequestUsecase.ListMyRequestMessages(userId, id)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SendMyRequestMessage godoc
+// @Summary Reply about my request
+// @Description Post a message to the admins about one of your own pending, in review or rejected requests
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Param request body dto.SendRequestMessageRequest true "Message"
+// @Success 201 {object} dto.RequestMessageResponse
+// @Router /api/v1/me/requests/{id}/messages [post]
+func (h *RequestHandler) SendMyRequestMessage(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request dto.SendRequestMessageRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.RequestUsecase.SendMyRequestMessage(userId, id, request)
+	if err != nil {
+		c.JSON(requestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// CountMyUnreadMessages godoc
+// @Summary Count my unread messages
+// @Description Get the number of unread admin messages on your own requests, per request
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Success 200 {object} dto.UnreadMessagesResponse
+// @Router /api/v1/me/messages/unread [get]
+func (h *RequestHandler) CountMyUnreadMessages(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	resp, err := h.RequestUsecase.CountMyUnreadMessages(userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,72 @@ func (m *MockApplicantRequestUsecase) CreateApplicantRequest(input dto.Applicant
 	return args.Error(0)
 }
 
+func (m *MockApplicantRequestUsecase) CreateMyRequest(userID int, input dto.MyRequestCreatingDTO) error {
+	args := m.Called(userID, input)
+	return args.Error(0)
+}
+
+func (m *MockApplicantRequestUsecase) ListMyRequests(userID int) ([]*dto.RequestResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApplicantRequestUsecase) GetMyRequest(userID int, requestID int) (*dto.RequestResponse, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApplicantRequestUsecase) GetRequestHistory(userID int, requestID int) (*dto.RequestHistoryResponse, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestHistoryResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApplicantRequestUsecase) CancelMyRequest(userID int, requestID int) error {
+	args := m.Called(userID, requestID)
+	return args.Error(0)
+}
+
+func (m *MockApplicantRequestUsecase) ResubmitMyRequest(userID int, requestID int) (*dto.RequestResponse, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApplicantRequestUsecase) ListMyRequestMessages(userID int, requestID int) (*dto.RequestMessagesResponse, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestMessagesResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApplicantRequestUsecase) SendMyRequestMessage(userID int, requestID int, request dto.SendRequestMessageRequest) (*dto.RequestMessageResponse, error) {
+	args := m.Called(userID, requestID, request)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestMessageResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockApplicantRequestUsecase) CountMyUnreadMessages(userID int) (*dto.UnreadMessagesResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.UnreadMessagesResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func TestCreateApplicantRequest(t *testing.T) {
 	mockUsecase := new(MockApplicantRequestUsecase)
 	handler := NewApplicantRequestHandler(mockUsecase)
@@ -33,7 +100,6 @@ func TestCreateApplicantRequest(t *testing.T) {
 		mockInput := dto.ApplicantRequestCreatingDTO{
 			UserID: 1,
 			Type:   "Application",
-			Status: 0,
 		}
 		mockUsecase.On("CreateApplicantRequest", mockInput).Return(nil)
 
@@ -61,3 +127,182 @@ func TestCreateApplicantRequest(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 }
+
+func TestApplicantGetRequestHistory(t *testing.T) {
+	mockUsecase := new(MockApplicantRequestUsecase)
+	handler := NewApplicantRequestHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/applicant-request/:id/history", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.GetRequestHistory(c)
+	})
+
+	t.Run("own request", func(t *testing.T) {
+		mockUsecase.On("GetRequestHistory", 5, 1).Return(&dto.RequestHistoryResponse{RequestID: 1}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/applicant-request/1/history", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("someone else's request", func(t *testing.T) {
+		mockUsecase.On("GetRequestHistory", 5, 2).Return(nil, domain.ErrRequestNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/applicant-request/2/history", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestCreateMyRequest(t *testing.T) {
+	mockUsecase := new(MockApplicantRequestUsecase)
+	handler := NewApplicantRequestHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/me/requests", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.CreateMyRequest(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("CreateMyRequest", 5, dto.MyRequestCreatingDTO{Type: "registration"}).Return(nil)
+
+		// user_id in the body cannot pick another user
+		body := `{"type":"registration","user_id":9}`
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/requests", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/requests", strings.NewReader(`{"type":"promotion"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetMyRequest(t *testing.T) {
+	mockUsecase := new(MockApplicantRequestUsecase)
+	handler := NewApplicantRequestHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/me/requests/:id", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.GetMyRequest(c)
+	})
+
+	mockUsecase.On("GetMyRequest", 5, 1).Return(&dto.RequestResponse{ID: 1, UserID: 5}, nil)
+	mockUsecase.On("GetMyRequest", 5, 2).Return(nil, domain.ErrRequestNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/me/requests/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/me/requests/2", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCancelMyRequest(t *testing.T) {
+	mockUsecase := new(MockApplicantRequestUsecase)
+	handler := NewApplicantRequestHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/me/requests/:id/cancel", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.CancelMyRequest(c)
+	})
+
+	mockUsecase.On("CancelMyRequest", 5, 1).Return(nil)
+	mockUsecase.On("CancelMyRequest", 5, 2).Return(&domain.InvalidTransitionError{From: domain.RequestStatusApproved, To: domain.RequestStatusCancelled})
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/requests/1/cancel", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/me/requests/2/cancel", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestResubmitMyRequest(t *testing.T) {
+	mockUsecase := new(MockApplicantRequestUsecase)
+	handler := NewApplicantRequestHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/me/requests/:id/resubmit", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.ResubmitMyRequest(c)
+	})
+
+	previousID := 1
+	mockUsecase.On("ResubmitMyRequest", 5, 1).Return(&dto.RequestResponse{ID: 2, UserID: 5, PreviousRequestID: &previousID}, nil)
+	mockUsecase.On("ResubmitMyRequest", 5, 3).Return(nil, domain.ErrRequestNotFound)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/requests/1/resubmit", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"previous_request_id":1`)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/me/requests/3/resubmit", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestMyRequestMessages(t *testing.T) {
+	mockUsecase := new(MockApplicantRequestUsecase)
+	handler := NewApplicantRequestHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	setUser := func(c *gin.Context) { c.Set("userId", 5) }
+	r.GET("/api/v1/me/requests/:id/messages", setUser, handler.ListMyRequestMessages)
+	r.GET("/api/v1/me/messages/unread", setUser, handler.CountMyUnreadMessages)
+
+	mockUsecase.On("ListMyRequestMessages", 5, 1).Return(&dto.RequestMessagesResponse{
+		RequestID: 1,
+		Messages:  []dto.RequestMessageResponse{{ID: 1, RequestID: 1, SenderID: 7, FromAdmin: true, Body: "Which passport?"}},
+	}, nil)
+	mockUsecase.On("ListMyRequestMessages", 5, 2).Return(nil, domain.ErrRequestNotFound)
+	mockUsecase.On("CountMyUnreadMessages", 5).Return(&dto.UnreadMessagesResponse{Total: 1, Requests: []dto.RequestUnreadCount{{RequestID: 1, Unread: 1}}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/me/requests/1/messages", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"body":"Which passport?"`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/me/requests/2/messages", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/me/messages/unread", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"total":1`)
+}
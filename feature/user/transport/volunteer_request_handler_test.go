@@ -33,7 +33,6 @@ func TestCreateVolunteerRequest(t *testing.T)  {
 		mockInput := dto.VoluteerRequestCreatingDTO{
 			UserID: 1,
 			Type:   "Verification",
-			Status: 0,
 		}
 		mockUsecase.On("CreateVolunteerRequest", mockInput).Return(nil)
 
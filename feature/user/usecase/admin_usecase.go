@@ -1,97 +1,382 @@
 package usecase
 
 import (
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 )
 
 type AdminUsecaseInterface interface {
-	GetListPendingRequest() (*dto.ListRequest, string)
+	ListRequests(query dto.ListRequestQuery) (*dto.ListRequest, string)
 	GetPendingRequestById(id int) (*dto.RequestResponse, string)
-	GetListRequest() (*dto.ListRequest, string)
 	GetRequestById(id int) (*dto.RequestResponse, string)
-	ApproveRequest(id int, verifier_id int) string
-	RejectRequest(id int, verifier_id int) string
-	AddRejectNotes(id int, notes string) string
+	ReviewRequest(id int, verifier_id int) error
+	ApproveRequest(id int, verifier_id int) error
+	RejectRequest(id int, verifier_id int) error
+	AddRejectNotes(id int, verifier_id int, notes string) error
+	GetRequestHistory(id int) (*dto.RequestHistoryResponse, string)
 	DeleteRequest(id int) string
+	ListDeletedRequests() ([]*dto.RequestResponse, error)
+	RestoreRequest(id int) error
+	BulkApproveRequests(adminID int, request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error)
+	BulkRejectRequests(adminID int, request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error)
+	BulkDeleteRequests(request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error)
+	ListRequestMessages(id int) (*dto.RequestMessagesResponse, error)
+	SendRequestMessage(id int, adminID int, request dto.SendRequestMessageRequest) (*dto.RequestMessageResponse, error)
+	CountUnreadMessages() (*dto.UnreadMessagesResponse, error)
+	MarkRequestViewed(id int, adminID int) error
+	ListRequestViews(id int) (*dto.RequestViewsResponse, error)
+	ClaimRequest(id int, adminID int) (*dto.RequestResponse, error)
+	AssignRequest(id int, adminID int, request dto.AssignRequestRequest) (*dto.RequestResponse, error)
+	ReleaseRequest(id int, adminID int) error
 }
 
+// AdminUsecase serves the admin request endpoints. Claims on requests last
+// claimTTL.
 type AdminUsecase struct {
-	repo storage.AdminRepositoryInterface
+	repo     storage.AdminRepositoryInterface
+	claimTTL time.Duration
 }
 
-func NewAdminUsecase(repo storage.AdminRepositoryInterface) *AdminUsecase {
-	return &AdminUsecase{repo: repo}
+func NewAdminUsecase(repo storage.AdminRepositoryInterface, claimTTL time.Duration) *AdminUsecase {
+	return &AdminUsecase{repo: repo, claimTTL: claimTTL}
 }
-func (u *AdminUsecase) GetListPendingRequest() (*dto.ListRequest, string) {
-	requests, msg := u.repo.GetListPendingRequest()
-	if requests != nil {
-		return &dto.ListRequest{
-			Requests: requests,
-		}, msg
-	} else {
-		msg = "No request found"
+
+const (
+	defaultRequestPageSize = 20
+	// maxBulkRequests bounds the number of requests a bulk action handles
+	maxBulkRequests = 500
+)
+
+func (u *AdminUsecase) ListRequests(query dto.ListRequestQuery) (*dto.ListRequest, string) {
+	if query.Page < 1 {
+		query.Page = 1
 	}
-	return nil, msg
+	if query.PageSize < 1 {
+		query.PageSize = defaultRequestPageSize
+	}
+	requests, total, msg := u.repo.ListRequests(query)
+	if msg != "" {
+		return nil, msg
+	}
+	resp := make([]*dto.RequestResponse, 0, len(requests))
+	for _, request := range requests {
+		resp = append(resp, toRequestResponse(request))
+	}
+	return &dto.ListRequest{
+		Requests:   resp,
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Total:      total,
+		TotalPages: int((total + int64(query.PageSize) - 1) / int64(query.PageSize)),
+	}, ""
 }
 func (u *AdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, string) {
 	request, msg := u.repo.GetPendingRequestByID(id)
 	if request != nil {
-		return &dto.RequestResponse{
-			ID:          request.ID,
-			UserID:      request.UserID,
-			Type:        request.Type,
-			Status:      request.Status,
-			RejectNotes: request.RejectNotes,
-			VerifierID:  request.VerifierID,
-			CreateAt:    request.CreatedAt,
-			UpdateAt:    request.UpdatedAt,
-		}, msg
+		return toRequestResponse(request), msg
 	} else {
 		msg = "Request not found"
 	}
 	return nil, msg
 }
 
-func (u *AdminUsecase) GetListRequest() (*dto.ListRequest, string) {
-	requests, msg := u.repo.GetListAllRequest()
-	if requests != nil {
-		return &dto.ListRequest{
-			Requests: requests,
-		}, msg
-	} else {
-		msg = "No request found"
-	}
-	return nil, msg
-}
 func (u *AdminUsecase) GetRequestById(id int) (*dto.RequestResponse, string) {
 	request, msg := u.repo.GetRequestByID(id)
 	if request != nil {
-		return &dto.RequestResponse{
-			ID:          request.ID,
-			UserID:      request.UserID,
-			Type:        request.Type,
-			Status:      request.Status,
-			RejectNotes: request.RejectNotes,
-			VerifierID:  request.VerifierID,
-			CreateAt:    request.CreatedAt,
-			UpdateAt:    request.UpdatedAt,
-		}, msg
+		return toRequestResponse(request), msg
 	} else {
 		msg = "Request not found"
 	}
 	return nil, msg
 }
 
-func (u *AdminUsecase) ApproveRequest(id int, verifier_id int) string {
+func (u *AdminUsecase) ReviewRequest(id int, verifier_id int) error {
+	return u.repo.ReviewRequest(id, verifier_id)
+}
+func (u *AdminUsecase) ApproveRequest(id int, verifier_id int) error {
 	return u.repo.ApproveRequest(id, verifier_id)
 }
-func (u *AdminUsecase) RejectRequest(id int, verifier_id int) string {
+func (u *AdminUsecase) RejectRequest(id int, verifier_id int) error {
 	return u.repo.RejectRequest(id, verifier_id)
 }
-func (u *AdminUsecase) AddRejectNotes(id int, notes string) string {
-	return u.repo.AddRejectNotes(id, notes)
+func (u *AdminUsecase) AddRejectNotes(id int, verifier_id int, notes string) error {
+	return u.repo.AddRejectNotes(id, verifier_id, notes)
+}
+func (u *AdminUsecase) GetRequestHistory(id int) (*dto.RequestHistoryResponse, string) {
+	events, msg := u.repo.GetRequestHistory(id)
+	if msg != "" {
+		return nil, msg
+	}
+	return toRequestHistory(id, events), ""
 }
 func (u *AdminUsecase) DeleteRequest(id int) string {
 	return u.repo.DeleteRequest(id)
 }
+
+// ListDeletedRequests returns the requests in the trash.
+func (u *AdminUsecase) ListDeletedRequests() ([]*dto.RequestResponse, error) {
+	requests, err := u.repo.ListDeletedRequests()
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*dto.RequestResponse, 0, len(requests))
+	for _, request := range requests {
+		resp = append(resp, toRequestResponse(request))
+	}
+	return resp, nil
+}
+func (u *AdminUsecase) RestoreRequest(id int) error {
+	return u.repo.RestoreRequest(id)
+}
+func (u *AdminUsecase) ListRequestMessages(id int) (*dto.RequestMessagesResponse, error) {
+	messages, err := u.repo.ListRequestMessages(id)
+	if err != nil {
+		return nil, err
+	}
+	return toRequestMessages(id, messages), nil
+}
+func (u *AdminUsecase) SendRequestMessage(id int, adminID int, request dto.SendRequestMessageRequest) (*dto.RequestMessageResponse, error) {
+	message, err := u.repo.SendRequestMessage(id, adminID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := toRequestMessage(message)
+	return &resp, nil
+}
+
+// BulkApproveRequests approves every selected request the way ApproveRequest
+// does, each one in its own transaction.
+func (u *AdminUsecase) BulkApproveRequests(adminID int, request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+	return u.bulk(request, func(id int) error {
+		return u.repo.ApproveRequest(id, adminID)
+	})
+}
+
+// BulkRejectRequests rejects every selected request the way RejectRequest
+// does, each one in its own transaction.
+func (u *AdminUsecase) BulkRejectRequests(adminID int, request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+	return u.bulk(request, func(id int) error {
+		return u.repo.RejectRequest(id, adminID)
+	})
+}
+
+// BulkDeleteRequests deletes every selected request.
+func (u *AdminUsecase) BulkDeleteRequests(request dto.BulkRequestRequest) (*dto.BulkRequestResponse, error) {
+	return u.bulk(request, func(id int) error {
+		if _, msg := u.repo.GetRequestByID(id); msg != "" {
+			return domain.ErrRequestNotFound
+		}
+		if msg := u.repo.DeleteRequest(id); msg != storage.MsgDeleteRequestSuccess {
+			return errors.New(msg)
+		}
+		return nil
+	})
+}
+
+// bulk runs action on every selected request and reports the outcome of each.
+// Requests that already left the state the action applies to are skipped,
+// any other error fails the item without stopping the batch.
+func (u *AdminUsecase) bulk(request dto.BulkRequestRequest, action func(id int) error) (*dto.BulkRequestResponse, error) {
+	ids, err := u.bulkSelection(request)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.BulkRequestResponse{Items: make([]dto.BulkRequestItem, 0, len(ids))}
+	for _, id := range ids {
+		item := dto.BulkRequestItem{ID: id, Result: dto.BulkResultSucceeded}
+		var transitionErr *domain.InvalidTransitionError
+		if err := action(id); errors.As(err, &transitionErr) {
+			item.Result, item.Error = dto.BulkResultSkipped, err.Error()
+		} else if err != nil {
+			item.Result, item.Error = dto.BulkResultFailed, err.Error()
+		}
+		switch item.Result {
+		case dto.BulkResultSucceeded:
+			resp.Succeeded++
+		case dto.BulkResultSkipped:
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+// bulkSelection returns the ids of the requests a bulk action applies to, in
+// the order given or lowest first for a filter.
+func (u *AdminUsecase) bulkSelection(request dto.BulkRequestRequest) ([]int, error) {
+	// exactly one of ids and a non-empty filter
+	if (len(request.IDs) == 0) == (request.Filter == nil || *request.Filter == (dto.BulkRequestFilter{})) {
+		return nil, domain.ErrBulkNoSelection
+	}
+	if request.Filter == nil {
+		ids := make([]int, 0, len(request.IDs))
+		seen := make(map[int]bool, len(request.IDs))
+		for _, id := range request.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	filter := request.Filter
+	query := dto.ListRequestQuery{
+		Type:       filter.Type,
+		Status:     filter.Status,
+		VerifierID: filter.VerifierID,
+		Email:      filter.Email,
+		Name:       filter.Name,
+	}
+	var err error
+	if query.CreatedFrom, err = parseDate(filter.CreatedFrom); err != nil {
+		return nil, err
+	}
+	if query.CreatedTo, err = parseDate(filter.CreatedTo); err != nil {
+		return nil, err
+	}
+	ids, err := u.repo.ListRequestIDs(query, maxBulkRequests+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > maxBulkRequests {
+		return nil, domain.ErrBulkTooLarge
+	}
+	return ids, nil
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func (u *AdminUsecase) MarkRequestViewed(id int, adminID int) error {
+	return u.repo.MarkRequestViewed(id, adminID)
+}
+func (u *AdminUsecase) ListRequestViews(id int) (*dto.RequestViewsResponse, error) {
+	views, err := u.repo.ListRequestViews(id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.RequestViewsResponse{RequestID: id, Views: make([]dto.RequestViewResponse, 0, len(views))}
+	for _, view := range views {
+		resp.Views = append(resp.Views, dto.RequestViewResponse{AdminID: view.AdminID, ViewedAt: view.ViewedAt})
+	}
+	return resp, nil
+}
+
+// ClaimRequest gives the request to adminID for the claim TTL, or extends
+// their claim.
+func (u *AdminUsecase) ClaimRequest(id int, adminID int) (*dto.RequestResponse, error) {
+	return u.AssignRequest(id, adminID, dto.AssignRequestRequest{ReviewerID: adminID})
+}
+
+// AssignRequest gives the request to another reviewer for the claim TTL.
+func (u *AdminUsecase) AssignRequest(id int, adminID int, request dto.AssignRequestRequest) (*dto.RequestResponse, error) {
+	claimed, err := u.repo.ClaimRequest(id, adminID, request.ReviewerID, time.Now().Add(u.claimTTL))
+	if err != nil {
+		return nil, err
+	}
+	return toRequestResponse(claimed), nil
+}
+func (u *AdminUsecase) ReleaseRequest(id int, adminID int) error {
+	return u.repo.ReleaseRequest(id, adminID)
+}
+func (u *AdminUsecase) CountUnreadMessages() (*dto.UnreadMessagesResponse, error) {
+	counts, err := u.repo.CountUnreadMessages()
+	if err != nil {
+		return nil, err
+	}
+	return toUnreadMessages(counts), nil
+}
+
+func toRequestResponse(request *domain.Request) *dto.RequestResponse {
+	resp := &dto.RequestResponse{
+		ID:                  request.ID,
+		UserID:              request.UserID,
+		Type:                request.Type,
+		Status:              request.Status,
+		RejectNotes:         request.RejectNotes,
+		VerifierID:          request.VerifierID,
+		PreviousRequestID:   request.PreviousRequestID,
+		PreviousRejectNotes: request.PreviousRejectNotes,
+		CreateAt:            request.CreatedAt,
+		UpdateAt:            request.UpdatedAt,
+	}
+	if request.DeletedAt.Valid {
+		resp.DeletedAt = &request.DeletedAt.Time
+	}
+	// expired claims are not shown
+	if _, ok := request.ClaimHolder(time.Now()); ok {
+		resp.ClaimedBy = request.ClaimedBy
+		resp.ClaimExpiresAt = request.ClaimExpiresAt
+	}
+	return resp
+}
+
+// toRequestHistory renders request events with their status names.
+func toRequestHistory(requestID int, events []*domain.RequestEvent) *dto.RequestHistoryResponse {
+	history := &dto.RequestHistoryResponse{
+		RequestID: requestID,
+		Events:    make([]dto.RequestEventResponse, 0, len(events)),
+	}
+	for _, event := range events {
+		item := dto.RequestEventResponse{
+			ID:       event.ID,
+			Action:   event.Action,
+			ToStatus: domain.RequestStatusName(event.ToStatus),
+			ActorID:  event.ActorID,
+			Notes:    event.Notes,
+			CreateAt: event.CreatedAt,
+		}
+		if event.FromStatus != nil {
+			item.FromStatus = domain.RequestStatusName(*event.FromStatus)
+		}
+		history.Events = append(history.Events, item)
+	}
+	return history
+}
+
+func toRequestMessage(message *domain.RequestMessage) dto.RequestMessageResponse {
+	return dto.RequestMessageResponse{
+		ID:        message.ID,
+		RequestID: message.RequestID,
+		SenderID:  message.SenderID,
+		FromAdmin: message.FromAdmin,
+		Body:      message.Body,
+		ReadAt:    message.ReadAt,
+		CreateAt:  message.CreatedAt,
+	}
+}
+
+func toRequestMessages(requestID int, messages []*domain.RequestMessage) *dto.RequestMessagesResponse {
+	thread := &dto.RequestMessagesResponse{
+		RequestID: requestID,
+		Messages:  make([]dto.RequestMessageResponse, 0, len(messages)),
+	}
+	for _, message := range messages {
+		thread.Messages = append(thread.Messages, toRequestMessage(message))
+	}
+	return thread
+}
+
+func toUnreadMessages(counts []domain.RequestUnreadCount) *dto.UnreadMessagesResponse {
+	unread := &dto.UnreadMessagesResponse{Requests: make([]dto.RequestUnreadCount, 0, len(counts))}
+	for _, count := range counts {
+		unread.Total += count.Unread
+		unread.Requests = append(unread.Requests, dto.RequestUnreadCount{RequestID: count.RequestID, Unread: count.Unread})
+	}
+	return unread
+}
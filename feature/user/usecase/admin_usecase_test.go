@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 )
 
 // Mocking the AdminRepositoryInterface
@@ -15,9 +16,12 @@ type MockAdminRepository struct {
 	mock.Mock
 }
 
-func (m *MockAdminRepository) GetListPendingRequest() ([]*domain.Request, string) {
-	args := m.Called()
-	return args.Get(0).([]*domain.Request), args.String(1)
+func (m *MockAdminRepository) ListRequests(query dto.ListRequestQuery) ([]*domain.Request, int64, string) {
+	args := m.Called(query)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.Request), args.Get(1).(int64), args.String(2)
+	}
+	return nil, args.Get(1).(int64), args.String(2)
 }
 
 func (m *MockAdminRepository) GetPendingRequestByID(id int) (*domain.Request, string) {
@@ -25,29 +29,34 @@ func (m *MockAdminRepository) GetPendingRequestByID(id int) (*domain.Request, st
 	return args.Get(0).(*domain.Request), args.String(1)
 }
 
-func (m *MockAdminRepository) GetListAllRequest() ([]*domain.Request, string) {
-	args := m.Called()
-	return args.Get(0).([]*domain.Request), args.String(1)
-}
-
 func (m *MockAdminRepository) GetRequestByID(id int) (*domain.Request, string) {
 	args := m.Called(id)
 	return args.Get(0).(*domain.Request), args.String(1)
 }
 
-func (m *MockAdminRepository) ApproveRequest(id int, verifier_id int) string {
+func (m *MockAdminRepository) ReviewRequest(id int, verifier_id int) error {
 	args := m.Called(id, verifier_id)
-	return args.String(0)
+	return args.Error(0)
 }
 
-func (m *MockAdminRepository) RejectRequest(id int, verifier_id int) string {
+func (m *MockAdminRepository) ApproveRequest(id int, verifier_id int) error {
 	args := m.Called(id, verifier_id)
-	return args.String(0)
+	return args.Error(0)
 }
 
-func (m *MockAdminRepository) AddRejectNotes(id int, notes string) string {
-	args := m.Called(id, notes)
-	return args.String(0)
+func (m *MockAdminRepository) RejectRequest(id int, verifier_id int) error {
+	args := m.Called(id, verifier_id)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepository) AddRejectNotes(id int, verifier_id int, notes string) error {
+	args := m.Called(id, verifier_id, notes)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepository) GetRequestHistory(id int) ([]*domain.RequestEvent, string) {
+	args := m.Called(id)
+	return args.Get(0).([]*domain.RequestEvent), args.String(1)
 }
 
 func (m *MockAdminRepository) DeleteRequest(id int) string {
@@ -55,20 +64,97 @@ func (m *MockAdminRepository) DeleteRequest(id int) string {
 	return args.String(0)
 }
 
-func TestGetListPendingRequest(t *testing.T) {
+func (m *MockAdminRepository) ListDeletedRequests() ([]*domain.Request, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Request), args.Error(1)
+}
+
+func (m *MockAdminRepository) RestoreRequest(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepository) ListRequestIDs(query dto.ListRequestQuery, limit int) ([]int, error) {
+	args := m.Called(query, limit)
+	if args.Get(0) != nil {
+		return args.Get(0).([]int), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) ListRequestMessages(id int) ([]*domain.RequestMessage, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.RequestMessage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) SendRequestMessage(id int, adminID int, body string) (*domain.RequestMessage, error) {
+	args := m.Called(id, adminID, body)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.RequestMessage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) CountUnreadMessages() ([]domain.RequestUnreadCount, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]domain.RequestUnreadCount), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) MarkRequestViewed(id int, adminID int) error {
+	args := m.Called(id, adminID)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepository) ListRequestViews(id int) ([]*domain.RequestView, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.RequestView), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) ClaimRequest(id int, adminID int, reviewerID int, expiresAt time.Time) (*domain.Request, error) {
+	args := m.Called(id, adminID, reviewerID, expiresAt)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Request), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) ReleaseRequest(id int, adminID int) error {
+	args := m.Called(id, adminID)
+	return args.Error(0)
+}
+
+func TestListRequests(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
-	mockRepo.On("GetListPendingRequest").Return(nil, "No request found")
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
 
-	result, msg := usecase.GetListPendingRequest()
-	assert.Nil(t, result)
-	assert.Equal(t, "No request found", msg)
+	requests := []*domain.Request{{ID: 1}, {ID: 2}}
+	mockRepo.On("ListRequests", dto.ListRequestQuery{Page: 1, PageSize: 20, Type: "registration"}).Return(requests, int64(45), "")
+
+	result, msg := usecase.ListRequests(dto.ListRequestQuery{Type: "registration"})
+	assert.Empty(t, msg)
+	if assert.Len(t, result.Requests, 2) {
+		assert.Equal(t, 1, result.Requests[0].ID)
+		assert.Equal(t, 2, result.Requests[1].ID)
+	}
+	assert.Equal(t, 1, result.Page)
+	assert.Equal(t, 20, result.PageSize)
+	assert.Equal(t, int64(45), result.Total)
+	assert.Equal(t, 3, result.TotalPages)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetPendingRequestById(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
 
 	mockRequest := &domain.Request{
 		ID:          1,
@@ -97,40 +183,42 @@ func TestGetPendingRequestById(t *testing.T) {
 
 func TestApproveRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
 
-	mockRepo.On("ApproveRequest", 1, 456).Return("Request approved")
+	mockRepo.On("ApproveRequest", 1, 456).Return(nil)
 
-	msg := usecase.ApproveRequest(1, 456)
-	assert.Equal(t, "Request approved", msg)
+	err := usecase.ApproveRequest(1, 456)
+	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestRejectRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
 
-	mockRepo.On("RejectRequest", 1, 456).Return("Request rejected")
+	transitionErr := &domain.InvalidTransitionError{From: domain.RequestStatusApproved, To: domain.RequestStatusRejected}
+	mockRepo.On("RejectRequest", 1, 456).Return(transitionErr)
 
-	msg := usecase.RejectRequest(1, 456)
-	assert.Equal(t, "Request rejected", msg)
+	err := usecase.RejectRequest(1, 456)
+	assert.ErrorIs(t, err, transitionErr)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestAddRejectNotes(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
 
-	mockRepo.On("AddRejectNotes", 1, "Some notes").Return("Reject notes added")
+	mockRepo.On("AddRejectNotes", 1, 456, "Some notes").Return(nil)
+	mockRepo.On("AddRejectNotes", 2, 456, "Some notes").Return(domain.ErrNotesClosed)
 
-	msg := usecase.AddRejectNotes(1, "Some notes")
-	assert.Equal(t, "Reject notes added", msg)
+	assert.NoError(t, usecase.AddRejectNotes(1, 456, "Some notes"))
+	assert.ErrorIs(t, usecase.AddRejectNotes(2, 456, "Some notes"), domain.ErrNotesClosed)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestDeleteRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
 
 	mockRepo.On("DeleteRequest", 1).Return("Request deleted")
 
@@ -138,3 +226,145 @@ func TestDeleteRequest(t *testing.T) {
 	assert.Equal(t, "Request deleted", msg)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGetRequestHistory(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	actorID := 456
+	pending, rejected := domain.RequestStatusPending, domain.RequestStatusRejected
+	events := []*domain.RequestEvent{
+		{ID: 1, RequestID: 1, ActorID: &actorID, Action: domain.RequestEventReject, FromStatus: &pending, ToStatus: rejected},
+		{ID: 2, RequestID: 1, ActorID: &actorID, Action: domain.RequestEventNote, FromStatus: &rejected, ToStatus: rejected, Notes: "missing passport"},
+	}
+	mockRepo.On("GetRequestHistory", 1).Return(events, "")
+
+	result, msg := usecase.GetRequestHistory(1)
+	assert.Empty(t, msg)
+	assert.Equal(t, 1, result.RequestID)
+	assert.Len(t, result.Events, 2)
+	assert.Equal(t, "pending", result.Events[0].FromStatus)
+	assert.Equal(t, "rejected", result.Events[0].ToStatus)
+	assert.Equal(t, "missing passport", result.Events[1].Notes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRequestMessages(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	mockRepo.On("SendRequestMessage", 1, 456, "Which passport?").Return(&domain.RequestMessage{ID: 3, RequestID: 1, SenderID: 456, FromAdmin: true, Body: "Which passport?"}, nil)
+	mockRepo.On("CountUnreadMessages").Return([]domain.RequestUnreadCount{{RequestID: 1, Unread: 2}, {RequestID: 4, Unread: 1}}, nil)
+
+	message, err := usecase.SendRequestMessage(1, 456, dto.SendRequestMessageRequest{Body: "Which passport?"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, message.ID)
+	assert.True(t, message.FromAdmin)
+
+	unread, err := usecase.CountUnreadMessages()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), unread.Total)
+	assert.Len(t, unread.Requests, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestClaimRequest(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	reviewer := 456
+	expiresAt := time.Now().Add(30 * time.Minute)
+	expiresAround := mock.MatchedBy(func(at time.Time) bool {
+		return at.Sub(time.Now()) > 29*time.Minute && at.Sub(time.Now()) <= 30*time.Minute
+	})
+	mockRepo.On("ClaimRequest", 1, 456, 456, expiresAround).Return(&domain.Request{ID: 1, ClaimedBy: &reviewer, ClaimExpiresAt: &expiresAt}, nil)
+	mockRepo.On("ClaimRequest", 2, 456, 789, expiresAround).Return(nil, &domain.RequestClaimedError{ClaimedBy: 111, ExpiresAt: expiresAt})
+
+	result, err := usecase.ClaimRequest(1, 456)
+	assert.NoError(t, err)
+	assert.Equal(t, &reviewer, result.ClaimedBy)
+
+	_, err = usecase.AssignRequest(2, 456, dto.AssignRequestRequest{ReviewerID: 789})
+	var claimedErr *domain.RequestClaimedError
+	assert.ErrorAs(t, err, &claimedErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestToRequestResponse_HidesExpiredClaim(t *testing.T) {
+	reviewer := 456
+	expired := time.Now().Add(-time.Minute)
+
+	resp := toRequestResponse(&domain.Request{ID: 1, ClaimedBy: &reviewer, ClaimExpiresAt: &expired})
+	assert.Nil(t, resp.ClaimedBy)
+	assert.Nil(t, resp.ClaimExpiresAt)
+}
+
+func TestBulkApproveRequests(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	mockRepo.On("ApproveRequest", 1, 456).Return(nil)
+	mockRepo.On("ApproveRequest", 2, 456).Return(&domain.InvalidTransitionError{From: domain.RequestStatusApproved, To: domain.RequestStatusApproved})
+	mockRepo.On("ApproveRequest", 3, 456).Return(domain.ErrDepartmentRequired)
+
+	// duplicates are processed once
+	result, err := usecase.BulkApproveRequests(456, dto.BulkRequestRequest{IDs: []int{1, 2, 3, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []dto.BulkRequestItem{
+		{ID: 1, Result: dto.BulkResultSucceeded},
+		{ID: 2, Result: dto.BulkResultSkipped, Error: "request cannot move from approved to approved"},
+		{ID: 3, Result: dto.BulkResultFailed, Error: domain.ErrDepartmentRequired.Error()},
+	}, result.Items)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBulkRejectRequests_Filter(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	pending := domain.RequestStatusPending
+	from := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("ListRequestIDs", dto.ListRequestQuery{Type: "registration", Status: &pending, CreatedFrom: &from}, 501).Return([]int{4, 5}, nil)
+	mockRepo.On("RejectRequest", 4, 456).Return(nil)
+	mockRepo.On("RejectRequest", 5, 456).Return(nil)
+
+	result, err := usecase.BulkRejectRequests(456, dto.BulkRequestRequest{Filter: &dto.BulkRequestFilter{Type: "registration", Status: &pending, CreatedFrom: "2024-05-10"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Succeeded)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBulkSelection(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	_, err := usecase.BulkDeleteRequests(dto.BulkRequestRequest{})
+	assert.ErrorIs(t, err, domain.ErrBulkNoSelection)
+	_, err = usecase.BulkDeleteRequests(dto.BulkRequestRequest{Filter: &dto.BulkRequestFilter{}})
+	assert.ErrorIs(t, err, domain.ErrBulkNoSelection)
+	_, err = usecase.BulkDeleteRequests(dto.BulkRequestRequest{IDs: []int{1}, Filter: &dto.BulkRequestFilter{Type: "registration"}})
+	assert.ErrorIs(t, err, domain.ErrBulkNoSelection)
+
+	mockRepo.On("ListRequestIDs", dto.ListRequestQuery{Type: "verification"}, 501).Return(make([]int, 501), nil)
+	_, err = usecase.BulkDeleteRequests(dto.BulkRequestRequest{Filter: &dto.BulkRequestFilter{Type: "verification"}})
+	assert.ErrorIs(t, err, domain.ErrBulkTooLarge)
+}
+
+func TestBulkDeleteRequests(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, 30*time.Minute)
+
+	mockRepo.On("GetRequestByID", 1).Return(&domain.Request{ID: 1}, "")
+	mockRepo.On("DeleteRequest", 1).Return("Delete request success")
+	mockRepo.On("GetRequestByID", 2).Return((*domain.Request)(nil), "record not found")
+
+	result, err := usecase.BulkDeleteRequests(dto.BulkRequestRequest{IDs: []int{1, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, domain.ErrRequestNotFound.Error(), result.Items[1].Error)
+	mockRepo.AssertExpectations(t)
+}
@@ -8,6 +8,15 @@ import (
 
 type ApplicantRequestUsecaseInterface interface {
 	CreateApplicantRequest(request dto.ApplicantRequestCreatingDTO) error
+	CreateMyRequest(userID int, request dto.MyRequestCreatingDTO) error
+	ListMyRequests(userID int) ([]*dto.RequestResponse, error)
+	GetMyRequest(userID int, requestID int) (*dto.RequestResponse, error)
+	GetRequestHistory(userID int, requestID int) (*dto.RequestHistoryResponse, error)
+	CancelMyRequest(userID int, requestID int) error
+	ResubmitMyRequest(userID int, requestID int) (*dto.RequestResponse, error)
+	ListMyRequestMessages(userID int, requestID int) (*dto.RequestMessagesResponse, error)
+	SendMyRequestMessage(userID int, requestID int, request dto.SendRequestMessageRequest) (*dto.RequestMessageResponse, error)
+	CountMyUnreadMessages(userID int) (*dto.UnreadMessagesResponse, error)
 }
 
 type ApplicantRequestUsecase struct {
@@ -22,7 +31,89 @@ func (u *ApplicantRequestUsecase) CreateApplicantRequest(request dto.ApplicantRe
 	req := &domain.ApplicantRequestDomain{
 		UserID: request.UserID,
 		Type:   request.Type,
-		Status: request.Status,
+		Status: domain.RequestStatusPending,
 	}
 	return u.RequestRepo.CreateApplicantRequest(req)
 }
+
+// CreateMyRequest submits a pending request on behalf of the authenticated user.
+func (u *ApplicantRequestUsecase) CreateMyRequest(userID int, request dto.MyRequestCreatingDTO) error {
+	return u.RequestRepo.CreateApplicantRequest(&domain.ApplicantRequestDomain{
+		UserID: userID,
+		Type:   request.Type,
+		Status: domain.RequestStatusPending,
+	})
+}
+
+func (u *ApplicantRequestUsecase) ListMyRequests(userID int) ([]*dto.RequestResponse, error) {
+	requests, err := u.RequestRepo.ListRequestsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*dto.RequestResponse, 0, len(requests))
+	for _, request := range requests {
+		response = append(response, toRequestResponse(request))
+	}
+	return response, nil
+}
+
+func (u *ApplicantRequestUsecase) GetMyRequest(userID int, requestID int) (*dto.RequestResponse, error) {
+	request, err := u.RequestRepo.GetRequestByUser(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return toRequestResponse(request), nil
+}
+
+func (u *ApplicantRequestUsecase) GetRequestHistory(userID int, requestID int) (*dto.RequestHistoryResponse, error) {
+	events, err := u.RequestRepo.GetRequestHistory(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return toRequestHistory(requestID, events), nil
+}
+
+// CancelMyRequest withdraws a request of the authenticated user that has not
+// been approved yet.
+func (u *ApplicantRequestUsecase) CancelMyRequest(userID int, requestID int) error {
+	return u.RequestRepo.CancelRequest(userID, requestID)
+}
+
+// ResubmitMyRequest puts a rejected request of the authenticated user back in
+// the pending queue as a new request linked to the rejected one.
+func (u *ApplicantRequestUsecase) ResubmitMyRequest(userID int, requestID int) (*dto.RequestResponse, error) {
+	request, err := u.RequestRepo.ResubmitRequest(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return toRequestResponse(request), nil
+}
+
+// ListMyRequestMessages returns the conversation with the admins about a
+// request of the authenticated user.
+func (u *ApplicantRequestUsecase) ListMyRequestMessages(userID int, requestID int) (*dto.RequestMessagesResponse, error) {
+	messages, err := u.RequestRepo.ListRequestMessages(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return toRequestMessages(requestID, messages), nil
+}
+
+// SendMyRequestMessage replies to the admins about a request of the
+// authenticated user.
+func (u *ApplicantRequestUsecase) SendMyRequestMessage(userID int, requestID int, request dto.SendRequestMessageRequest) (*dto.RequestMessageResponse, error) {
+	message, err := u.RequestRepo.SendRequestMessage(userID, requestID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := toRequestMessage(message)
+	return &resp, nil
+}
+
+func (u *ApplicantRequestUsecase) CountMyUnreadMessages(userID int) (*dto.UnreadMessagesResponse, error) {
+	counts, err := u.RequestRepo.CountUnreadMessages(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toUnreadMessages(counts), nil
+}
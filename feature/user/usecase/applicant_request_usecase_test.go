@@ -18,6 +18,67 @@ func (m *mockApplicantRequestRepository) CreateApplicantRequest(appliRequest *do
 	return args.Error(0)
 }
 
+func (m *mockApplicantRequestRepository) ListRequestsByUser(userID int) ([]*domain.Request, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.Request), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApplicantRequestRepository) GetRequestByUser(userID int, requestID int) (*domain.Request, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Request), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApplicantRequestRepository) GetRequestHistory(userID int, requestID int) ([]*domain.RequestEvent, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.RequestEvent), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApplicantRequestRepository) CancelRequest(userID int, requestID int) error {
+	args := m.Called(userID, requestID)
+	return args.Error(0)
+}
+
+func (m *mockApplicantRequestRepository) ResubmitRequest(userID int, requestID int) (*domain.Request, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Request), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApplicantRequestRepository) ListRequestMessages(userID int, requestID int) ([]*domain.RequestMessage, error) {
+	args := m.Called(userID, requestID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.RequestMessage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApplicantRequestRepository) SendRequestMessage(userID int, requestID int, body string) (*domain.RequestMessage, error) {
+	args := m.Called(userID, requestID, body)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.RequestMessage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockApplicantRequestRepository) CountUnreadMessages(userID int) ([]domain.RequestUnreadCount, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]domain.RequestUnreadCount), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func TestCreateApplicantRequest(t *testing.T) {
 	mockRepo := new(mockApplicantRequestRepository)
 	usecase := NewApplicantRequestUsecase(mockRepo)
@@ -25,7 +86,6 @@ func TestCreateApplicantRequest(t *testing.T) {
 	input := dto.ApplicantRequestCreatingDTO{
 		UserID: 1,
 		Type:   "application",
-		Status: 0,
 	}
 
 	mockRepo.On("CreateApplicantRequest", mock.Anything).Return(nil)
@@ -35,3 +95,89 @@ func TestCreateApplicantRequest(t *testing.T) {
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestApplicantGetRequestHistory(t *testing.T) {
+	mockRepo := new(mockApplicantRequestRepository)
+	usecase := NewApplicantRequestUsecase(mockRepo)
+
+	mockRepo.On("GetRequestHistory", 1, 2).Return(nil, domain.ErrRequestNotFound)
+
+	result, err := usecase.GetRequestHistory(1, 2)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateMyRequest(t *testing.T) {
+	mockRepo := new(mockApplicantRequestRepository)
+	usecase := NewApplicantRequestUsecase(mockRepo)
+
+	expected := &domain.ApplicantRequestDomain{UserID: 5, Type: "registration", Status: domain.RequestStatusPending}
+	mockRepo.On("CreateApplicantRequest", expected).Return(nil)
+
+	err := usecase.CreateMyRequest(5, dto.MyRequestCreatingDTO{Type: "registration"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListMyRequests(t *testing.T) {
+	mockRepo := new(mockApplicantRequestRepository)
+	usecase := NewApplicantRequestUsecase(mockRepo)
+
+	mockRepo.On("ListRequestsByUser", 5).Return([]*domain.Request{{ID: 2, UserID: 5}, {ID: 1, UserID: 5}}, nil)
+
+	result, err := usecase.ListMyRequests(5)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, 2, result[0].ID)
+}
+
+func TestResubmitMyRequest(t *testing.T) {
+	mockRepo := new(mockApplicantRequestRepository)
+	usecase := NewApplicantRequestUsecase(mockRepo)
+
+	previousID := 1
+	mockRepo.On("ResubmitRequest", 5, 1).Return(&domain.Request{
+		ID:                  2,
+		UserID:              5,
+		Status:              domain.RequestStatusPending,
+		PreviousRequestID:   &previousID,
+		PreviousRejectNotes: "missing document",
+	}, nil)
+	mockRepo.On("ResubmitRequest", 5, 3).Return(nil, &domain.InvalidTransitionError{From: domain.RequestStatusPending, To: domain.RequestStatusResubmitted})
+
+	result, err := usecase.ResubmitMyRequest(5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ID)
+	assert.Equal(t, &previousID, result.PreviousRequestID)
+	assert.Equal(t, "missing document", result.PreviousRejectNotes)
+
+	result, err = usecase.ResubmitMyRequest(5, 3)
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListMyRequestMessages(t *testing.T) {
+	mockRepo := new(mockApplicantRequestRepository)
+	usecase := NewApplicantRequestUsecase(mockRepo)
+
+	mockRepo.On("ListRequestMessages", 5, 1).Return([]*domain.RequestMessage{
+		{ID: 1, RequestID: 1, SenderID: 7, FromAdmin: true, Body: "Which passport?"},
+		{ID: 2, RequestID: 1, SenderID: 5, Body: "The new one"},
+	}, nil)
+	mockRepo.On("ListRequestMessages", 5, 2).Return(nil, domain.ErrRequestNotFound)
+
+	result, err := usecase.ListMyRequestMessages(5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.RequestID)
+	assert.Len(t, result.Messages, 2)
+	assert.Equal(t, "The new one", result.Messages[1].Body)
+
+	_, err = usecase.ListMyRequestMessages(5, 2)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -12,7 +12,10 @@ type ApplicantUsecaseInterface interface {
 	CreateApplicant(request dto.ApplicantCreateDTO) error
 	UpdateApplicant(id int, request dto.ApplicantUpdateDTO) error
 	DeleteApplicant(id int) error
+	ListDeletedApplicants() ([]*dto.ApplicantResponseDTO, error)
+	RestoreApplicant(id int) error
 	FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error)
+	UpdateApplicantProfile(id int, request dto.ApplicantProfileUpdateDTO) error
 }
 
 type ApplicantUsecase struct {
@@ -66,7 +69,27 @@ func (u *ApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO,
 	if err != nil {
 		return nil, err
 	}
+	return toApplicantResponse(user), nil
+}
+
+// ListDeletedApplicants returns the applicants in the trash.
+func (u *ApplicantUsecase) ListDeletedApplicants() ([]*dto.ApplicantResponseDTO, error) {
+	users, err := u.ApplicantRepo.ListDeletedApplicants()
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*dto.ApplicantResponseDTO, 0, len(users))
+	for _, user := range users {
+		response = append(response, toApplicantResponse(user))
+	}
+	return response, nil
+}
+
+func (u *ApplicantUsecase) RestoreApplicant(id int) error {
+	return u.ApplicantRepo.RestoreApplicant(id)
+}
 
+func toApplicantResponse(user *domain.ApplicantDomain) *dto.ApplicantResponseDTO {
 	response := &dto.ApplicantResponseDTO{
 		ID:                user.ID,
 		Email:             user.Email,
@@ -80,5 +103,32 @@ func (u *ApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO,
 		ResidentCountryID: user.ResidentCountryID,
 		DepartmentID:      user.DepartmentID,
 	}
-	return response, nil
+	if user.DeletedAt.Valid {
+		response.DeletedAt = &user.DeletedAt.Time
+	}
+	return response
+}
+
+// UpdateApplicantProfile updates the profile of the authenticated applicant.
+func (u *ApplicantUsecase) UpdateApplicantProfile(id int, request dto.ApplicantProfileUpdateDTO) error {
+	user, err := u.ApplicantRepo.FindApplicantByID(id)
+	if err != nil {
+		return err
+	}
+	if request.DOB != "" {
+		dob, err := time.Parse("2006-01-02", request.DOB)
+		if err != nil {
+			return err
+		}
+		user.DOB = dob
+	}
+
+	user.Name = request.Name
+	user.Surname = request.Surname
+	user.Gender = request.Gender
+	user.Mobile = request.Mobile
+	user.CountryID = request.CountryID
+	user.ResidentCountryID = request.ResidentCountryID
+
+	return u.ApplicantRepo.UpdateApplicantProfile(user)
 }
@@ -30,11 +30,29 @@ func (m *MockApplicantRepository) DeleteApplicant(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockApplicantRepository) ListDeletedApplicants() ([]*domain.ApplicantDomain, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.ApplicantDomain), args.Error(1)
+}
+
+func (m *MockApplicantRepository) RestoreApplicant(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *MockApplicantRepository) FindApplicantByID(id int) (*domain.ApplicantDomain, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*domain.ApplicantDomain), args.Error(1)
 }
 
+func (m *MockApplicantRepository) UpdateApplicantProfile(applicant *domain.ApplicantDomain) error {
+	args := m.Called(applicant)
+	return args.Error(0)
+}
+
 func TestCreateApplicant(t *testing.T) {
 	mockRepo := new(MockApplicantRepository)
 	usecase := NewApplicantUsecase(mockRepo)
@@ -64,7 +82,7 @@ func TestUpdateApplicant(t *testing.T) {
 		Name:              "Tony",
 		Surname:           "Quang",
 		Gender:            "Male",
-		DOB:               "time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC)",
+		DOB:               "2002-09-20",
 		Mobile:            "0913895987",
 		CountryID:         2,
 		ResidentCountryID: 7,
@@ -159,3 +177,21 @@ func TestFindApplicantByID_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdateApplicantProfile(t *testing.T) {
+	mockRepo := new(MockApplicantRepository)
+	usecase := NewApplicantUsecase(mockRepo)
+
+	existing := &domain.ApplicantDomain{ID: 7, RoleID: 2, Email: "me@example.com", Name: "Old"}
+	mockRepo.On("FindApplicantByID", 7).Return(existing, nil)
+	mockRepo.On("UpdateApplicantProfile", mock.AnythingOfType("*domain.ApplicantDomain")).Return(nil)
+
+	err := usecase.UpdateApplicantProfile(7, dto.ApplicantProfileUpdateDTO{Name: "Johnny", Surname: "Hoang", DOB: "2002-09-20"})
+	assert.NoError(t, err)
+
+	updated := mockRepo.Calls[1].Arguments.Get(0).(*domain.ApplicantDomain)
+	assert.Equal(t, "Johnny", updated.Name)
+	assert.Equal(t, time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC), updated.DOB)
+	assert.Equal(t, 2, updated.RoleID)
+	assert.Equal(t, "me@example.com", updated.Email)
+}
@@ -21,7 +21,7 @@ func (u *VolunteerRequestUsecase) CreateVolunteerRequest(request dto.VoluteerReq
 	req := &domain.VolunteerRequest{
 		UserID: request.UserID,
 		Type:   request.Type,
-		Status: request.Status,
+		Status: domain.RequestStatusPending,
 	}
 	return u.VolRequestRepo.CreateVolunteerRequest(req)
 }
@@ -25,10 +25,11 @@ func TestCreateVolunteerRequest(t *testing.T)  {
 	input := dto.VoluteerRequestCreatingDTO{
 		UserID: 1,
 		Type:   "verification",
-		Status: 0,
 	}
 
-	mockRepo.On("CreateApplicantRequest", mock.Anything).Return(nil)
+	mockRepo.On("CreateVolunteerRequest", mock.MatchedBy(func(req *domain.VolunteerRequest) bool {
+		return req.Status == domain.RequestStatusPending
+	})).Return(nil)
 
 	err := usecase.CreateVolunteerRequest(input)
 
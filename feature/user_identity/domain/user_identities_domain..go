@@ -1,6 +1,13 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrUserIdentityNotFound is returned when an identity does not exist or
+// belongs to another user.
+var ErrUserIdentityNotFound = errors.New("user identity not found")
 
 type UserIdentity struct {
 	ID          int       `gorm:"primaryKey"`
@@ -27,3 +27,13 @@ type UserIdentityResponse struct {
 	ExpiryDate  string `json:"expiry_date"`
 	PlaceIssued string `json:"place_issued"`
 }
+
+// MyUserIdentityRequest creates or updates an identity of the authenticated
+// user, the owner is taken from the token.
+type MyUserIdentityRequest struct {
+	Number      string `json:"number" binding:"required"`
+	Type        string `json:"type" binding:"required"`
+	Status      int    `json:"status" binding:"omitempty,oneof=0 1"`
+	ExpiryDate  string `json:"expiry_date" binding:"required"`
+	PlaceIssued string `json:"place_issued" binding:"required"`
+}
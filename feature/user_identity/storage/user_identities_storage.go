@@ -9,6 +9,7 @@ type UserIndentityRepositoryInterface interface {
 	CreateUserIdentity(identity *domain.UserIdentity) error
 	UpdateUserIdentity(identity *domain.UserIdentity) error
 	FindUserIdentityByID(id int) (*domain.UserIdentity, error)
+	FindUserIdentitiesByUserID(userID int) ([]*domain.UserIdentity, error)
 }
 
 type UserIdentityRepository struct {
@@ -34,3 +35,11 @@ func (r *UserIdentityRepository) FindUserIdentityByID(id int) (*domain.UserIdent
 	}
 	return &identity, nil
 }
+
+func (r *UserIdentityRepository) FindUserIdentitiesByUserID(userID int) ([]*domain.UserIdentity, error) {
+	identities := make([]*domain.UserIdentity, 0)
+	if err := r.DB.Where("user_id = ?", userID).Order("id").Find(&identities).Error; err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
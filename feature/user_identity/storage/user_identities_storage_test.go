@@ -4,37 +4,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
-type MockDB struct {
-	mock.Mock
-	gorm.DB
+func setupUserIdentityDB(t *testing.T) *gorm.DB {
+	return testdb.Open(t, &domain.UserIdentity{})
 }
 
-func (m *MockDB) Create(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Save(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) First(dest interface{}, conds ...interface{}) *gorm.DB {
-	args := m.Called(dest, conds)
-	return args.Get(0).(*gorm.DB)
-}
-
-func TestCreateUserIdentity(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
-
-	userIdentity := &domain.UserIdentity{
+func newUserIdentity() *domain.UserIdentity {
+	return &domain.UserIdentity{
 		ID:          1,
 		UserID:      2,
 		Number:      "123456789",
@@ -43,69 +24,53 @@ func TestCreateUserIdentity(t *testing.T) {
 		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
 		PlaceIssued: "Some city",
 	}
-	mockDB.On("Create", userIdentity).Return(&gorm.DB{Error: nil})
+}
+
+func TestCreateUserIdentity(t *testing.T) {
+	db := setupUserIdentityDB(t)
+	repo := NewUserIdentityRepository(db)
 
-	err := repo.CreateUserIdentity(userIdentity)
+	err := repo.CreateUserIdentity(newUserIdentity())
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	var stored domain.UserIdentity
+	assert.NoError(t, db.First(&stored, 1).Error)
+	assert.Equal(t, "123456789", stored.Number)
 }
 
 func TestUpdateUserIdentity(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
-
-	userIdentity := &domain.UserIdentity{
-		ID:          1,
-		UserID:      2,
-		Number:      "123456987",
-		Type:        "Citizen ID",
-		Status:      0,
-		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
-		PlaceIssued: "Some city",
-	}
-	mockDB.On("Save", userIdentity).Return(&gorm.DB{Error: nil})
+	db := setupUserIdentityDB(t)
+	repo := NewUserIdentityRepository(db)
+	userIdentity := newUserIdentity()
+	assert.NoError(t, repo.CreateUserIdentity(userIdentity))
 
+	userIdentity.Number = "123456987"
 	err := repo.UpdateUserIdentity(userIdentity)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	var stored domain.UserIdentity
+	assert.NoError(t, db.First(&stored, 1).Error)
+	assert.Equal(t, "123456987", stored.Number)
 }
 
 func TestFindUserIdentityByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
-
-	userIdentity := &domain.UserIdentity{
-		ID:          1,
-		UserID:      2,
-		Number:      "123456987",
-		Type:        "Citizen ID",
-		Status:      0,
-		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
-		PlaceIssued: "Some city",
-	}
-	mockDB.On("First", &userIdentity, 1).Return(&gorm.DB{Error: nil}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(**domain.UserIdentity)
-		*arg = userIdentity
-	})
+	repo := NewUserIdentityRepository(setupUserIdentityDB(t))
+	userIdentity := newUserIdentity()
+	assert.NoError(t, repo.CreateUserIdentity(userIdentity))
 
 	result, err := repo.FindUserIdentityByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, userIdentity, result)
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, userIdentity.UserID, result.UserID)
+	assert.Equal(t, userIdentity.Number, result.Number)
+	assert.True(t, userIdentity.ExpiryDate.Equal(result.ExpiryDate))
 }
 
 func TestFindUserIdentityByID_NotFound(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
-
-	mockDB.On("First", mock.Anything, 1).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	repo := NewUserIdentityRepository(setupUserIdentityDB(t))
 
 	result, err := repo.FindUserIdentityByID(1)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	mockDB.AssertExpectations(t)
 }
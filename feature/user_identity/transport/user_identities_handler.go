@@ -1,9 +1,11 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/usecase"
 	"github.com/gin-gonic/gin"
@@ -77,6 +79,7 @@ func (h *UserIdentityHandler) UpdateUserIdentity(c *gin.Context) {
 // @Tags user_identity
 // @Param id path int true "Identity ID"
 // @Success 200 {object} dto.UserIdentityResponse
+// @Failure 404 {object} map[string]string
 // @Router /api/v1/applicant-identity/{id} [get]
 func (h *UserIdentityHandler) FindUserIdentity(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -86,10 +89,147 @@ func (h *UserIdentityHandler) FindUserIdentity(c *gin.Context) {
 	}
 
 	identity, err := h.UserIdentityUsecase.FindUserIdentityByID(id)
+	if err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// ListMyUserIdentities godoc
+// @Summary List my identities
+// @Description List the identity documents of the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Success 200 {array} dto.UserIdentityResponse
+// @Router /api/v1/me/identities [get]
+func (h *UserIdentityHandler) ListMyUserIdentities(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	identities, err := h.UserIdentityUsecase.ListMyUserIdentities(userId)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	c.JSON(http.StatusOK, identities)
+}
+
+// FindMyUserIdentity godoc
+// @Summary Find my identity
+// @Description Find an identity document of the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Identity ID"
+// @Success 200 {object} dto.UserIdentityResponse
+// @Router /api/v1/me/identities/{id} [get]
+func (h *UserIdentityHandler) FindMyUserIdentity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	identity, err := h.UserIdentityUsecase.FindMyUserIdentity(userId, id)
+	if err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, identity)
 }
+
+// CreateMyUserIdentity godoc
+// @Summary Create my identity
+// @Description Add an identity document to the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param request body dto.MyUserIdentityRequest true "Create User Identity Request"
+// @Success 201 {string} message "User identity created successfully"
+// @Router /api/v1/me/identities [post]
+func (h *UserIdentityHandler) CreateMyUserIdentity(c *gin.Context) {
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request dto.MyUserIdentityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.UserIdentityUsecase.CreateMyUserIdentity(userId, request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "User identity created successfully"})
+}
+
+// UpdateMyUserIdentity godoc
+// @Summary Update my identity
+// @Description Update an identity document of the authenticated user
+// @Produce json
+// @Tags me
+// @Security bearerToken
+// @Param id path int true "Identity ID"
+// @Param request body dto.MyUserIdentityRequest true "Update User Identity Request"
+// @Success 200 {string} message "User identity updated successfully"
+// @Router /api/v1/me/identities/{id} [put]
+func (h *UserIdentityHandler) UpdateMyUserIdentity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity ID"})
+		return
+	}
+	userId, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request dto.MyUserIdentityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.UserIdentityUsecase.UpdateMyUserIdentity(userId, id, request); err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User identity updated successfully"})
+}
+
+// currentUserID returns the id of the authenticated user set by AuthMiddleware.
+func currentUserID(c *gin.Context) (int, bool) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		return 0, false
+	}
+	id, ok := userId.(int)
+	return id, ok
+}
+
+func identityErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrUserIdentityNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
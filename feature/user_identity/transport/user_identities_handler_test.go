@@ -1,12 +1,12 @@
 package transport
 
 import (
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -29,9 +29,35 @@ func (m *MockUserIdentityUsecase) UpdateUserIdentity(id int, input dto.UpdateUse
 
 func (m *MockUserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*dto.UserIdentityResponse), args.Error(1)
 }
 
+func (m *MockUserIdentityUsecase) ListMyUserIdentities(userID int) ([]*dto.UserIdentityResponse, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*dto.UserIdentityResponse), args.Error(1)
+}
+
+func (m *MockUserIdentityUsecase) FindMyUserIdentity(userID int, id int) (*dto.UserIdentityResponse, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.UserIdentityResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserIdentityUsecase) CreateMyUserIdentity(userID int, input dto.MyUserIdentityRequest) error {
+	args := m.Called(userID, input)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityUsecase) UpdateMyUserIdentity(userID int, id int, input dto.MyUserIdentityRequest) error {
+	args := m.Called(userID, id, input)
+	return args.Error(0)
+}
+
 func TestCreateUserIdentity(t *testing.T) {
 	mockUsecase := new(MockUserIdentityUsecase)
 	handler := NewUserIdentityHandler(mockUsecase)
@@ -44,13 +70,13 @@ func TestCreateUserIdentity(t *testing.T) {
 			UserID:      2,
 			Number:      "123456789",
 			Type:        "Citizen ID",
-			Status:      0,
-			ExpiryDate:  "12-12-2025",
+			Status:      1,
+			ExpiryDate:  "2025-12-12",
 			PlaceIssued: "Some city",
 		}
 		mockUsecase.On("CreateUserIdentity", mockInput).Return(nil)
 
-		body := `{"UserID":2,"Number":"123456789","Type":"Citizen ID","Status":"Approved","Expiry Date":"12-12-2025","PlaceIssued":"Some city"}`
+		body := `{"user_id":2,"number":"123456789","type":"Citizen ID","status":1,"expiry_date":"2025-12-12","place_issued":"Some city"}`
 		req, err := http.NewRequest(http.MethodPost, "/api/v1/user-identity", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -88,12 +114,12 @@ func TestUpdateUserIdentity(t *testing.T) {
 			Number:      "123888789",
 			Type:        "Passport",
 			Status:      0,
-			ExpiryDate:  "12-12-2025",
+			ExpiryDate:  "2025-12-12",
 			PlaceIssued: "Another city",
 		}
 		mockUsecase.On("UpdateUserIdentity", 1, mockInput).Return(nil)
 
-		body := `{"UserID":2,"Number":"123888789","Type":"Passport","Status":"Approved","Expiry Date":"12-12-2025","PlaceIssued":"Another city"}`
+		body := `{"user_id":2,"number":"123888789","type":"Passport","status":0,"expiry_date":"2025-12-12","place_issued":"Another city"}`
 		req, err := http.NewRequest(http.MethodPut, "/api/v1/user-identity/1", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -135,7 +161,7 @@ func TestFindUserIdentity(t *testing.T) {
 			ExpiryDate:  "12-12-2025",
 			PlaceIssued: "Some city",
 		}
-		mockUsecase.On("FindUserIdentity", 1).Return(mockUserIdentity, nil)
+		mockUsecase.On("FindUserIdentityByID", 1).Return(mockUserIdentity, nil)
 
 		req, err := http.NewRequest(http.MethodGet, "/api/v1/user-identity/1", nil)
 		assert.NoError(t, err)
@@ -148,9 +174,9 @@ func TestFindUserIdentity(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUsecase.On("FindUserIdentity", 1).Return(nil, errors.New("user identity not found"))
+		mockUsecase.On("FindUserIdentityByID", 2).Return(nil, domain.ErrUserIdentityNotFound)
 
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/user-identity/1", nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/user-identity/2", nil)
 		assert.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -159,3 +185,55 @@ func TestFindUserIdentity(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 }
+
+func TestUpdateMyUserIdentity(t *testing.T) {
+	mockUsecase := new(MockUserIdentityUsecase)
+	handler := NewUserIdentityHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PUT("/api/v1/me/identities/:id", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.UpdateMyUserIdentity(c)
+	})
+
+	input := dto.MyUserIdentityRequest{Number: "123456789", Type: "passport", ExpiryDate: "2030-01-01", PlaceIssued: "Hanoi"}
+	mockUsecase.On("UpdateMyUserIdentity", 5, 1, input).Return(nil)
+	mockUsecase.On("UpdateMyUserIdentity", 5, 2, input).Return(domain.ErrUserIdentityNotFound)
+
+	body := `{"number":"123456789","type":"passport","expiry_date":"2030-01-01","place_issued":"Hanoi"}`
+
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/me/identities/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodPut, "/api/v1/me/identities/2", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestListMyUserIdentities(t *testing.T) {
+	mockUsecase := new(MockUserIdentityUsecase)
+	handler := NewUserIdentityHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/me/identities", func(c *gin.Context) {
+		c.Set("userId", 5)
+		handler.ListMyUserIdentities(c)
+	})
+
+	mockUsecase.On("ListMyUserIdentities", 5).Return([]*dto.UserIdentityResponse{{ID: 1, UserID: 5, Number: "123456789"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/me/identities", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "123456789")
+	mockUsecase.AssertExpectations(t)
+}
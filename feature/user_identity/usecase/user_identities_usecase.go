@@ -1,17 +1,23 @@
 package usecase
 
 import (
+	"errors"
 	"time"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/storage"
+	"gorm.io/gorm"
 )
 
 type UserIdentityUsecaseInterface interface {
 	CreateUserIdentity(request dto.CreateUserIdentityRequest) error
 	UpdateUserIdentity(id int, request dto.UpdateUserIdentityRequest) error
 	FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error)
+	ListMyUserIdentities(userID int) ([]*dto.UserIdentityResponse, error)
+	FindMyUserIdentity(userID int, id int) (*dto.UserIdentityResponse, error)
+	CreateMyUserIdentity(userID int, request dto.MyUserIdentityRequest) error
+	UpdateMyUserIdentity(userID int, id int, request dto.MyUserIdentityRequest) error
 }
 
 type UserIdentityUsecase struct {
@@ -59,11 +65,76 @@ func (u *UserIdentityUsecase) UpdateUserIdentity(id int, request dto.UpdateUserI
 func (u *UserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error) {
 
 	identity, err := u.UserIdentityRepo.FindUserIdentityByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserIdentityResponse(identity), nil
+}
+
+func (u *UserIdentityUsecase) ListMyUserIdentities(userID int) ([]*dto.UserIdentityResponse, error) {
+	identities, err := u.UserIdentityRepo.FindUserIdentitiesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		response = append(response, toUserIdentityResponse(identity))
+	}
+	return response, nil
+}
+
+func (u *UserIdentityUsecase) FindMyUserIdentity(userID int, id int) (*dto.UserIdentityResponse, error) {
+	identity, err := u.findOwnedIdentity(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUserIdentityResponse(identity), nil
+}
+
+func (u *UserIdentityUsecase) CreateMyUserIdentity(userID int, request dto.MyUserIdentityRequest) error {
+	return u.CreateUserIdentity(dto.CreateUserIdentityRequest{
+		UserID:      userID,
+		Number:      request.Number,
+		Type:        request.Type,
+		Status:      request.Status,
+		ExpiryDate:  request.ExpiryDate,
+		PlaceIssued: request.PlaceIssued,
+	})
+}
+
+func (u *UserIdentityUsecase) UpdateMyUserIdentity(userID int, id int, request dto.MyUserIdentityRequest) error {
+	if _, err := u.findOwnedIdentity(userID, id); err != nil {
+		return err
+	}
+	return u.UpdateUserIdentity(id, dto.UpdateUserIdentityRequest{
+		UserID:      userID,
+		Number:      request.Number,
+		Type:        request.Type,
+		Status:      request.Status,
+		ExpiryDate:  request.ExpiryDate,
+		PlaceIssued: request.PlaceIssued,
+	})
+}
+
+// findOwnedIdentity reports identities of other users as not found, so their
+// existence is not leaked.
+func (u *UserIdentityUsecase) findOwnedIdentity(userID int, id int) (*domain.UserIdentity, error) {
+	identity, err := u.UserIdentityRepo.FindUserIdentityByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && identity.UserID != userID) {
+		return nil, domain.ErrUserIdentityNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
+	return identity, nil
+}
 
-	response := &dto.UserIdentityResponse{
+func toUserIdentityResponse(identity *domain.UserIdentity) *dto.UserIdentityResponse {
+	return &dto.UserIdentityResponse{
 		ID:          identity.ID,
 		UserID:      identity.UserID,
 		Number:      identity.Number,
@@ -72,6 +143,4 @@ func (u *UserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityRes
 		ExpiryDate:  identity.ExpiryDate.Format("2006-01-02"),
 		PlaceIssued: identity.PlaceIssued,
 	}
-
-	return response, nil
 }
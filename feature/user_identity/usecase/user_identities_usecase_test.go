@@ -1,7 +1,6 @@
 package usecase
 
 import (
-	"errors"
 	"testing"
 	"time"
 
@@ -9,6 +8,7 @@ import (
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 type MockUserIdentityRepository struct {
@@ -27,9 +27,17 @@ func (m *MockUserIdentityRepository) UpdateUserIdentity(UserIdentity *domain.Use
 
 func (m *MockUserIdentityRepository) FindUserIdentityByID(id int) (*domain.UserIdentity, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*domain.UserIdentity), args.Error(1)
 }
 
+func (m *MockUserIdentityRepository) FindUserIdentitiesByUserID(userID int) ([]*domain.UserIdentity, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*domain.UserIdentity), args.Error(1)
+}
+
 func TestCreateUserIdentity(t *testing.T) {
 	mockRepo := new(MockUserIdentityRepository)
 	usecase := NewUserIdentityUsecase(mockRepo)
@@ -39,7 +47,7 @@ func TestCreateUserIdentity(t *testing.T) {
 		Number:      "123456789",
 		Type:        "Citizen ID",
 		Status:      0,
-		ExpiryDate:  "12-12-2025",
+		ExpiryDate:  "2025-12-12",
 		PlaceIssued: "Some city",
 	}
 
@@ -60,20 +68,19 @@ func TestUpdateUserIdentity(t *testing.T) {
 		Number:      "123555789",
 		Type:        "Passport",
 		Status:      0,
-		ExpiryDate:  "12-12-2028",
+		ExpiryDate:  "2028-12-12",
 		PlaceIssued: "Some city",
 	}
 	userIdentity := &domain.UserIdentity{
 		ID:          1,
 		UserID:      2,
-		Number:      "123456987",
-		Type:        "Citizen ID",
+		Number:      "123555789",
+		Type:        "Passport",
 		Status:      0,
-		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+		ExpiryDate:  time.Date(2028, 12, 12, 0, 0, 0, 0, time.UTC),
 		PlaceIssued: "Some city",
 	}
 
-	mockRepo.On("FindUserIdentityByID", 1).Return(userIdentity, nil)
 	mockRepo.On("UpdateUserIdentity", userIdentity).Return(nil)
 
 	err := usecase.UpdateUserIdentity(1, input)
@@ -107,7 +114,7 @@ func TestFindUserIdentityByID(t *testing.T) {
 		Number:      "123456987",
 		Type:        "Citizen ID",
 		Status:      0,
-		ExpiryDate:  "2025-12-12 00:00:00 +0000 UTC",
+		ExpiryDate:  "2025-12-12",
 		PlaceIssued: "Some city",
 	}, result)
 	mockRepo.AssertExpectations(t)
@@ -117,11 +124,64 @@ func TestFindUserIdentityByID_NotFound(t *testing.T) {
 	mockRepo := new(MockUserIdentityRepository)
 	usecase := NewUserIdentityUsecase(mockRepo)
 
-	mockRepo.On("FindUserIdentityByID", 1).Return(nil, errors.New("record not found"))
+	mockRepo.On("FindUserIdentityByID", 1).Return(nil, gorm.ErrRecordNotFound)
 
 	result, err := usecase.FindUserIdentityByID(1)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserIdentityNotFound)
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdateMyUserIdentity(t *testing.T) {
+	input := dto.MyUserIdentityRequest{Number: "123456789", Type: "passport", ExpiryDate: "2030-01-01", PlaceIssued: "Hanoi"}
+
+	t.Run("own identity", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		usecase := NewUserIdentityUsecase(mockRepo)
+
+		mockRepo.On("FindUserIdentityByID", 1).Return(&domain.UserIdentity{ID: 1, UserID: 5}, nil)
+		mockRepo.On("UpdateUserIdentity", mock.AnythingOfType("*domain.UserIdentity")).Return(nil)
+
+		err := usecase.UpdateMyUserIdentity(5, 1, input)
+		assert.NoError(t, err)
+
+		updated := mockRepo.Calls[1].Arguments.Get(0).(*domain.UserIdentity)
+		assert.Equal(t, 5, updated.UserID)
+		assert.Equal(t, "123456789", updated.Number)
+	})
+
+	t.Run("someone else's identity", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		usecase := NewUserIdentityUsecase(mockRepo)
+
+		mockRepo.On("FindUserIdentityByID", 1).Return(&domain.UserIdentity{ID: 1, UserID: 6}, nil)
+
+		err := usecase.UpdateMyUserIdentity(5, 1, input)
+		assert.ErrorIs(t, err, domain.ErrUserIdentityNotFound)
+		mockRepo.AssertNotCalled(t, "UpdateUserIdentity", mock.Anything)
+	})
+
+	t.Run("missing identity", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		usecase := NewUserIdentityUsecase(mockRepo)
+
+		mockRepo.On("FindUserIdentityByID", 1).Return((*domain.UserIdentity)(nil), gorm.ErrRecordNotFound)
+
+		err := usecase.UpdateMyUserIdentity(5, 1, input)
+		assert.ErrorIs(t, err, domain.ErrUserIdentityNotFound)
+	})
+}
+
+func TestCreateMyUserIdentity(t *testing.T) {
+	mockRepo := new(MockUserIdentityRepository)
+	usecase := NewUserIdentityUsecase(mockRepo)
+
+	mockRepo.On("CreateUserIdentity", mock.AnythingOfType("*domain.UserIdentity")).Return(nil)
+
+	err := usecase.CreateMyUserIdentity(5, dto.MyUserIdentityRequest{Number: "123456789", Type: "passport", ExpiryDate: "2030-01-01", PlaceIssued: "Hanoi"})
+	assert.NoError(t, err)
+
+	created := mockRepo.Calls[0].Arguments.Get(0).(*domain.UserIdentity)
+	assert.Equal(t, 5, created.UserID)
+}
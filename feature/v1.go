@@ -1,12 +1,17 @@
 package feature
 
 import (
+	"log"
 	"net/http"
+	"time"
 
 	_ "github.com/cesc1802/onboarding-and-volunteer-service/docs"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
 	authStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
 	authTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/transport"
 	authUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/env"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/middleware"
 	userStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 	userTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/transport"
@@ -30,10 +35,15 @@ import (
 	departmentTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/transport"
 	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
 
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	roleStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
 	roleTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/transport"
 	roleUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
 
+	activityStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/storage"
+	activityTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/transport"
+	activityUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/usecase"
+
 	"github.com/cesc1802/share-module/system"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -41,9 +51,21 @@ import (
 
 // @host localhost:8080
 // @BasePath /api/v1
-func RegisterHandlerV1(mono system.Service) {
+func RegisterHandlerV1(mono system.Service) error {
 	router := mono.Router()
-	secretKey := authStorage.GetSecretKey()
+	secretKey := env.GetSecretKey()
+	passwordHasher, err := hasher.New(env.GetPasswordHashAlgorithm())
+	if err != nil {
+		return err
+	}
+	certificateTemplate, err := volunteerUsecase.LoadCertificateTemplate(env.GetCertificateTemplate())
+	if err != nil {
+		return err
+	}
+	availabilityLocation, err := time.LoadLocation(env.GetAvailabilityTimezone())
+	if err != nil {
+		return err
+	}
 	router.Use(cors.Default())
 	// add swagger
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -54,27 +76,54 @@ func RegisterHandlerV1(mono system.Service) {
 	})
 	v1 := router.Group("/api/v1")
 	// Initialize repository
-	authRepo := authStorage.NewAuthenticationRepository(mono.DB())
+	authRepo := authStorage.NewAuthenticationRepository(mono.DB(), passwordHasher)
+	refreshTokenRepo := authStorage.NewRefreshTokenRepository(mono.DB())
+	passwordResetTokenRepo := authStorage.NewPasswordResetTokenRepository(mono.DB(), passwordHasher)
 	userRepo := userStorage.NewAdminRepository(mono.DB())
 	applicantRepo := userStorage.NewApplicantRepository(mono.DB())
 	applicantRequestRepo := userStorage.NewApplicantRequestRepository(mono.DB())
 	applicantIdentityRepo := appliIdentityStorage.NewUserIdentityRepository(mono.DB())
 	volunteerRepo := volunteerStorage.NewVolunteerRepository(mono.DB())
+	onboardingRepo := volunteerStorage.NewOnboardingRepository(mono.DB())
+	hoursRepo := volunteerStorage.NewHoursRepository(mono.DB())
+	availabilityRepo := volunteerStorage.NewAvailabilityRepository(mono.DB())
+	skillRepo := volunteerStorage.NewSkillRepository(mono.DB())
+	certificateRepo := volunteerStorage.NewCertificateRepository(mono.DB())
 	volunteerRequestRepo := userStorage.NewVolunteerRequestRepository(mono.DB())
 	countryRepo := countryStorage.NewCountryRepository(mono.DB())
 	departmentRepo := departmentStorage.NewDepartmentRepository(mono.DB())
+	departmentScopeRepo := departmentStorage.NewDepartmentScopeRepository(mono.DB())
 	roleRepo := roleStorage.NewRoleRepository(mono.DB())
+	permissionRepo := roleStorage.NewPermissionRepository(mono.DB())
+	positionRepo := roleStorage.NewPositionRepository(mono.DB())
+	activityRepo := activityStorage.NewActivityRepository(mono.DB())
+	attendanceRepo := activityStorage.NewAttendanceRepository(mono.DB())
+	calendarRepo := activityStorage.NewCalendarRepository(mono.DB())
+
+	// emails are queued in the outbox and delivered by the mail worker
+	mailSender := mailer.NewOutbox(mono.DB())
 
 	// Initialize usecase
-	authUseCase := authUsecase.NewUserUsecase(authRepo, secretKey)
-	userUseCase := userUsecase.NewAdminUsecase(userRepo)
+	departmentScope := departmentUsecase.NewDepartmentScope(departmentScopeRepo)
+	authUseCase := authUsecase.NewUserUsecase(authRepo, refreshTokenRepo, passwordResetTokenRepo, mailSender, secretKey)
+	userUseCase := userUsecase.NewAdminUsecase(userRepo, env.GetRequestClaimTTL())
 	applicantUseCase := userUsecase.NewApplicantUsecase(applicantRepo)
 	applicantRequestUseCase := userUsecase.NewApplicantRequestUsecase(applicantRequestRepo)
 	applicantIdenityUseCase := appliIdentityUsecase.NewUserIdentityUsecase(applicantIdentityRepo)
-	volunteerUseCase := volunteerUsecase.NewVolunteerUsecase(volunteerRepo)
+	volunteerUseCase := volunteerUsecase.NewVolunteerUsecase(volunteerRepo, env.GetVolunteerDeactivationPolicy())
+	onboardingUseCase := volunteerUsecase.NewOnboardingUsecase(onboardingRepo, departmentScope, passwordHasher, env.GetPasswordResetURL(), env.GetAccountSetupTTL())
+	hoursUseCase := volunteerUsecase.NewHoursUsecase(hoursRepo, departmentScope)
+	availabilityUseCase := volunteerUsecase.NewAvailabilityUsecase(availabilityRepo, departmentScope)
+	skillUseCase := volunteerUsecase.NewSkillUsecase(skillRepo, availabilityRepo, departmentScope, availabilityLocation)
+	certificateUseCase := volunteerUsecase.NewCertificateUsecase(certificateRepo, departmentScope, certificateTemplate, env.GetAppBaseURL())
 	volunteerRequestUseCase := userUsecase.NewVolunteerRequestUsecase(volunteerRequestRepo)
 	countryUsecase := countryUsecase.NewCountryUsecase(countryRepo)
 	departmentUsecase := departmentUsecase.NewDepartmentUsecase(departmentRepo)
+	permissionUsecase := roleUsecase.NewPermissionUsecase(permissionRepo, roleRepo)
+	positionUsecase := roleUsecase.NewPositionUsecase(positionRepo)
+	activityUseCase := activityUsecase.NewActivityUsecase(activityRepo, departmentScope)
+	attendanceUseCase := activityUsecase.NewAttendanceUsecase(attendanceRepo, departmentScope, env.GetAttendanceSecretKey(), env.GetAttendanceTokenTTL(), env.GetAttendanceURL())
+	calendarUseCase := activityUsecase.NewCalendarUsecase(calendarRepo, permissionRepo, env.GetAppBaseURL())
 	roleUsecase := roleUsecase.NewRoleUsecase(roleRepo)
 
 	// Initialize handler
@@ -84,32 +133,117 @@ func RegisterHandlerV1(mono system.Service) {
 	applicantRequestHandler := userTransport.NewApplicantRequestHandler(applicantRequestUseCase)
 	applicantIdentityHandler := appliIdentityTransport.NewUserIdentityHandler(applicantIdenityUseCase)
 	volunteerHandler := volunteerTransport.NewVolunteerHandler(volunteerUseCase)
+	onboardingHandler := volunteerTransport.NewOnboardingHandler(onboardingUseCase)
+	hoursHandler := volunteerTransport.NewHoursHandler(hoursUseCase)
+	availabilityHandler := volunteerTransport.NewAvailabilityHandler(availabilityUseCase)
+	skillHandler := volunteerTransport.NewSkillHandler(skillUseCase)
+	certificateHandler := volunteerTransport.NewCertificateHandler(certificateUseCase)
 	volunteerRequestHandler := userTransport.NewVolunteerRequestHandler(volunteerRequestUseCase)
 	countryHandler := countryTransport.NewCountryHandler(countryUsecase)
 	departmentHandler := departmentTransport.NewDepartmentHandler(departmentUsecase)
 	roleHandler := roleTransport.NewRoleHandler(roleUsecase)
+	permissionHandler := roleTransport.NewPermissionHandler(permissionUsecase)
+	positionHandler := roleTransport.NewPositionHandler(positionUsecase)
+	activityHandler := activityTransport.NewActivityHandler(activityUseCase)
+	attendanceHandler := activityTransport.NewAttendanceHandler(attendanceUseCase)
+	calendarHandler := activityTransport.NewCalendarHandler(calendarUseCase)
+
+	authMiddleware := middleware.AuthMiddleware(secretKey, refreshTokenRepo)
+	can := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermission(permissionRepo, permission)
+	}
 
 	auth := v1.Group("/auth")
 	{
 		auth.POST("/login", authHandler.Login)
 
 		auth.POST("/register", authHandler.Register)
+
+		auth.POST("/refresh", authHandler.Refresh)
+
+		auth.POST("/logout", authHandler.Logout)
+
+		auth.GET("/verify-email", authHandler.VerifyEmail)
+
+		auth.POST("/verify-email/resend", authHandler.ResendVerificationEmail)
+
+		auth.POST("/forgot-password", authHandler.ForgotPassword)
+
+		auth.POST("/reset-password", authHandler.ResetPassword)
 	}
 
 	admin := v1.Group("/admin")
-	admin.Use(middleware.AuthMiddleware(secretKey))
+	admin.Use(authMiddleware)
+	{
+		admin.GET("/list-request", can(roleDomain.PermissionRequestRead), userHandler.GetListRequest)
+		admin.GET("/request/:id", can(roleDomain.PermissionRequestRead), userHandler.GetRequestById)
+		admin.GET("/request/:id/history", can(roleDomain.PermissionRequestRead), userHandler.GetRequestHistory)
+		admin.GET("/request/:id/messages", can(roleDomain.PermissionRequestRead), userHandler.ListRequestMessages)
+		admin.POST("/request/:id/messages", can(roleDomain.PermissionRequestReview), userHandler.SendRequestMessage)
+		admin.POST("/request/:id/view", can(roleDomain.PermissionRequestRead), userHandler.MarkRequestViewed)
+		admin.GET("/request/:id/views", can(roleDomain.PermissionRequestRead), userHandler.ListRequestViews)
+		admin.POST("/request/:id/claim", can(roleDomain.PermissionRequestReview), userHandler.ClaimRequest)
+		admin.DELETE("/request/:id/claim", can(roleDomain.PermissionRequestReview), userHandler.ReleaseRequest)
+		admin.POST("/request/:id/assign", can(roleDomain.PermissionRequestReview), userHandler.AssignRequest)
+		admin.GET("/messages/unread", can(roleDomain.PermissionRequestRead), userHandler.CountUnreadMessages)
+		admin.GET("/list-pending-request", can(roleDomain.PermissionRequestRead), userHandler.GetListPendingRequest)
+		admin.GET("/pending-request/:id", can(roleDomain.PermissionRequestRead), userHandler.GetPendingRequestById)
+		admin.POST("/review-request/:id", can(roleDomain.PermissionRequestReview), userHandler.ReviewRequest)
+		admin.POST("/approve-request/:id", can(roleDomain.PermissionRequestApprove), userHandler.ApproveRequest)
+		admin.POST("/reject-request/:id", can(roleDomain.PermissionRequestReject), userHandler.RejectRequest)
+		admin.POST("/add-reject-notes/:id", can(roleDomain.PermissionRequestReject), userHandler.AddRejectNotes)
+		admin.DELETE("/delete-request/:id", can(roleDomain.PermissionRequestDelete), userHandler.DeleteRequest)
+		admin.POST("/approve-requests", can(roleDomain.PermissionRequestApprove), userHandler.BulkApproveRequests)
+		admin.POST("/reject-requests", can(roleDomain.PermissionRequestReject), userHandler.BulkRejectRequests)
+		admin.POST("/delete-requests", can(roleDomain.PermissionRequestDelete), userHandler.BulkDeleteRequests)
+		admin.GET("/trash/requests", can(roleDomain.PermissionRequestDelete), userHandler.ListDeletedRequests)
+		admin.POST("/trash/requests/:id/restore", can(roleDomain.PermissionRequestDelete), userHandler.RestoreRequest)
+		admin.GET("/trash/applicants", can(roleDomain.PermissionApplicantManage), applicantHandler.ListDeletedApplicants)
+		admin.POST("/trash/applicants/:id/restore", can(roleDomain.PermissionApplicantManage), applicantHandler.RestoreApplicant)
+		admin.GET("/trash/volunteers", can(roleDomain.PermissionApplicantManage), volunteerHandler.ListDeletedVolunteers)
+		admin.POST("/trash/volunteers/:id/restore", can(roleDomain.PermissionApplicantManage), volunteerHandler.RestoreVolunteer)
+	}
+
+	// self-service endpoints, the user is always the one from the token
+	me := v1.Group("/me")
+	me.Use(authMiddleware)
 	{
-		admin.GET("/list-request", userHandler.GetListRequest)
-		admin.GET("/request/:id", userHandler.GetRequestById)
-		admin.GET("/list-pending-request", userHandler.GetListPendingRequest)
-		admin.GET("/pending-request/:id", userHandler.GetPendingRequestById)
-		admin.POST("/approve-request/:id", userHandler.ApproveRequest)
-		admin.POST("/reject-request/:id", userHandler.RejectRequest)
-		admin.POST("/add-reject-notes/:id", userHandler.AddRejectNotes)
-		admin.DELETE("/delete-request/:id", userHandler.DeleteRequest)
+		me.GET("", applicantHandler.GetMe)
+		me.PUT("", applicantHandler.UpdateMe)
+		me.PUT("/password", authHandler.ChangePassword)
+		me.GET("/requests", applicantRequestHandler.ListMyRequests)
+		me.POST("/requests", applicantRequestHandler.CreateMyRequest)
+		me.GET("/requests/:id", applicantRequestHandler.GetMyRequest)
+		me.GET("/requests/:id/history", applicantRequestHandler.GetRequestHistory)
+		me.POST("/requests/:id/cancel", applicantRequestHandler.CancelMyRequest)
+		me.POST("/requests/:id/resubmit", applicantRequestHandler.ResubmitMyRequest)
+		me.GET("/requests/:id/messages", applicantRequestHandler.ListMyRequestMessages)
+		me.POST("/requests/:id/messages", applicantRequestHandler.SendMyRequestMessage)
+		me.GET("/messages/unread", applicantRequestHandler.CountMyUnreadMessages)
+		me.GET("/identities", applicantIdentityHandler.ListMyUserIdentities)
+		me.POST("/identities", applicantIdentityHandler.CreateMyUserIdentity)
+		me.GET("/identities/:id", applicantIdentityHandler.FindMyUserIdentity)
+		me.PUT("/identities/:id", applicantIdentityHandler.UpdateMyUserIdentity)
+		me.GET("/hours", hoursHandler.ListMyHours)
+		me.POST("/hours", hoursHandler.LogMyHours)
+		me.PUT("/hours/:id", hoursHandler.UpdateMyHours)
+		me.DELETE("/hours/:id", hoursHandler.DeleteMyHours)
+		me.GET("/availability", availabilityHandler.GetMyAvailability)
+		me.PUT("/availability/slots", availabilityHandler.ReplaceMySlots)
+		me.POST("/availability/exceptions", availabilityHandler.AddMyException)
+		me.DELETE("/availability/exceptions/:id", availabilityHandler.DeleteMyException)
+		me.GET("/skills", skillHandler.ListMySkills)
+		me.PUT("/skills/:skillId", skillHandler.DeclareMySkill)
+		me.DELETE("/skills/:skillId", skillHandler.RemoveMySkill)
+		me.GET("/certificates", certificateHandler.ListMyCertificates)
+		me.GET("/certificates/:id/pdf", certificateHandler.MyCertificatePDF)
+		me.POST("/calendar-feed", calendarHandler.CreateMyFeed)
+		me.DELETE("/calendar-feed", calendarHandler.RevokeMyFeed)
 	}
 
+	// raw id endpoints act on any user and are reserved to staff
 	applicant := v1.Group("/applicant")
+	applicant.Use(authMiddleware, can(roleDomain.PermissionApplicantManage))
 	{
 		applicant.POST("/", applicantHandler.CreateApplicant)
 		applicant.PUT("/:id", applicantHandler.UpdateApplicant)
@@ -118,11 +252,14 @@ func RegisterHandlerV1(mono system.Service) {
 	}
 
 	appliRequest := v1.Group("/applicant-request")
+	appliRequest.Use(authMiddleware)
 	{
-		appliRequest.POST("/", applicantRequestHandler.CreateApplicantRequest)
+		appliRequest.POST("/", can(roleDomain.PermissionApplicantManage), applicantRequestHandler.CreateApplicantRequest)
+		appliRequest.GET("/:id/history", applicantRequestHandler.GetRequestHistory)
 	}
 
 	appliIdentity := v1.Group("applicant-identity")
+	appliIdentity.Use(authMiddleware, can(roleDomain.PermissionApplicantManage))
 	{
 		appliIdentity.POST("/", applicantIdentityHandler.CreateUserIdentity)
 		appliIdentity.GET("/:id", applicantIdentityHandler.FindUserIdentity)
@@ -131,13 +268,28 @@ func RegisterHandlerV1(mono system.Service) {
 
 	volunteer := v1.Group("/volunteer")
 	{
-		volunteer.POST("/", volunteerHandler.CreateVolunteer)
-		volunteer.PUT("/:id", volunteerHandler.UpdateVolunteer)
-		volunteer.DELETE("/:id", volunteerHandler.DeleteVolunteer)
-		volunteer.GET("/:id", volunteerHandler.FindVolunteerByID)
+		volunteer.GET("/", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.ListVolunteers)
+		volunteer.POST("/", authMiddleware, can(roleDomain.PermissionApplicantManage), onboardingHandler.OnboardVolunteer)
+		volunteer.PUT("/:id", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.UpdateVolunteer)
+		volunteer.DELETE("/:id", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.DeleteVolunteer)
+		volunteer.GET("/:id", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.FindVolunteerByID)
+		volunteer.GET("/:id/positions", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.ListPositionHistory)
+		volunteer.POST("/:id/positions", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.AssignPosition)
+		volunteer.DELETE("/:id/positions/current", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.EndPosition)
+		volunteer.POST("/:id/deactivate", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.DeactivateVolunteer)
+		volunteer.POST("/:id/reactivate", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.ReactivateVolunteer)
+		volunteer.GET("/:id/history", authMiddleware, can(roleDomain.PermissionApplicantManage), volunteerHandler.ListHistory)
+		volunteer.GET("/:id/skills", authMiddleware, can(roleDomain.PermissionSkillManage), skillHandler.ListVolunteerSkills)
+		volunteer.POST("/:id/skills/:skillId/verify", authMiddleware, can(roleDomain.PermissionSkillManage), skillHandler.VerifySkill)
+		volunteer.DELETE("/:id/skills/:skillId/verify", authMiddleware, can(roleDomain.PermissionSkillManage), skillHandler.UnverifySkill)
+		volunteer.GET("/:id/certificates", authMiddleware, can(roleDomain.PermissionHoursReview), certificateHandler.ListCertificates)
+		volunteer.POST("/:id/certificates", authMiddleware, can(roleDomain.PermissionHoursReview), certificateHandler.IssueCertificate)
+		volunteer.GET("/:id/certificates/:certificateId/pdf", authMiddleware, can(roleDomain.PermissionHoursReview), certificateHandler.CertificatePDF)
+		volunteer.POST("/:id/certificates/:certificateId/revoke", authMiddleware, can(roleDomain.PermissionHoursReview), certificateHandler.RevokeCertificate)
 	}
 
 	volRequest := v1.Group("/volunteer-request")
+	volRequest.Use(authMiddleware, can(roleDomain.PermissionApplicantManage))
 	{
 		volRequest.POST("/", volunteerRequestHandler.CreateVolunteerRequest)
 	}
@@ -160,9 +312,120 @@ func RegisterHandlerV1(mono system.Service) {
 
 	role := v1.Group("/role")
 	{
-		role.POST("/", roleHandler.CreateRole)
-		role.PUT("/:id", roleHandler.UpdateRole)
-		role.DELETE("/:id", roleHandler.DeleteRole)
+		role.POST("/", authMiddleware, can(roleDomain.PermissionRoleManage), roleHandler.CreateRole)
+		role.PUT("/:id", authMiddleware, can(roleDomain.PermissionRoleManage), roleHandler.UpdateRole)
+		role.DELETE("/:id", authMiddleware, can(roleDomain.PermissionRoleManage), roleHandler.DeleteRole)
 		role.GET("/:id", roleHandler.GetRoleByID)
+		role.GET("/:id/permissions", authMiddleware, can(roleDomain.PermissionRoleManage), permissionHandler.GetRolePermissions)
+		role.POST("/:id/permissions", authMiddleware, can(roleDomain.PermissionRoleManage), permissionHandler.AssignPermission)
+		role.DELETE("/:id/permissions/:permissionId", authMiddleware, can(roleDomain.PermissionRoleManage), permissionHandler.RevokePermission)
+	}
+
+	position := v1.Group("/position")
+	{
+		position.POST("/", authMiddleware, can(roleDomain.PermissionRoleManage), positionHandler.CreatePosition)
+		position.GET("/", positionHandler.ListPositions)
+		position.GET("/:id", positionHandler.GetPositionByID)
+		position.PUT("/:id", authMiddleware, can(roleDomain.PermissionRoleManage), positionHandler.UpdatePosition)
+		position.DELETE("/:id", authMiddleware, can(roleDomain.PermissionRoleManage), positionHandler.DeletePosition)
+	}
+
+	skill := v1.Group("/skill")
+	{
+		skill.POST("/", authMiddleware, can(roleDomain.PermissionSkillManage), skillHandler.CreateSkill)
+		skill.GET("/", skillHandler.ListSkills)
+		skill.GET("/:id", skillHandler.GetSkillByID)
+		skill.PUT("/:id", authMiddleware, can(roleDomain.PermissionSkillManage), skillHandler.UpdateSkill)
+		skill.DELETE("/:id", authMiddleware, can(roleDomain.PermissionSkillManage), skillHandler.DeleteSkill)
+	}
+
+	activity := v1.Group("/activity")
+	activity.Use(authMiddleware)
+	{
+		activity.GET("/", activityHandler.ListActivities)
+		activity.POST("/", can(roleDomain.PermissionActivityManage), activityHandler.CreateActivity)
+		activity.GET("/:id", activityHandler.FindActivityByID)
+		activity.PUT("/:id", can(roleDomain.PermissionActivityManage), activityHandler.UpdateActivity)
+		activity.DELETE("/:id", can(roleDomain.PermissionActivityManage), activityHandler.DeleteActivity)
+		activity.GET("/:id/signups", can(roleDomain.PermissionActivityManage), activityHandler.ListSignups)
+		activity.DELETE("/:id/signups/:volunteerId", can(roleDomain.PermissionActivityManage), activityHandler.CancelSignup)
+		activity.POST("/:id/signup", activityHandler.SignUp)
+		activity.DELETE("/:id/signup", activityHandler.CancelOwnSignup)
+		activity.GET("/:id/matches", can(roleDomain.PermissionActivityManage), skillHandler.MatchVolunteers)
+		activity.GET("/:id/attendance", can(roleDomain.PermissionActivityManage), attendanceHandler.ListAttendance)
+		activity.GET("/:id/attendance/qr", can(roleDomain.PermissionActivityManage), attendanceHandler.AttendanceQR)
+		activity.POST("/attendance", attendanceHandler.ScanAttendance)
+	}
+
+	hours := v1.Group("/hours")
+	hours.Use(authMiddleware, can(roleDomain.PermissionHoursReview))
+	{
+		hours.GET("/", hoursHandler.ListHours)
+		hours.POST("/:id/approve", hoursHandler.ApproveHours)
+		hours.POST("/:id/dispute", hoursHandler.DisputeHours)
+		hours.GET("/totals/volunteers", hoursHandler.VolunteerTotals)
+		hours.GET("/totals/departments", hoursHandler.DepartmentTotals)
+	}
+
+	availability := v1.Group("/availability")
+	availability.Use(authMiddleware, can(roleDomain.PermissionActivityManage))
+	{
+		availability.GET("/", availabilityHandler.ListAvailability)
+	}
+
+	// calendar clients cannot send the access token, the feeds check the
+	// feed token in their URL instead
+	calendar := v1.Group("/calendar")
+	{
+		calendar.GET("/me.ics", calendarHandler.VolunteerCalendar)
+		calendar.GET("/departments/:id/activities.ics", calendarHandler.DepartmentCalendar)
+	}
+
+	// anyone holding a certificate can have its code checked
+	certificates := v1.Group("/certificates")
+	{
+		certificates.GET("/:code/verify", certificateHandler.VerifyCertificate)
+	}
+
+	permission := v1.Group("/permission")
+	permission.Use(authMiddleware, can(roleDomain.PermissionRoleManage))
+	{
+		permission.POST("/", permissionHandler.CreatePermission)
+		permission.GET("/", permissionHandler.ListPermissions)
+		permission.DELETE("/:id", permissionHandler.DeletePermission)
+	}
+
+	return nil
+}
+
+// NewMailWorker returns the worker delivering the emails queued in the outbox
+// with the transport configured in the environment.
+func NewMailWorker(mono system.Service) *mailer.Worker {
+	cfg := mailer.ConfigFromEnv()
+	return mailer.NewWorker(mono.DB(), mailer.New(cfg), cfg)
+}
+
+// PurgeDeleted permanently removes the requests, volunteers and applicants
+// that were soft deleted before the given time. Requests and volunteers go
+// first so that users are only removed once nothing points at them.
+func PurgeDeleted(mono system.Service, before time.Time) error {
+	requests, err := userStorage.NewAdminRepository(mono.DB()).PurgeDeletedRequests(before)
+	if err != nil {
+		return err
+	}
+	volunteers, err := volunteerStorage.NewVolunteerRepository(mono.DB()).PurgeDeletedVolunteers(before)
+	if err != nil {
+		log.Printf("purged %d requests deleted before %s, purging volunteers failed: %v",
+			requests, before.Format(time.RFC3339), err)
+		return err
+	}
+	applicants, err := userStorage.NewApplicantRepository(mono.DB()).PurgeDeletedApplicants(before)
+	if err != nil {
+		log.Printf("purged %d requests and %d volunteers deleted before %s, purging applicants failed: %v",
+			requests, volunteers, before.Format(time.RFC3339), err)
+		return err
 	}
+	log.Printf("purged %d requests, %d volunteers and %d applicants deleted before %s",
+		requests, volunteers, applicants, before.Format(time.RFC3339))
+	return nil
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidTimeRange   = errors.New("start time must be before end time")
+	ErrSlotOverlap        = errors.New("availability slots overlap")
+	ErrExceptionNotFound  = errors.New("availability exception not found")
+	ErrAvailabilityPeriod = errors.New("availability period must span 1 to 31 days")
+)
+
+// AvailabilitySlot is a weekly period a volunteer is usually available.
+// Weekday counts from Sunday (0) like time.Weekday, times are "15:04" in
+// the local time of the department.
+type AvailabilitySlot struct {
+	ID          int       `gorm:"primaryKey"`
+	VolunteerID int       `gorm:"not null;index"`
+	Weekday     int       `gorm:"not null"`
+	StartTime   string    `gorm:"size:5;not null"`
+	EndTime     string    `gorm:"size:5;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (AvailabilitySlot) TableName() string {
+	return "volunteer_availability_slots"
+}
+
+// AvailabilityException overrides the weekly slots on a given day. It makes
+// the volunteer unavailable, or available when Available is set, for the
+// whole day or between StartTime and EndTime when they are set.
+type AvailabilityException struct {
+	ID          int       `gorm:"primaryKey"`
+	VolunteerID int       `gorm:"not null;index"`
+	Date        time.Time `gorm:"type:date;not null;index"`
+	StartTime   string    `gorm:"size:5"`
+	EndTime     string    `gorm:"size:5"`
+	Available   bool      `gorm:"not null"`
+	Note        string    `gorm:"size:255"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (AvailabilityException) TableName() string {
+	return "volunteer_availability_exceptions"
+}
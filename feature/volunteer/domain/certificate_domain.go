@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCertificateNotFound = errors.New("certificate not found")
+	ErrCertificateRevoked  = errors.New("certificate has been revoked")
+	ErrCertificatePeriod   = errors.New("certificate period must start before it ends and end by today")
+	ErrNoApprovedHours     = errors.New("volunteer has no approved hours in this period")
+	ErrOwnCertificate      = errors.New("volunteers cannot sign their own certificate")
+)
+
+// Certificate is a proof of service signed by a coordinator. It keeps the
+// names and the approved hours as they were when it was issued, so that what
+// third parties verify matches the PDF the volunteer holds. Code is the
+// public verification code printed on it.
+type Certificate struct {
+	ID             int       `gorm:"primaryKey"`
+	Code           string    `gorm:"size:20;not null;unique"`
+	VolunteerID    int       `gorm:"not null;index"`
+	VolunteerName  string    `gorm:"size:255;not null"`
+	DepartmentID   int       `gorm:"not null"`
+	DepartmentName string    `gorm:"size:255;not null"`
+	PeriodFrom     time.Time `gorm:"type:date;not null"`
+	PeriodTo       time.Time `gorm:"type:date;not null"`
+	TotalMinutes   int       `gorm:"not null"`
+	SignedBy       int       `gorm:"not null"`
+	SignerName     string    `gorm:"size:255;not null"`
+	IssuedAt       time.Time `gorm:"not null"`
+	RevokedAt      *time.Time
+	RevokedBy      *int
+	RevokeReason   string `gorm:"size:255"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (Certificate) TableName() string {
+	return "volunteer_certificates"
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	departmentDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+)
+
+// Hours entry statuses. Entries are logged pending, a coordinator approves or
+// disputes them and the volunteer can correct a disputed entry, which makes it
+// pending again.
+const (
+	HoursPending  = "pending"
+	HoursApproved = "approved"
+	HoursDisputed = "disputed"
+)
+
+var (
+	ErrHoursNotFound     = errors.New("hours entry not found")
+	ErrHoursApproved     = errors.New("approved hours cannot be changed")
+	ErrHoursNotPending   = errors.New("only pending hours can be reviewed")
+	ErrHoursInFuture     = errors.New("hours cannot be logged for a future date")
+	ErrOwnHours          = errors.New("volunteers cannot review their own hours")
+	ErrNotSignedUp       = errors.New("volunteer was not confirmed for this activity")
+	ErrOutsideDepartment = departmentDomain.ErrOutsideDepartment
+)
+
+// HoursEntry is time a volunteer spent on an activity or for their
+// department on a given day. The user and department names are only filled
+// when reading.
+type HoursEntry struct {
+	ID             int       `gorm:"primaryKey"`
+	VolunteerID    int       `gorm:"not null;index"`
+	Date           time.Time `gorm:"type:date;not null;index"`
+	ActivityID     *int      `gorm:"index"`
+	DepartmentID   int       `gorm:"not null;index"`
+	Minutes        int       `gorm:"not null"`
+	Notes          string    `gorm:"size:255"`
+	Status         string    `gorm:"size:20;not null;index"`
+	ReviewedBy     *int
+	ReviewedAt     *time.Time
+	ReviewNote     string    `gorm:"size:255"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	UserID         int       `gorm:"->;-:migration"`
+	Name           string    `gorm:"->;-:migration"`
+	Surname        string    `gorm:"->;-:migration"`
+	DepartmentName string    `gorm:"->;-:migration"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (HoursEntry) TableName() string {
+	return "volunteer_hours"
+}
+
+// VolunteerHoursTotal sums the hours of a volunteer by status.
+type VolunteerHoursTotal struct {
+	VolunteerID     int
+	Name            string
+	Surname         string
+	ApprovedMinutes int
+	PendingMinutes  int
+	DisputedMinutes int
+	Entries         int
+}
+
+// DepartmentHoursTotal sums the hours logged for a department by status.
+type DepartmentHoursTotal struct {
+	DepartmentID    int
+	DepartmentName  string
+	ApprovedMinutes int
+	PendingMinutes  int
+	DisputedMinutes int
+	Volunteers      int
+}
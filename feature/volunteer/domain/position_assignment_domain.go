@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPositionAlreadyHeld = errors.New("volunteer already holds this position")
+	ErrNoCurrentPosition   = errors.New("volunteer holds no position")
+)
+
+// PositionAssignment records a volunteer holding a position of the catalog
+// from StartedAt until EndedAt. The assignment without EndedAt is the current
+// position of the volunteer, there is at most one.
+type PositionAssignment struct {
+	ID           int       `gorm:"primaryKey"`
+	VolunteerID  int       `gorm:"not null;index"`
+	PositionID   int       `gorm:"not null;index"`
+	AssignedBy   int       `gorm:"not null"`
+	Notes        string    `gorm:"size:255"`
+	StartedAt    time.Time `gorm:"not null"`
+	EndedAt      *time.Time
+	PositionCode string `gorm:"->;-:migration"`
+	PositionName string `gorm:"->;-:migration"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (PositionAssignment) TableName() string {
+	return "volunteer_positions"
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSkillNotFound          = errors.New("skill not found")
+	ErrSkillNameTaken         = errors.New("a skill with this name already exists")
+	ErrSkillInUse             = errors.New("skill is declared by volunteers or required by activities")
+	ErrVolunteerSkillNotFound = errors.New("volunteer has not declared this skill")
+	ErrOwnSkill               = errors.New("volunteers cannot verify their own skills")
+)
+
+// Skill is an entry of the skill catalog, such as a language, first aid or a
+// driving licence. Category only groups skills when listing them.
+type Skill struct {
+	ID          int       `gorm:"primaryKey"`
+	Name        string    `gorm:"size:100;not null;unique"`
+	Category    string    `gorm:"size:50"`
+	Description string    `gorm:"size:255"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (Skill) TableName() string {
+	return "skills"
+}
+
+// VolunteerSkill is a skill a volunteer declared. A coordinator verifies it,
+// the verification is dropped when the volunteer changes the declaration. The
+// skill fields are only filled when reading.
+type VolunteerSkill struct {
+	VolunteerID   int    `gorm:"primaryKey"`
+	SkillID       int    `gorm:"primaryKey"`
+	Note          string `gorm:"size:255"`
+	VerifiedBy    *int
+	VerifiedAt    *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	SkillName     string    `gorm:"->;-:migration"`
+	SkillCategory string    `gorm:"->;-:migration"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (VolunteerSkill) TableName() string {
+	return "volunteer_skills"
+}
+
+// Availability of a volunteer over the time of an activity, as ranked by
+// matching.
+const (
+	MatchAvailable   = "available"
+	MatchPartial     = "partial"
+	MatchUnavailable = "unavailable"
+)
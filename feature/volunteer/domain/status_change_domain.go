@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Volunteer statuses.
+const (
+	VolunteerStatusInactive = 0
+	VolunteerStatusActive   = 1
+)
+
+// What deactivating a volunteer does to their account, see
+// env.GetVolunteerDeactivationPolicy.
+const (
+	// DeactivationBlockLogin disables the user so they can no longer log in.
+	DeactivationBlockLogin = "block"
+	// DeactivationDemote keeps the login but moves the user back to the
+	// applicant role.
+	DeactivationDemote = "demote"
+)
+
+var (
+	ErrVolunteerInactive = errors.New("volunteer is already deactivated")
+	ErrVolunteerActive   = errors.New("volunteer is already active")
+)
+
+// VolunteerStatusChange records an admin deactivating or reactivating a
+// volunteer, Status is the status the volunteer was given.
+type VolunteerStatusChange struct {
+	ID          int       `gorm:"primaryKey"`
+	VolunteerID int       `gorm:"not null;index"`
+	Status      int       `gorm:"not null"`
+	Reason      string    `gorm:"size:255;not null"`
+	ChangedBy   int       `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (VolunteerStatusChange) TableName() string {
+	return "volunteer_status_changes"
+}
@@ -1,14 +1,53 @@
 package domain
 
 import (
+	"errors"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVolunteerNotFound = errors.New("volunteer not found")
+	ErrEmailTaken        = errors.New("email is already used by another account")
 )
 
+// Volunteer is the volunteer_details row of a user promoted to volunteer.
+// OnboardedBy is the admin who added the volunteer by hand, it is nil for
+// volunteers who went through verification.
 type Volunteer struct {
-	ID           int       `gorm:"primaryKey"`
-	UserID       int       `gorm:"unique;notnull"`
-	DepartmentID int       `gorm:"notnull"`
-	Status       int       `gorm:"notnull"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	ID           int `gorm:"primaryKey"`
+	UserID       int `gorm:"unique;notnull"`
+	DepartmentID int `gorm:"notnull"`
+	Status       int `gorm:"notnull"`
+	OnboardedBy  *int
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName overrides the default table name used by GORM.
+func (Volunteer) TableName() string {
+	return "volunteer_details"
+}
+
+// VolunteerListing is a volunteer joined with their user, department and
+// role, as shown in the volunteer directory.
+type VolunteerListing struct {
+	ID             int
+	UserID         int
+	Email          string
+	Name           string
+	Surname        string
+	Gender         string
+	Mobile         string
+	RoleID         int
+	RoleName       string
+	DepartmentID   int
+	DepartmentName string
+	PositionID     *int
+	PositionCode   string
+	PositionName   string
+	Status         int
+	CreatedAt      time.Time
 }
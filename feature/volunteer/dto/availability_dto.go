@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+type AvailabilitySlotDTO struct {
+	Weekday   int    `json:"weekday" binding:"min=0,max=6"`
+	StartTime string `json:"start_time" binding:"required,datetime=15:04"`
+	EndTime   string `json:"end_time" binding:"required,datetime=15:04"`
+}
+
+type AvailabilitySlotsDTO struct {
+	Slots []AvailabilitySlotDTO `json:"slots" binding:"max=50,dive"`
+}
+
+type AvailabilityExceptionDTO struct {
+	Date      string `json:"date" binding:"required,datetime=2006-01-02"`
+	StartTime string `json:"start_time" binding:"required_with=EndTime,omitempty,datetime=15:04"`
+	EndTime   string `json:"end_time" binding:"required_with=StartTime,omitempty,datetime=15:04"`
+	Available bool   `json:"available"`
+	Note      string `json:"note" binding:"max=255"`
+}
+
+type AvailabilityExceptionResponseDTO struct {
+	ID        int    `json:"id"`
+	Date      string `json:"date"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Available bool   `json:"available"`
+	Note      string `json:"note,omitempty"`
+}
+
+type MyAvailabilityDTO struct {
+	Slots      []AvailabilitySlotDTO              `json:"slots"`
+	Exceptions []AvailabilityExceptionResponseDTO `json:"exceptions"`
+}
+
+type AvailabilityQuery struct {
+	DepartmentID *int       `form:"department_id"`
+	VolunteerID  *int       `form:"volunteer_id"`
+	From         *time.Time `form:"from" time_format:"2006-01-02"`
+	To           *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// AvailabilityWindowDTO is a period a volunteer is available on a day, the
+// end of the day being "24:00".
+type AvailabilityWindowDTO struct {
+	Date      string `json:"date"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type VolunteerAvailabilityDTO struct {
+	VolunteerID  int                     `json:"volunteer_id"`
+	Name         string                  `json:"name"`
+	Surname      string                  `json:"surname"`
+	DepartmentID int                     `json:"department_id"`
+	Windows      []AvailabilityWindowDTO `json:"windows"`
+}
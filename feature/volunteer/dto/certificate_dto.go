@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// IssueCertificateDTO is the period a certificate covers, From and To are
+// inclusive days.
+type IssueCertificateDTO struct {
+	From string `json:"from" binding:"required,datetime=2006-01-02"`
+	To   string `json:"to" binding:"required,datetime=2006-01-02"`
+}
+
+type RevokeCertificateDTO struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type CertificateDTO struct {
+	ID             int        `json:"id"`
+	Code           string     `json:"code"`
+	VolunteerID    int        `json:"volunteer_id"`
+	VolunteerName  string     `json:"volunteer_name"`
+	DepartmentID   int        `json:"department_id"`
+	DepartmentName string     `json:"department_name"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	TotalMinutes   int        `json:"total_minutes"`
+	TotalHours     float64    `json:"total_hours"`
+	SignedBy       int        `json:"signed_by"`
+	SignerName     string     `json:"signer_name"`
+	IssuedAt       time.Time  `json:"issued_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	RevokeReason   string     `json:"revoke_reason,omitempty"`
+	VerifyURL      string     `json:"verify_url"`
+}
+
+// CertificateVerificationDTO is what anyone holding a verification code can
+// check. Valid is false once the certificate was revoked.
+type CertificateVerificationDTO struct {
+	Code           string     `json:"code"`
+	Valid          bool       `json:"valid"`
+	VolunteerName  string     `json:"volunteer_name"`
+	DepartmentName string     `json:"department_name"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	TotalHours     float64    `json:"total_hours"`
+	SignerName     string     `json:"signer_name"`
+	IssuedAt       time.Time  `json:"issued_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
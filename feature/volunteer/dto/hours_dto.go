@@ -0,0 +1,91 @@
+package dto
+
+import "time"
+
+// LogHoursDTO is the time a volunteer reports for a day. Hours logged for an
+// activity count for its department, otherwise for the department of the
+// volunteer; DepartmentID, when given, must be that department.
+type LogHoursDTO struct {
+	Date         string `json:"date" binding:"required,datetime=2006-01-02"`
+	ActivityID   *int   `json:"activity_id" binding:"omitempty,min=1"`
+	DepartmentID *int   `json:"department_id" binding:"omitempty,min=1"`
+	Minutes      int    `json:"minutes" binding:"required,min=1,max=1440"`
+	Notes        string `json:"notes" binding:"max=255"`
+}
+
+type ApproveHoursDTO struct {
+	Note string `json:"note" binding:"max=255"`
+}
+
+type DisputeHoursDTO struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type HoursEntryDTO struct {
+	ID             int        `json:"id"`
+	VolunteerID    int        `json:"volunteer_id"`
+	Name           string     `json:"name,omitempty"`
+	Surname        string     `json:"surname,omitempty"`
+	Date           string     `json:"date"`
+	ActivityID     *int       `json:"activity_id"`
+	DepartmentID   int        `json:"department_id"`
+	DepartmentName string     `json:"department_name,omitempty"`
+	Minutes        int        `json:"minutes"`
+	Notes          string     `json:"notes"`
+	Status         string     `json:"status"`
+	ReviewedBy     *int       `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote     string     `json:"review_note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ListHoursQuery filters hours entries, From and To are inclusive days.
+type ListHoursQuery struct {
+	Page         int        `form:"page" binding:"omitempty,min=1"`
+	PageSize     int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Status       string     `form:"status" binding:"omitempty,oneof=pending approved disputed"`
+	VolunteerID  *int       `form:"volunteer_id"`
+	DepartmentID *int       `form:"department_id"`
+	From         *time.Time `form:"from" time_format:"2006-01-02"`
+	To           *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+type ListHoursDTO struct {
+	Entries    []HoursEntryDTO `json:"entries"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	Total      int64           `json:"total"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// HoursTotalsQuery restricts the totals to a volunteer, a department and a
+// range of days, From and To are inclusive.
+type HoursTotalsQuery struct {
+	VolunteerID  *int       `form:"volunteer_id"`
+	DepartmentID *int       `form:"department_id"`
+	From         *time.Time `form:"from" time_format:"2006-01-02"`
+	To           *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// Only approved hours count towards ApprovedHours, the minutes of entries
+// waiting for review or disputed are reported apart.
+type VolunteerHoursTotalDTO struct {
+	VolunteerID     int     `json:"volunteer_id"`
+	Name            string  `json:"name"`
+	Surname         string  `json:"surname"`
+	ApprovedMinutes int     `json:"approved_minutes"`
+	ApprovedHours   float64 `json:"approved_hours"`
+	PendingMinutes  int     `json:"pending_minutes"`
+	DisputedMinutes int     `json:"disputed_minutes"`
+	Entries         int     `json:"entries"`
+}
+
+type DepartmentHoursTotalDTO struct {
+	DepartmentID    int     `json:"department_id"`
+	DepartmentName  string  `json:"department_name"`
+	ApprovedMinutes int     `json:"approved_minutes"`
+	ApprovedHours   float64 `json:"approved_hours"`
+	PendingMinutes  int     `json:"pending_minutes"`
+	DisputedMinutes int     `json:"disputed_minutes"`
+	Volunteers      int     `json:"volunteers"`
+}
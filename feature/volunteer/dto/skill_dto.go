@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// SkillDTO is what an admin fills in to create or update a skill.
+type SkillDTO struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Category    string `json:"category" binding:"max=50"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+type SkillResponseDTO struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// ListSkillQuery filters the catalog by category.
+type ListSkillQuery struct {
+	Category string `form:"category"`
+}
+
+// DeclareSkillDTO is what a volunteer says about a skill they have, for
+// instance the level of a language or the category of a driving licence.
+type DeclareSkillDTO struct {
+	Note string `json:"note" binding:"max=255"`
+}
+
+type VolunteerSkillDTO struct {
+	SkillID    int        `json:"skill_id"`
+	Name       string     `json:"name"`
+	Category   string     `json:"category"`
+	Note       string     `json:"note,omitempty"`
+	Verified   bool       `json:"verified"`
+	VerifiedBy *int       `json:"verified_by,omitempty"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// MatchQuery tunes the ranking of volunteers for an activity. AllSkills only
+// keeps volunteers holding every required skill and VerifiedOnly ignores the
+// skills that were not verified.
+type MatchQuery struct {
+	Limit        int  `form:"limit" binding:"omitempty,min=1,max=100"`
+	AllSkills    bool `form:"all_skills"`
+	VerifiedOnly bool `form:"verified_only"`
+}
+
+type MatchedSkillDTO struct {
+	SkillID  int    `json:"skill_id"`
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
+// VolunteerMatchDTO is a volunteer ranked for an activity, with what the
+// score is made of.
+type VolunteerMatchDTO struct {
+	VolunteerID     int               `json:"volunteer_id"`
+	UserID          int               `json:"user_id"`
+	Name            string            `json:"name"`
+	Surname         string            `json:"surname"`
+	DepartmentID    int               `json:"department_id"`
+	SameDepartment  bool              `json:"same_department"`
+	MatchedSkills   []MatchedSkillDTO `json:"matched_skills"`
+	MissingSkillIDs []int             `json:"missing_skill_ids"`
+	Availability    string            `json:"availability"`
+	Score           int               `json:"score"`
+}
@@ -1,19 +1,142 @@
 package dto
 
-type VolunteerCreateDTO struct {
-	UserID       int `json:"user_id" binding:"required"`
-	DepartmentID int `json:"department_id" binding:"required"`
-	Status       int `json:"status" binding:"required"`
-}
+import "time"
 
+// VolunteerUpdateDTO cannot change the status, volunteers are deactivated and
+// reactivated with a reason instead.
 type VolunteerUpdateDTO struct {
 	DepartmentID int `json:"department_id"`
-	Status       int `json:"status"`
 }
 
 type VolunteerResponseDTO struct {
-	ID           int `json:"id"`
-	UserID       int `json:"user_id"`
-	DepartmentID int `json:"department_id"`
-	Status       int `json:"status"`
+	ID           int        `json:"id"`
+	UserID       int        `json:"user_id"`
+	DepartmentID int        `json:"department_id"`
+	Status       int        `json:"status"`
+	OnboardedBy  *int       `json:"onboarded_by,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ListVolunteerQuery holds the search, filters, sort and pagination accepted
+// by the volunteer directory. Search matches the name, surname or email of
+// the volunteer, or their id when it is a number. Sort is a column name,
+// prefixed with "-" for descending order.
+type ListVolunteerQuery struct {
+	Page         int    `form:"page" binding:"omitempty,min=1"`
+	PageSize     int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Search       string `form:"search"`
+	Gender       string `form:"gender" binding:"omitempty,oneof=male female other"`
+	RoleID       *int   `form:"role_id"`
+	DepartmentID *int   `form:"department_id"`
+	PositionID   *int   `form:"position_id"`
+	Status       *int   `form:"status"`
+	Sort         string `form:"sort" binding:"omitempty,oneof=id -id name -name email -email department -department position -position created_at -created_at"`
+}
+
+type VolunteerListItemDTO struct {
+	ID             int       `json:"id"`
+	UserID         int       `json:"user_id"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Surname        string    `json:"surname"`
+	Gender         string    `json:"gender"`
+	Mobile         string    `json:"mobile"`
+	RoleID         int       `json:"role_id"`
+	RoleName       string    `json:"role_name"`
+	DepartmentID   int       `json:"department_id"`
+	DepartmentName string    `json:"department_name"`
+	PositionID     *int      `json:"position_id"`
+	PositionCode   string    `json:"position_code"`
+	PositionName   string    `json:"position_name"`
+	Status         int       `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ListVolunteerDTO struct {
+	Volunteers []VolunteerListItemDTO `json:"volunteers"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	Total      int64                  `json:"total"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+type AssignPositionDTO struct {
+	PositionID int    `json:"position_id" binding:"required,min=1"`
+	Notes      string `json:"notes" binding:"max=255"`
+}
+
+type PositionAssignmentDTO struct {
+	ID           int        `json:"id"`
+	VolunteerID  int        `json:"volunteer_id"`
+	PositionID   int        `json:"position_id"`
+	PositionCode string     `json:"position_code"`
+	PositionName string     `json:"position_name"`
+	AssignedBy   int        `json:"assigned_by"`
+	Notes        string     `json:"notes"`
+	StartedAt    time.Time  `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at"`
+}
+
+// OnboardVolunteerDTO is what an admin fills in to add a volunteer by hand,
+// their account, identity document and volunteer details.
+type OnboardVolunteerDTO struct {
+	Email             string             `json:"email" binding:"required,email,max=100"`
+	Name              string             `json:"name" binding:"required,max=45"`
+	Surname           string             `json:"surname" binding:"required,max=45"`
+	Gender            string             `json:"gender" binding:"required,oneof=male female other"`
+	DOB               string             `json:"dob" binding:"required,datetime=2006-01-02"`
+	Mobile            string             `json:"mobile" binding:"required,max=15"`
+	CountryID         int                `json:"country_id" binding:"required"`
+	ResidentCountryID int                `json:"resident_country_id" binding:"required"`
+	DepartmentID      int                `json:"department_id" binding:"required"`
+	Identity          OnboardIdentityDTO `json:"identity" binding:"required"`
+}
+
+type OnboardIdentityDTO struct {
+	Number      string `json:"number" binding:"required"`
+	Type        string `json:"type" binding:"required"`
+	ExpiryDate  string `json:"expiry_date" binding:"required,datetime=2006-01-02"`
+	PlaceIssued string `json:"place_issued" binding:"required"`
+}
+
+type OnboardVolunteerResponseDTO struct {
+	ID          int    `json:"id"`
+	UserID      int    `json:"user_id"`
+	IdentityID  int    `json:"identity_id"`
+	Email       string `json:"email"`
+	OnboardedBy int    `json:"onboarded_by"`
+}
+
+type VolunteerStatusChangeDTO struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type VolunteerStatusChangeResponseDTO struct {
+	ID          int       `json:"id"`
+	VolunteerID int       `json:"volunteer_id"`
+	Status      int       `json:"status"`
+	Reason      string    `json:"reason"`
+	ChangedBy   int       `json:"changed_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Events of the volunteer history.
+const (
+	HistoryPositionAssigned = "position_assigned"
+	HistoryPositionEnded    = "position_ended"
+	HistoryDeactivated      = "deactivated"
+	HistoryReactivated      = "reactivated"
+)
+
+// VolunteerHistoryEntryDTO is one event of the volunteer history. Position
+// fields are set for position events, By and Reason for the events an admin
+// gave a reason for.
+type VolunteerHistoryEntryDTO struct {
+	Event        string    `json:"event"`
+	At           time.Time `json:"at"`
+	By           *int      `json:"by,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	PositionID   int       `json:"position_id,omitempty"`
+	PositionCode string    `json:"position_code,omitempty"`
+	PositionName string    `json:"position_name,omitempty"`
 }
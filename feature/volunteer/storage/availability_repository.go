@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// AvailabilityRepositoryInterface defines the methods that an AvailabilityRepository should implement
+type AvailabilityRepositoryInterface interface {
+	FindVolunteerByUserID(userID int) (*domain.Volunteer, error)
+	ReplaceSlots(volunteerID int, slots []*domain.AvailabilitySlot) error
+	ListSlots(volunteerIDs []int) ([]*domain.AvailabilitySlot, error)
+	CreateException(exception *domain.AvailabilityException) error
+	DeleteException(volunteerID int, id int) error
+	ListExceptions(volunteerIDs []int, from time.Time, to *time.Time) ([]*domain.AvailabilityException, error)
+	ListActiveVolunteers(departmentID *int, volunteerID *int) ([]*domain.VolunteerListing, error)
+}
+
+type AvailabilityRepository struct {
+	DB *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{DB: db}
+}
+
+// FindVolunteerByUserID returns the volunteer record of the user.
+func (r *AvailabilityRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	var volunteer domain.Volunteer
+	if err := r.DB.Where("user_id = ?", userID).Take(&volunteer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+// ReplaceSlots replaces the weekly slots of the volunteer.
+func (r *AvailabilityRepository) ReplaceSlots(volunteerID int, slots []*domain.AvailabilitySlot) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("volunteer_id = ?", volunteerID).Delete(&domain.AvailabilitySlot{}).Error; err != nil {
+			return err
+		}
+		if len(slots) == 0 {
+			return nil
+		}
+		return tx.Create(slots).Error
+	})
+}
+
+// ListSlots returns the weekly slots of the volunteers by day and time.
+func (r *AvailabilityRepository) ListSlots(volunteerIDs []int) ([]*domain.AvailabilitySlot, error) {
+	slots := make([]*domain.AvailabilitySlot, 0)
+	err := r.DB.Where("volunteer_id IN ?", volunteerIDs).
+		Order("volunteer_id").
+		Order("weekday").
+		Order("start_time").
+		Find(&slots).Error
+	return slots, err
+}
+
+func (r *AvailabilityRepository) CreateException(exception *domain.AvailabilityException) error {
+	return r.DB.Create(exception).Error
+}
+
+// DeleteException deletes an exception of the volunteer.
+func (r *AvailabilityRepository) DeleteException(volunteerID int, id int) error {
+	result := r.DB.Where("volunteer_id = ?", volunteerID).Delete(&domain.AvailabilityException{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrExceptionNotFound
+	}
+	return nil
+}
+
+// ListExceptions returns the exceptions of the volunteers from the given day,
+// up to and including to when it is set, by day.
+func (r *AvailabilityRepository) ListExceptions(volunteerIDs []int, from time.Time, to *time.Time) ([]*domain.AvailabilityException, error) {
+	db := r.DB.Where("volunteer_id IN ? AND date >= ?", volunteerIDs, from)
+	if to != nil {
+		db = db.Where("date < ?", to.AddDate(0, 0, 1))
+	}
+	exceptions := make([]*domain.AvailabilityException, 0)
+	err := db.Order("date").Order("start_time").Order("id").Find(&exceptions).Error
+	return exceptions, err
+}
+
+// ListActiveVolunteers returns the active volunteers, of a department or a
+// single one when set, by name.
+func (r *AvailabilityRepository) ListActiveVolunteers(departmentID *int, volunteerID *int) ([]*domain.VolunteerListing, error) {
+	db := r.DB.Model(&domain.Volunteer{}).
+		Select("volunteer_details.id, volunteer_details.user_id, users.name, users.surname, volunteer_details.department_id, volunteer_details.status").
+		Joins("JOIN users ON users.id = volunteer_details.user_id AND users.deleted_at IS NULL").
+		Where("volunteer_details.status = ?", domain.VolunteerStatusActive)
+	if departmentID != nil {
+		db = db.Where("volunteer_details.department_id = ?", *departmentID)
+	}
+	if volunteerID != nil {
+		db = db.Where("volunteer_details.id = ?", *volunteerID)
+	}
+	volunteers := make([]*domain.VolunteerListing, 0)
+	err := db.Order("users.name").Order("users.surname").Order("volunteer_details.id").Scan(&volunteers).Error
+	return volunteers, err
+}
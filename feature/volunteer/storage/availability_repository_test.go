@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+func TestAvailabilitySlots(t *testing.T) {
+	db := setupDirectoryDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.AvailabilitySlot{}))
+	repo := NewAvailabilityRepository(db)
+
+	assert.NoError(t, repo.ReplaceSlots(1, []*domain.AvailabilitySlot{
+		{VolunteerID: 1, Weekday: 3, StartTime: "14:00", EndTime: "18:00"},
+		{VolunteerID: 1, Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+	}))
+	assert.NoError(t, repo.ReplaceSlots(2, []*domain.AvailabilitySlot{
+		{VolunteerID: 2, Weekday: 6, StartTime: "10:00", EndTime: "16:00"},
+	}))
+	assert.NoError(t, repo.ReplaceSlots(1, []*domain.AvailabilitySlot{
+		{VolunteerID: 1, Weekday: 2, StartTime: "08:00", EndTime: "10:00"},
+		{VolunteerID: 1, Weekday: 2, StartTime: "07:00", EndTime: "07:30"},
+	}))
+
+	slots, err := repo.ListSlots([]int{1})
+	assert.NoError(t, err)
+	assert.Len(t, slots, 2)
+	assert.Equal(t, "07:00", slots[0].StartTime)
+
+	assert.NoError(t, repo.ReplaceSlots(1, nil))
+	slots, err = repo.ListSlots([]int{1, 2})
+	assert.NoError(t, err)
+	assert.Len(t, slots, 1)
+	assert.Equal(t, 2, slots[0].VolunteerID)
+}
+
+func TestAvailabilityExceptions(t *testing.T) {
+	db := setupDirectoryDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.AvailabilityException{}))
+	repo := NewAvailabilityRepository(db)
+
+	for _, exception := range []*domain.AvailabilityException{
+		{VolunteerID: 1, Date: day("2024-03-01")},
+		{VolunteerID: 1, Date: day("2024-03-05"), StartTime: "10:00", EndTime: "12:00", Available: true},
+		{VolunteerID: 1, Date: day("2024-04-01")},
+		{VolunteerID: 2, Date: day("2024-03-02")},
+	} {
+		assert.NoError(t, repo.CreateException(exception))
+	}
+
+	to := day("2024-03-05")
+	exceptions, err := repo.ListExceptions([]int{1}, day("2024-03-01"), &to)
+	assert.NoError(t, err)
+	assert.Len(t, exceptions, 2)
+	assert.True(t, exceptions[1].Available)
+
+	exceptions, err = repo.ListExceptions([]int{1, 2}, day("2024-03-02"), nil)
+	assert.NoError(t, err)
+	assert.Len(t, exceptions, 3)
+
+	assert.ErrorIs(t, repo.DeleteException(2, exceptions[1].ID), domain.ErrExceptionNotFound)
+	assert.NoError(t, repo.DeleteException(1, exceptions[1].ID))
+}
+
+func TestListActiveVolunteers(t *testing.T) {
+	repo := NewAvailabilityRepository(setupDirectoryDB(t))
+
+	volunteers, err := repo.ListActiveVolunteers(nil, nil)
+	assert.NoError(t, err)
+	// Carl is inactive and Dan's user is deleted
+	assert.Len(t, volunteers, 2)
+	assert.Equal(t, "Anna", volunteers[0].Name)
+	assert.Equal(t, "Bob", volunteers[1].Name)
+
+	garden := 2
+	volunteers, err = repo.ListActiveVolunteers(&garden, nil)
+	assert.NoError(t, err)
+	assert.Len(t, volunteers, 1)
+	assert.Equal(t, 2, volunteers[0].DepartmentID)
+
+	anna := 1
+	volunteers, err = repo.ListActiveVolunteers(nil, &anna)
+	assert.NoError(t, err)
+	assert.Len(t, volunteers, 1)
+	assert.Equal(t, 1, volunteers[0].ID)
+}
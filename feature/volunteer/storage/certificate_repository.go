@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// CertificateRepositoryInterface defines the methods that a CertificateRepository should implement
+type CertificateRepositoryInterface interface {
+	CreateCertificate(certificate *domain.Certificate) error
+	FindCertificateByID(id int) (*domain.Certificate, error)
+	FindCertificateByCode(code string) (*domain.Certificate, error)
+	ListCertificates(volunteerID int) ([]*domain.Certificate, error)
+	RevokeCertificate(id int, revokedBy int, reason string) error
+	SumApprovedMinutes(volunteerID int, from time.Time, to time.Time) (int, error)
+	FindVolunteerListing(id int) (*domain.VolunteerListing, error)
+	FindVolunteerByUserID(userID int) (*domain.Volunteer, error)
+	FindUserName(userID int) (string, error)
+}
+
+type CertificateRepository struct {
+	DB *gorm.DB
+}
+
+func NewCertificateRepository(db *gorm.DB) *CertificateRepository {
+	return &CertificateRepository{DB: db}
+}
+
+func (r *CertificateRepository) CreateCertificate(certificate *domain.Certificate) error {
+	return r.DB.Create(certificate).Error
+}
+
+func (r *CertificateRepository) FindCertificateByID(id int) (*domain.Certificate, error) {
+	var certificate domain.Certificate
+	if err := r.DB.First(&certificate, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCertificateNotFound
+		}
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+func (r *CertificateRepository) FindCertificateByCode(code string) (*domain.Certificate, error) {
+	var certificate domain.Certificate
+	if err := r.DB.Where("code = ?", code).Take(&certificate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCertificateNotFound
+		}
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+// ListCertificates returns the certificates of the volunteer, the latest
+// first.
+func (r *CertificateRepository) ListCertificates(volunteerID int) ([]*domain.Certificate, error) {
+	certificates := make([]*domain.Certificate, 0)
+	err := r.DB.Where("volunteer_id = ?", volunteerID).
+		Order("issued_at DESC").
+		Order("id DESC").
+		Find(&certificates).Error
+	return certificates, err
+}
+
+// RevokeCertificate revokes a certificate that was not revoked yet.
+func (r *CertificateRepository) RevokeCertificate(id int, revokedBy int, reason string) error {
+	result := r.DB.Model(&domain.Certificate{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_by": revokedBy, "revoke_reason": reason})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.FindCertificateByID(id); err != nil {
+			return err
+		}
+		return domain.ErrCertificateRevoked
+	}
+	return nil
+}
+
+// SumApprovedMinutes returns the approved minutes the volunteer logged from
+// from to to, both days included.
+func (r *CertificateRepository) SumApprovedMinutes(volunteerID int, from time.Time, to time.Time) (int, error) {
+	var minutes int
+	err := filterHours(r.DB.Model(&domain.HoursEntry{}), &volunteerID, nil, &from, &to).
+		Where("volunteer_hours.status = ?", domain.HoursApproved).
+		Select("COALESCE(SUM(volunteer_hours.minutes), 0)").
+		Scan(&minutes).Error
+	return minutes, err
+}
+
+// FindVolunteerListing returns the volunteer with their name and the name of
+// their department.
+func (r *CertificateRepository) FindVolunteerListing(id int) (*domain.VolunteerListing, error) {
+	var volunteer domain.VolunteerListing
+	err := r.DB.Model(&domain.Volunteer{}).
+		Select("volunteer_details.id, volunteer_details.user_id, users.name, users.surname, volunteer_details.department_id, departments.name AS department_name, volunteer_details.status").
+		Joins("JOIN users ON users.id = volunteer_details.user_id AND users.deleted_at IS NULL").
+		Joins("LEFT JOIN departments ON departments.id = volunteer_details.department_id").
+		Where("volunteer_details.id = ?", id).
+		Take(&volunteer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+// FindVolunteerByUserID returns the volunteer record of the user.
+func (r *CertificateRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	var volunteer domain.Volunteer
+	if err := r.DB.Where("user_id = ?", userID).Take(&volunteer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+// FindUserName returns the full name of the user.
+func (r *CertificateRepository) FindUserName(userID int) (string, error) {
+	var user authDomain.User
+	if err := r.DB.Select("id", "name", "surname").First(&user, userID).Error; err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(user.Name + " " + user.Surname), nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+func TestCertificates(t *testing.T) {
+	db := setupDirectoryDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.HoursEntry{}, &domain.Certificate{}))
+	repo := NewCertificateRepository(db)
+
+	for _, entry := range []*domain.HoursEntry{
+		{VolunteerID: 1, Date: day("2024-03-01"), DepartmentID: 1, Minutes: 90, Status: domain.HoursApproved},
+		{VolunteerID: 1, Date: day("2024-03-31"), DepartmentID: 1, Minutes: 60, Status: domain.HoursApproved},
+		{VolunteerID: 1, Date: day("2024-03-15"), DepartmentID: 1, Minutes: 45, Status: domain.HoursPending},
+		{VolunteerID: 1, Date: day("2024-04-01"), DepartmentID: 1, Minutes: 30, Status: domain.HoursApproved},
+		{VolunteerID: 2, Date: day("2024-03-10"), DepartmentID: 2, Minutes: 120, Status: domain.HoursApproved},
+	} {
+		assert.NoError(t, db.Create(entry).Error)
+	}
+
+	t.Run("approved minutes", func(t *testing.T) {
+		minutes, err := repo.SumApprovedMinutes(1, day("2024-03-01"), day("2024-03-31"))
+		assert.NoError(t, err)
+		assert.Equal(t, 150, minutes)
+
+		minutes, err = repo.SumApprovedMinutes(1, day("2024-05-01"), day("2024-05-31"))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, minutes)
+	})
+
+	t.Run("volunteer listing", func(t *testing.T) {
+		volunteer, err := repo.FindVolunteerListing(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Anna", volunteer.Name)
+		assert.Equal(t, "Kitchen", volunteer.DepartmentName)
+
+		_, err = repo.FindVolunteerListing(4)
+		assert.ErrorIs(t, err, domain.ErrVolunteerNotFound)
+	})
+
+	t.Run("issue and revoke", func(t *testing.T) {
+		issuedAt := time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)
+		first := &domain.Certificate{Code: "AAAA-BBBB-CCCC-DDDD", VolunteerID: 1, VolunteerName: "Anna Smith",
+			DepartmentID: 1, DepartmentName: "Kitchen", PeriodFrom: day("2024-03-01"), PeriodTo: day("2024-03-31"),
+			TotalMinutes: 150, SignedBy: 3, SignerName: "Carl Annan", IssuedAt: issuedAt}
+		second := *first
+		second.Code = "EEEE-FFFF-GGGG-HHHH"
+		second.IssuedAt = issuedAt.Add(time.Hour)
+		assert.NoError(t, repo.CreateCertificate(first))
+		assert.NoError(t, repo.CreateCertificate(&second))
+
+		certificates, err := repo.ListCertificates(1)
+		assert.NoError(t, err)
+		if assert.Len(t, certificates, 2) {
+			assert.Equal(t, second.ID, certificates[0].ID)
+		}
+
+		assert.NoError(t, repo.RevokeCertificate(first.ID, 3, "Hours were disputed"))
+		assert.ErrorIs(t, repo.RevokeCertificate(first.ID, 3, "again"), domain.ErrCertificateRevoked)
+		assert.ErrorIs(t, repo.RevokeCertificate(99, 3, "missing"), domain.ErrCertificateNotFound)
+
+		certificate, err := repo.FindCertificateByCode("AAAA-BBBB-CCCC-DDDD")
+		assert.NoError(t, err)
+		assert.NotNil(t, certificate.RevokedAt)
+		assert.Equal(t, "Hours were disputed", certificate.RevokeReason)
+
+		_, err = repo.FindCertificateByCode("ZZZZ-ZZZZ-ZZZZ-ZZZZ")
+		assert.ErrorIs(t, err, domain.ErrCertificateNotFound)
+	})
+}
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+)
+
+// HoursRepositoryInterface defines the methods that an HoursRepository should implement
+type HoursRepositoryInterface interface {
+	CreateHours(entry *domain.HoursEntry) error
+	UpdateHours(entry *domain.HoursEntry) error
+	DeleteHours(id int) error
+	FindHoursByID(id int) (*domain.HoursEntry, error)
+	ListHours(query dto.ListHoursQuery) ([]*domain.HoursEntry, int64, error)
+	ReviewHours(id int, status string, reviewerID int, note string) error
+	VolunteerTotals(query dto.HoursTotalsQuery) ([]*domain.VolunteerHoursTotal, error)
+	DepartmentTotals(query dto.HoursTotalsQuery) ([]*domain.DepartmentHoursTotal, error)
+	FindVolunteerByUserID(userID int) (*domain.Volunteer, error)
+	FindSignedUpActivity(activityID int, volunteerID int) (*activityDomain.Activity, error)
+}
+
+type HoursRepository struct {
+	DB *gorm.DB
+}
+
+func NewHoursRepository(db *gorm.DB) *HoursRepository {
+	return &HoursRepository{DB: db}
+}
+
+func (r *HoursRepository) CreateHours(entry *domain.HoursEntry) error {
+	return r.DB.Create(entry).Error
+}
+
+// UpdateHours saves a correction of the volunteer, which needs a new review.
+// Approved entries are left untouched.
+func (r *HoursRepository) UpdateHours(entry *domain.HoursEntry) error {
+	result := r.DB.Model(&domain.HoursEntry{}).
+		Where("id = ? AND status <> ?", entry.ID, domain.HoursApproved).
+		Updates(map[string]interface{}{
+			"date":          entry.Date,
+			"activity_id":   entry.ActivityID,
+			"department_id": entry.DepartmentID,
+			"minutes":       entry.Minutes,
+			"notes":         entry.Notes,
+			"status":        domain.HoursPending,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrHoursApproved
+	}
+	return nil
+}
+
+// DeleteHours deletes an entry that was not approved.
+func (r *HoursRepository) DeleteHours(id int) error {
+	result := r.DB.Where("status <> ?", domain.HoursApproved).Delete(&domain.HoursEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrHoursApproved
+	}
+	return nil
+}
+
+func (r *HoursRepository) FindHoursByID(id int) (*domain.HoursEntry, error) {
+	var entry domain.HoursEntry
+	if err := r.hoursWithNames(r.DB).First(&entry, "volunteer_hours.id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrHoursNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListHours returns one page of the entries matching the query, the latest
+// day first, together with the number of matching entries. Page and PageSize
+// must be set.
+func (r *HoursRepository) ListHours(query dto.ListHoursQuery) ([]*domain.HoursEntry, int64, error) {
+	db := r.DB.Model(&domain.HoursEntry{})
+	if query.Status != "" {
+		db = db.Where("volunteer_hours.status = ?", query.Status)
+	}
+	db = filterHours(db, query.VolunteerID, query.DepartmentID, query.From, query.To)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	entries := make([]*domain.HoursEntry, 0)
+	err := r.hoursWithNames(db).
+		Order("volunteer_hours.date DESC").
+		Order("volunteer_hours.id DESC").
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// ReviewHours gives a pending entry the status decided by the reviewer.
+func (r *HoursRepository) ReviewHours(id int, status string, reviewerID int, note string) error {
+	result := r.DB.Model(&domain.HoursEntry{}).
+		Where("id = ? AND status = ?", id, domain.HoursPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+			"review_note": note,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrHoursNotPending
+	}
+	return nil
+}
+
+// hoursMinutes sums the minutes of the selected entries by status.
+const hoursMinutes = "SUM(CASE WHEN volunteer_hours.status = 'approved' THEN volunteer_hours.minutes ELSE 0 END) AS approved_minutes, " +
+	"SUM(CASE WHEN volunteer_hours.status = 'pending' THEN volunteer_hours.minutes ELSE 0 END) AS pending_minutes, " +
+	"SUM(CASE WHEN volunteer_hours.status = 'disputed' THEN volunteer_hours.minutes ELSE 0 END) AS disputed_minutes"
+
+// VolunteerTotals sums the hours of every volunteer matching the query, the
+// most approved hours first.
+func (r *HoursRepository) VolunteerTotals(query dto.HoursTotalsQuery) ([]*domain.VolunteerHoursTotal, error) {
+	totals := make([]*domain.VolunteerHoursTotal, 0)
+	err := filterHours(r.DB.Model(&domain.HoursEntry{}), query.VolunteerID, query.DepartmentID, query.From, query.To).
+		Select("volunteer_hours.volunteer_id, users.name, users.surname, COUNT(*) AS entries, " + hoursMinutes).
+		Joins("JOIN volunteer_details ON volunteer_details.id = volunteer_hours.volunteer_id").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Group("volunteer_hours.volunteer_id, users.name, users.surname").
+		Order("approved_minutes DESC").
+		Order("volunteer_hours.volunteer_id").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// DepartmentTotals sums the hours logged for every department matching the
+// query, the most approved hours first.
+func (r *HoursRepository) DepartmentTotals(query dto.HoursTotalsQuery) ([]*domain.DepartmentHoursTotal, error) {
+	totals := make([]*domain.DepartmentHoursTotal, 0)
+	err := filterHours(r.DB.Model(&domain.HoursEntry{}), query.VolunteerID, query.DepartmentID, query.From, query.To).
+		Select("volunteer_hours.department_id, departments.name AS department_name, COUNT(DISTINCT volunteer_hours.volunteer_id) AS volunteers, " + hoursMinutes).
+		Joins("JOIN departments ON departments.id = volunteer_hours.department_id").
+		Group("volunteer_hours.department_id, departments.name").
+		Order("approved_minutes DESC").
+		Order("volunteer_hours.department_id").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// FindVolunteerByUserID returns the volunteer record of the user.
+func (r *HoursRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	var volunteer domain.Volunteer
+	if err := r.DB.Where("user_id = ?", userID).Take(&volunteer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+// FindSignedUpActivity returns the activity if the volunteer's sign-up for it
+// is confirmed.
+func (r *HoursRepository) FindSignedUpActivity(activityID int, volunteerID int) (*activityDomain.Activity, error) {
+	var activity activityDomain.Activity
+	if err := r.DB.First(&activity, activityID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, activityDomain.ErrActivityNotFound
+		}
+		return nil, err
+	}
+	var confirmed int64
+	if err := r.DB.Model(&activityDomain.Signup{}).
+		Where("activity_id = ? AND volunteer_id = ? AND status = ?", activityID, volunteerID, activityDomain.SignupConfirmed).
+		Count(&confirmed).Error; err != nil {
+		return nil, err
+	}
+	if confirmed == 0 {
+		return nil, domain.ErrNotSignedUp
+	}
+	return &activity, nil
+}
+
+func (r *HoursRepository) hoursWithNames(db *gorm.DB) *gorm.DB {
+	return db.Select("volunteer_hours.*, users.id AS user_id, users.name, users.surname, departments.name AS department_name").
+		Joins("JOIN volunteer_details ON volunteer_details.id = volunteer_hours.volunteer_id").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Joins("LEFT JOIN departments ON departments.id = volunteer_hours.department_id")
+}
+
+func filterHours(db *gorm.DB, volunteerID *int, departmentID *int, from *time.Time, to *time.Time) *gorm.DB {
+	if volunteerID != nil {
+		db = db.Where("volunteer_hours.volunteer_id = ?", *volunteerID)
+	}
+	if departmentID != nil {
+		db = db.Where("volunteer_hours.department_id = ?", *departmentID)
+	}
+	if from != nil {
+		db = db.Where("volunteer_hours.date >= ?", *from)
+	}
+	if to != nil {
+		db = db.Where("volunteer_hours.date < ?", to.AddDate(0, 0, 1))
+	}
+	return db
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+)
+
+// setupHoursDB returns a database with the volunteers 1 (Anna, Kitchen) and
+// 2 (Bob, Garden), and activity 1 of the Garden Anna is confirmed for.
+func setupHoursDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &authDomain.User{}, &domain.Volunteer{}, &domain.HoursEntry{},
+		&activityDomain.Activity{}, &activityDomain.Signup{})
+	testdb.Exec(t, db,
+		"CREATE TABLE departments (id integer primary key, name text)",
+		"INSERT INTO departments (id, name) VALUES (1, 'Kitchen'), (2, 'Garden')")
+	for i, name := range []string{"Anna", "Bob"} {
+		user := &authDomain.User{Email: name + "@example.com", Password: "hash", Name: name, Surname: "Smith", Gender: "other", Mobile: "123", Status: 1}
+		assert.NoError(t, db.Create(user).Error)
+		assert.NoError(t, db.Create(&domain.Volunteer{UserID: user.ID, DepartmentID: i + 1, Status: 1}).Error)
+	}
+	starts := time.Now().AddDate(0, 0, -2)
+	assert.NoError(t, db.Create(&activityDomain.Activity{Title: "Planting", DepartmentID: 2, Location: "Park",
+		StartsAt: starts, EndsAt: starts.Add(3 * time.Hour), Capacity: 5, CreatedBy: 1}).Error)
+	assert.NoError(t, db.Create(&activityDomain.Signup{ActivityID: 1, VolunteerID: 1, Status: activityDomain.SignupConfirmed}).Error)
+	return db
+}
+
+func day(value string) time.Time {
+	date, _ := time.Parse(time.DateOnly, value)
+	return date
+}
+
+func TestHoursLifecycle(t *testing.T) {
+	repo := NewHoursRepository(setupHoursDB(t))
+
+	entry := &domain.HoursEntry{VolunteerID: 1, Date: day("2024-03-01"), DepartmentID: 1, Minutes: 90, Status: domain.HoursPending}
+	assert.NoError(t, repo.CreateHours(entry))
+
+	found, err := repo.FindHoursByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Anna", found.Name)
+	assert.Equal(t, "Kitchen", found.DepartmentName)
+	assert.Equal(t, "2024-03-01", found.Date.Format(time.DateOnly))
+
+	assert.NoError(t, repo.ReviewHours(entry.ID, domain.HoursDisputed, 2, "too long"))
+	assert.ErrorIs(t, repo.ReviewHours(entry.ID, domain.HoursApproved, 2, ""), domain.ErrHoursNotPending)
+
+	entry.Minutes = 60
+	assert.NoError(t, repo.UpdateHours(entry))
+	found, err = repo.FindHoursByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.HoursPending, found.Status)
+	assert.Equal(t, 60, found.Minutes)
+
+	assert.NoError(t, repo.ReviewHours(entry.ID, domain.HoursApproved, 2, ""))
+	assert.ErrorIs(t, repo.UpdateHours(entry), domain.ErrHoursApproved)
+	assert.ErrorIs(t, repo.DeleteHours(entry.ID), domain.ErrHoursApproved)
+
+	other := &domain.HoursEntry{VolunteerID: 1, Date: day("2024-03-02"), DepartmentID: 1, Minutes: 30, Status: domain.HoursPending}
+	assert.NoError(t, repo.CreateHours(other))
+	assert.NoError(t, repo.DeleteHours(other.ID))
+	_, err = repo.FindHoursByID(other.ID)
+	assert.ErrorIs(t, err, domain.ErrHoursNotFound)
+}
+
+func TestListHoursAndTotals(t *testing.T) {
+	repo := NewHoursRepository(setupHoursDB(t))
+	activityID := 1
+	for _, entry := range []*domain.HoursEntry{
+		{VolunteerID: 1, Date: day("2024-03-01"), DepartmentID: 1, Minutes: 60, Status: domain.HoursApproved},
+		{VolunteerID: 1, Date: day("2024-03-05"), DepartmentID: 2, ActivityID: &activityID, Minutes: 120, Status: domain.HoursApproved},
+		{VolunteerID: 1, Date: day("2024-04-01"), DepartmentID: 1, Minutes: 30, Status: domain.HoursPending},
+		{VolunteerID: 2, Date: day("2024-03-10"), DepartmentID: 2, Minutes: 45, Status: domain.HoursDisputed},
+	} {
+		assert.NoError(t, repo.CreateHours(entry))
+	}
+
+	from, to := day("2024-03-01"), day("2024-03-31")
+	entries, total, err := repo.ListHours(dto.ListHoursQuery{Page: 1, PageSize: 2, From: &from, To: &to})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "2024-03-10", entries[0].Date.Format(time.DateOnly))
+	assert.Equal(t, "Bob", entries[0].Name)
+
+	garden := 2
+	entries, total, err = repo.ListHours(dto.ListHoursQuery{Page: 1, PageSize: 10, DepartmentID: &garden, Status: domain.HoursApproved})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 120, entries[0].Minutes)
+
+	volunteers, err := repo.VolunteerTotals(dto.HoursTotalsQuery{From: &from, To: &to})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.VolunteerHoursTotal{
+		{VolunteerID: 1, Name: "Anna", Surname: "Smith", ApprovedMinutes: 180, Entries: 2},
+		{VolunteerID: 2, Name: "Bob", Surname: "Smith", DisputedMinutes: 45, Entries: 1},
+	}, volunteers)
+
+	departments, err := repo.DepartmentTotals(dto.HoursTotalsQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.DepartmentHoursTotal{
+		{DepartmentID: 2, DepartmentName: "Garden", ApprovedMinutes: 120, DisputedMinutes: 45, Volunteers: 2},
+		{DepartmentID: 1, DepartmentName: "Kitchen", ApprovedMinutes: 60, PendingMinutes: 30, Volunteers: 1},
+	}, departments)
+}
+
+func TestFindSignedUpActivity(t *testing.T) {
+	repo := NewHoursRepository(setupHoursDB(t))
+
+	activity, err := repo.FindSignedUpActivity(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, activity.DepartmentID)
+
+	_, err = repo.FindSignedUpActivity(1, 2)
+	assert.ErrorIs(t, err, domain.ErrNotSignedUp)
+
+	_, err = repo.FindSignedUpActivity(9, 1)
+	assert.ErrorIs(t, err, activityDomain.ErrActivityNotFound)
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// OnboardingRepositoryInterface defines the methods that an OnboardingRepository should implement
+type OnboardingRepositoryInterface interface {
+	OnboardVolunteer(user *authDomain.User, identity *identityDomain.UserIdentity, volunteer *domain.Volunteer, setupToken *authDomain.PasswordResetToken, setupEmail mailer.Message) error
+}
+
+// OnboardingRepository adds volunteers on behalf of an admin.
+type OnboardingRepository struct {
+	DB *gorm.DB
+}
+
+func NewOnboardingRepository(db *gorm.DB) *OnboardingRepository {
+	return &OnboardingRepository{DB: db}
+}
+
+// OnboardVolunteer creates the verified account of a volunteer with the
+// volunteer role, their identity document, their volunteer details and the
+// token of their account setup link, and queues the setup email, all in one
+// transaction. The ids of the created rows are set on the arguments.
+func (r *OnboardingRepository) OnboardVolunteer(user *authDomain.User, identity *identityDomain.UserIdentity, volunteer *domain.Volunteer, setupToken *authDomain.PasswordResetToken, setupEmail mailer.Message) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// deleted accounts keep their email until they are purged
+		var taken int64
+		if err := tx.Unscoped().Model(&authDomain.User{}).Where("LOWER(email) = LOWER(?)", user.Email).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return domain.ErrEmailTaken
+		}
+		var role roleDomain.Role
+		if err := tx.Where("name = ?", roleDomain.RoleVolunteer).First(&role).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		user.RoleID = int(role.Id)
+		user.VerificationStatus = 1
+		user.EmailVerifiedAt = &now
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		if err := tx.Create(identity).Error; err != nil {
+			return err
+		}
+		volunteer.UserID = user.ID
+		if err := tx.Create(volunteer).Error; err != nil {
+			return err
+		}
+		setupToken.UserID = user.ID
+		if err := tx.Create(setupToken).Error; err != nil {
+			return err
+		}
+		return mailer.Enqueue(tx, setupEmail)
+	})
+}
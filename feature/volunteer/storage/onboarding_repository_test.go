@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+func setupOnboardingDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &roleDomain.Role{}, &authDomain.User{}, &identityDomain.UserIdentity{}, &domain.Volunteer{}, &authDomain.PasswordResetToken{}, &mailer.OutboxEmail{})
+	assert.NoError(t, db.Create(&roleDomain.Role{Id: 3, Name: roleDomain.RoleVolunteer, Status: 1}).Error)
+	return db
+}
+
+func newOnboarding(email string) (*authDomain.User, *identityDomain.UserIdentity, *domain.Volunteer, *authDomain.PasswordResetToken, mailer.Message) {
+	adminID := 1
+	return &authDomain.User{Email: email, Password: "hash", Name: "Anna", Surname: "Smith", Gender: "female", Mobile: "123", Status: 1},
+		&identityDomain.UserIdentity{Number: "X1", Type: "passport", Status: 1, ExpiryDate: time.Now().AddDate(5, 0, 0), PlaceIssued: "Hanoi"},
+		&domain.Volunteer{DepartmentID: 2, Status: 1, OnboardedBy: &adminID},
+		&authDomain.PasswordResetToken{TokenHash: "token-" + email, ExpiresAt: time.Now().Add(time.Hour)},
+		mailer.Message{To: email, Subject: "Set up your account", Body: "link"}
+}
+
+func TestOnboardVolunteer(t *testing.T) {
+	db := setupOnboardingDB(t)
+	repo := NewOnboardingRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		user, identity, volunteer, token, msg := newOnboarding("anna@example.com")
+		assert.NoError(t, repo.OnboardVolunteer(user, identity, volunteer, token, msg))
+
+		var saved authDomain.User
+		assert.NoError(t, db.First(&saved, user.ID).Error)
+		assert.Equal(t, 3, saved.RoleID)
+		assert.Equal(t, 1, saved.VerificationStatus)
+		assert.NotNil(t, saved.EmailVerifiedAt)
+		assert.Equal(t, user.ID, identity.UserID)
+		assert.Equal(t, user.ID, volunteer.UserID)
+		assert.Equal(t, user.ID, token.UserID)
+
+		var savedVolunteer domain.Volunteer
+		assert.NoError(t, db.First(&savedVolunteer, volunteer.ID).Error)
+		if assert.NotNil(t, savedVolunteer.OnboardedBy) {
+			assert.Equal(t, 1, *savedVolunteer.OnboardedBy)
+		}
+		var outbox []mailer.OutboxEmail
+		assert.NoError(t, db.Find(&outbox).Error)
+		if assert.Len(t, outbox, 1) {
+			assert.Equal(t, "anna@example.com", outbox[0].Recipient)
+		}
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		user, identity, volunteer, token, msg := newOnboarding("Anna@Example.com")
+		assert.ErrorIs(t, repo.OnboardVolunteer(user, identity, volunteer, token, msg), domain.ErrEmailTaken)
+
+		var users, outbox int64
+		db.Model(&authDomain.User{}).Count(&users)
+		db.Model(&mailer.OutboxEmail{}).Count(&outbox)
+		assert.Equal(t, int64(1), users)
+		assert.Equal(t, int64(1), outbox)
+	})
+
+	t.Run("rolled back on failure", func(t *testing.T) {
+		user, identity, volunteer, token, msg := newOnboarding("bob@example.com")
+		token.TokenHash = "token-anna@example.com"
+		assert.Error(t, repo.OnboardVolunteer(user, identity, volunteer, token, msg))
+
+		var users, volunteers int64
+		db.Model(&authDomain.User{}).Count(&users)
+		db.Model(&domain.Volunteer{}).Count(&volunteers)
+		assert.Equal(t, int64(1), users)
+		assert.Equal(t, int64(1), volunteers)
+	})
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// SkillRepositoryInterface defines the methods that a SkillRepository should implement
+type SkillRepositoryInterface interface {
+	CreateSkill(skill *domain.Skill) error
+	UpdateSkill(skill *domain.Skill) error
+	DeleteSkill(id int) error
+	FindSkillByID(id int) (*domain.Skill, error)
+	ListSkills(category string) ([]*domain.Skill, error)
+	FindVolunteerSkill(volunteerID int, skillID int) (*domain.VolunteerSkill, error)
+	ListVolunteerSkills(volunteerIDs []int) ([]*domain.VolunteerSkill, error)
+	SaveVolunteerSkill(skill *domain.VolunteerSkill) error
+	DeleteVolunteerSkill(volunteerID int, skillID int) error
+	VerifyVolunteerSkill(volunteerID int, skillID int, verifiedBy *int) error
+	FindVolunteerByID(id int) (*domain.Volunteer, error)
+	FindVolunteerByUserID(userID int) (*domain.Volunteer, error)
+	FindActivity(id int) (*activityDomain.Activity, error)
+	ListSignedUpVolunteerIDs(activityID int) ([]int, error)
+}
+
+type SkillRepository struct {
+	DB *gorm.DB
+}
+
+func NewSkillRepository(db *gorm.DB) *SkillRepository {
+	return &SkillRepository{DB: db}
+}
+
+// CreateSkill adds a skill to the catalog, names are unique regardless of
+// case.
+func (r *SkillRepository) CreateSkill(skill *domain.Skill) error {
+	if err := r.checkName(skill); err != nil {
+		return err
+	}
+	return r.DB.Create(skill).Error
+}
+
+func (r *SkillRepository) UpdateSkill(skill *domain.Skill) error {
+	if err := r.checkName(skill); err != nil {
+		return err
+	}
+	return r.DB.Save(skill).Error
+}
+
+// DeleteSkill deletes a skill that no volunteer declared and no activity
+// requires.
+func (r *SkillRepository) DeleteSkill(id int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var declared, required int64
+		if err := tx.Model(&domain.VolunteerSkill{}).Where("skill_id = ?", id).Count(&declared).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&activityDomain.ActivitySkill{}).Where("skill_id = ?", id).Count(&required).Error; err != nil {
+			return err
+		}
+		if declared > 0 || required > 0 {
+			return domain.ErrSkillInUse
+		}
+		result := tx.Delete(&domain.Skill{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrSkillNotFound
+		}
+		return nil
+	})
+}
+
+func (r *SkillRepository) FindSkillByID(id int) (*domain.Skill, error) {
+	var skill domain.Skill
+	if err := r.DB.First(&skill, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSkillNotFound
+		}
+		return nil, err
+	}
+	return &skill, nil
+}
+
+// ListSkills returns the skills of the catalog, of a category when set, by
+// category and name.
+func (r *SkillRepository) ListSkills(category string) ([]*domain.Skill, error) {
+	db := r.DB.Model(&domain.Skill{})
+	if category != "" {
+		db = db.Where("category = ?", category)
+	}
+	skills := make([]*domain.Skill, 0)
+	err := db.Order("category").Order("name").Find(&skills).Error
+	return skills, err
+}
+
+// volunteerSkillColumns selects a declared skill with its name and category.
+const volunteerSkillColumns = "volunteer_skills.*, skills.name AS skill_name, skills.category AS skill_category"
+
+func (r *SkillRepository) FindVolunteerSkill(volunteerID int, skillID int) (*domain.VolunteerSkill, error) {
+	var skill domain.VolunteerSkill
+	err := r.DB.Model(&domain.VolunteerSkill{}).
+		Select(volunteerSkillColumns).
+		Joins("JOIN skills ON skills.id = volunteer_skills.skill_id").
+		Where("volunteer_skills.volunteer_id = ? AND volunteer_skills.skill_id = ?", volunteerID, skillID).
+		Take(&skill).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerSkillNotFound
+		}
+		return nil, err
+	}
+	return &skill, nil
+}
+
+// ListVolunteerSkills returns the skills the volunteers declared, by
+// volunteer, category and name.
+func (r *SkillRepository) ListVolunteerSkills(volunteerIDs []int) ([]*domain.VolunteerSkill, error) {
+	skills := make([]*domain.VolunteerSkill, 0)
+	err := r.DB.Model(&domain.VolunteerSkill{}).
+		Select(volunteerSkillColumns).
+		Joins("JOIN skills ON skills.id = volunteer_skills.skill_id").
+		Where("volunteer_skills.volunteer_id IN ?", volunteerIDs).
+		Order("volunteer_skills.volunteer_id").
+		Order("skills.category").
+		Order("skills.name").
+		Find(&skills).Error
+	return skills, err
+}
+
+// SaveVolunteerSkill creates or replaces the declaration of a skill.
+func (r *SkillRepository) SaveVolunteerSkill(skill *domain.VolunteerSkill) error {
+	return r.DB.Save(skill).Error
+}
+
+func (r *SkillRepository) DeleteVolunteerSkill(volunteerID int, skillID int) error {
+	result := r.DB.Where("volunteer_id = ? AND skill_id = ?", volunteerID, skillID).Delete(&domain.VolunteerSkill{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrVolunteerSkillNotFound
+	}
+	return nil
+}
+
+// VerifyVolunteerSkill records who verified a declared skill and when, a nil
+// verifiedBy withdraws the verification.
+func (r *SkillRepository) VerifyVolunteerSkill(volunteerID int, skillID int, verifiedBy *int) error {
+	var verifiedAt *time.Time
+	if verifiedBy != nil {
+		now := time.Now()
+		verifiedAt = &now
+	}
+	result := r.DB.Model(&domain.VolunteerSkill{}).
+		Where("volunteer_id = ? AND skill_id = ?", volunteerID, skillID).
+		Updates(map[string]interface{}{"verified_by": verifiedBy, "verified_at": verifiedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrVolunteerSkillNotFound
+	}
+	return nil
+}
+
+func (r *SkillRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
+	var volunteer domain.Volunteer
+	if err := r.DB.First(&volunteer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+// FindVolunteerByUserID returns the volunteer record of the user.
+func (r *SkillRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	var volunteer domain.Volunteer
+	if err := r.DB.Where("user_id = ?", userID).Take(&volunteer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+// FindActivity returns the activity with the skills it requires.
+func (r *SkillRepository) FindActivity(id int) (*activityDomain.Activity, error) {
+	var activity activityDomain.Activity
+	if err := r.DB.First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, activityDomain.ErrActivityNotFound
+		}
+		return nil, err
+	}
+	activity.SkillIDs = make([]int, 0)
+	if err := r.DB.Model(&activityDomain.ActivitySkill{}).
+		Where("activity_id = ?", id).
+		Order("skill_id").
+		Pluck("skill_id", &activity.SkillIDs).Error; err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// ListSignedUpVolunteerIDs returns the volunteers confirmed or waitlisted for
+// the activity.
+func (r *SkillRepository) ListSignedUpVolunteerIDs(activityID int) ([]int, error) {
+	ids := make([]int, 0)
+	err := r.DB.Model(&activityDomain.Signup{}).
+		Where("activity_id = ? AND status <> ?", activityID, activityDomain.SignupCancelled).
+		Pluck("volunteer_id", &ids).Error
+	return ids, err
+}
+
+func (r *SkillRepository) checkName(skill *domain.Skill) error {
+	var taken int64
+	if err := r.DB.Model(&domain.Skill{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", skill.Name, skill.ID).
+		Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return domain.ErrSkillNameTaken
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+func TestSkillCatalog(t *testing.T) {
+	db := setupDirectoryDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.Skill{}, &domain.VolunteerSkill{}, &activityDomain.ActivitySkill{}))
+	repo := NewSkillRepository(db)
+
+	firstAid := &domain.Skill{Name: "First aid", Category: "health"}
+	assert.NoError(t, repo.CreateSkill(firstAid))
+	assert.NoError(t, repo.CreateSkill(&domain.Skill{Name: "Spanish", Category: "language"}))
+	assert.ErrorIs(t, repo.CreateSkill(&domain.Skill{Name: "first AID"}), domain.ErrSkillNameTaken)
+
+	firstAid.Description = "Certified first aider"
+	assert.NoError(t, repo.UpdateSkill(firstAid))
+
+	skills, err := repo.ListSkills("")
+	assert.NoError(t, err)
+	if assert.Len(t, skills, 2) {
+		assert.Equal(t, "First aid", skills[0].Name)
+		assert.Equal(t, "Certified first aider", skills[0].Description)
+	}
+	skills, err = repo.ListSkills("language")
+	assert.NoError(t, err)
+	assert.Len(t, skills, 1)
+
+	assert.NoError(t, repo.SaveVolunteerSkill(&domain.VolunteerSkill{VolunteerID: 1, SkillID: firstAid.ID}))
+	assert.ErrorIs(t, repo.DeleteSkill(firstAid.ID), domain.ErrSkillInUse)
+	assert.NoError(t, repo.DeleteSkill(skills[0].ID))
+	assert.ErrorIs(t, repo.DeleteSkill(skills[0].ID), domain.ErrSkillNotFound)
+
+	_, err = repo.FindSkillByID(skills[0].ID)
+	assert.ErrorIs(t, err, domain.ErrSkillNotFound)
+}
+
+func TestVolunteerSkills(t *testing.T) {
+	db := setupDirectoryDB(t)
+	assert.NoError(t, db.AutoMigrate(&domain.Skill{}, &domain.VolunteerSkill{}))
+	repo := NewSkillRepository(db)
+	driving := &domain.Skill{Name: "Driving licence", Category: "transport"}
+	spanish := &domain.Skill{Name: "Spanish", Category: "language"}
+	assert.NoError(t, repo.CreateSkill(driving))
+	assert.NoError(t, repo.CreateSkill(spanish))
+
+	assert.NoError(t, repo.SaveVolunteerSkill(&domain.VolunteerSkill{VolunteerID: 1, SkillID: driving.ID, Note: "B"}))
+	assert.NoError(t, repo.SaveVolunteerSkill(&domain.VolunteerSkill{VolunteerID: 1, SkillID: spanish.ID, Note: "C1"}))
+	assert.NoError(t, repo.SaveVolunteerSkill(&domain.VolunteerSkill{VolunteerID: 2, SkillID: spanish.ID}))
+
+	admin := 3
+	assert.NoError(t, repo.VerifyVolunteerSkill(1, spanish.ID, &admin))
+	skill, err := repo.FindVolunteerSkill(1, spanish.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Spanish", skill.SkillName)
+	assert.Equal(t, 3, *skill.VerifiedBy)
+	assert.NotNil(t, skill.VerifiedAt)
+
+	skills, err := repo.ListVolunteerSkills([]int{1})
+	assert.NoError(t, err)
+	if assert.Len(t, skills, 2) {
+		assert.Equal(t, "C1", skills[0].Note)
+		assert.Equal(t, "transport", skills[1].SkillCategory)
+	}
+
+	assert.NoError(t, repo.VerifyVolunteerSkill(1, spanish.ID, nil))
+	skill, err = repo.FindVolunteerSkill(1, spanish.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, skill.VerifiedBy)
+	assert.Nil(t, skill.VerifiedAt)
+	assert.ErrorIs(t, repo.VerifyVolunteerSkill(2, driving.ID, &admin), domain.ErrVolunteerSkillNotFound)
+
+	assert.NoError(t, repo.DeleteVolunteerSkill(1, driving.ID))
+	assert.ErrorIs(t, repo.DeleteVolunteerSkill(1, driving.ID), domain.ErrVolunteerSkillNotFound)
+	_, err = repo.FindVolunteerSkill(1, driving.ID)
+	assert.ErrorIs(t, err, domain.ErrVolunteerSkillNotFound)
+}
+
+func TestMatchingSources(t *testing.T) {
+	db := setupDirectoryDB(t)
+	assert.NoError(t, db.AutoMigrate(&activityDomain.Activity{}, &activityDomain.ActivitySkill{}, &activityDomain.Signup{}))
+	repo := NewSkillRepository(db)
+	start := time.Now().Add(24 * time.Hour)
+	activity := &activityDomain.Activity{Title: "Food drive", DepartmentID: 1, Location: "Hall", StartsAt: start, EndsAt: start.Add(time.Hour), Capacity: 5, CreatedBy: 3}
+	assert.NoError(t, db.Create(activity).Error)
+	assert.NoError(t, db.Create(&[]activityDomain.ActivitySkill{{ActivityID: activity.ID, SkillID: 2}, {ActivityID: activity.ID, SkillID: 1}}).Error)
+	assert.NoError(t, db.Create(&[]activityDomain.Signup{
+		{ActivityID: activity.ID, VolunteerID: 1, Status: activityDomain.SignupConfirmed},
+		{ActivityID: activity.ID, VolunteerID: 2, Status: activityDomain.SignupCancelled},
+	}).Error)
+
+	found, err := repo.FindActivity(activity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, found.SkillIDs)
+	_, err = repo.FindActivity(99)
+	assert.ErrorIs(t, err, activityDomain.ErrActivityNotFound)
+
+	ids, err := repo.ListSignedUpVolunteerIDs(activity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, ids)
+}
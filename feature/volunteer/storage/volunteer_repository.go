@@ -1,17 +1,34 @@
 package storage
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
 
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 )
 
 // VolunteerRepositoryInterface defines the methods that a VolunteerRepository should implement
 type VolunteerRepositoryInterface interface {
-	CreateVolunteer(volunteer *domain.Volunteer) error
 	UpdateVolunteer(volunteer *domain.Volunteer) error
 	DeleteVolunteer(id int) error
+	ListDeletedVolunteers() ([]*domain.Volunteer, error)
+	RestoreVolunteer(id int) error
 	FindVolunteerByID(id int) (*domain.Volunteer, error)
+	ListVolunteers(query dto.ListVolunteerQuery) ([]*domain.VolunteerListing, int64, error)
+	AssignPosition(volunteerID int, positionID int, assignedBy int, notes string) (*domain.PositionAssignment, error)
+	EndPosition(volunteerID int) error
+	ListPositionHistory(volunteerID int) ([]*domain.PositionAssignment, error)
+	DeactivateVolunteer(volunteerID int, changedBy int, reason string, policy string) (*domain.VolunteerStatusChange, error)
+	ReactivateVolunteer(volunteerID int, changedBy int, reason string) (*domain.VolunteerStatusChange, error)
+	ListStatusChanges(volunteerID int) ([]*domain.VolunteerStatusChange, error)
 }
 
 type VolunteerRepository struct {
@@ -22,10 +39,6 @@ func NewVolunteerRepository(db *gorm.DB) *VolunteerRepository {
 	return &VolunteerRepository{DB: db}
 }
 
-func (r *VolunteerRepository) CreateVolunteer(volunteer *domain.Volunteer) error {
-	return r.DB.Create(volunteer).Error
-}
-
 func (r *VolunteerRepository) UpdateVolunteer(volunteer *domain.Volunteer) error {
 	return r.DB.Save(volunteer).Error
 }
@@ -41,3 +54,332 @@ func (r *VolunteerRepository) FindVolunteerByID(id int) (*domain.Volunteer, erro
 	}
 	return volunteer, nil
 }
+
+// ListDeletedVolunteers returns the soft deleted volunteers, most recently
+// deleted first.
+func (r *VolunteerRepository) ListDeletedVolunteers() ([]*domain.Volunteer, error) {
+	volunteers := make([]*domain.Volunteer, 0)
+	err := r.DB.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id DESC").Find(&volunteers).Error
+	return volunteers, err
+}
+
+// RestoreVolunteer brings back a soft deleted volunteer.
+func (r *VolunteerRepository) RestoreVolunteer(id int) error {
+	result := r.DB.Unscoped().Model(&domain.Volunteer{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrVolunteerNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedVolunteers permanently removes the volunteers soft deleted
+// before the given time, together with their position and status history,
+// their activity sign-ups and attendance, their logged hours and their
+// availability.
+func (r *VolunteerRepository) PurgeDeletedVolunteers(before time.Time) (int64, error) {
+	var purged int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Unscoped().Model(&domain.Volunteer{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.PositionAssignment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.VolunteerStatusChange{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&activityDomain.Signup{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&activityDomain.Attendance{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&activityDomain.AttendanceTokenUse{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.HoursEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.AvailabilitySlot{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.AvailabilityException{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.VolunteerSkill{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("volunteer_id IN ?", ids).Delete(&domain.Certificate{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&domain.Volunteer{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// volunteerSortColumns maps the sort keys accepted by ListVolunteers to
+// columns.
+var volunteerSortColumns = map[string]string{
+	"id":         "volunteer_details.id",
+	"name":       "users.name",
+	"email":      "users.email",
+	"department": "departments.name",
+	// volunteers without a position come last either way
+	"position":   "CASE WHEN positions.rank IS NULL THEN 1 ELSE 0 END, positions.rank",
+	"created_at": "volunteer_details.created_at",
+}
+
+// ListVolunteers returns one page of the volunteers matching the query with
+// their user, department, role and current position, sorted by id unless a sort is given,
+// together with the number of matching volunteers. Page and PageSize must
+// already be set.
+func (r *VolunteerRepository) ListVolunteers(query dto.ListVolunteerQuery) ([]*domain.VolunteerListing, int64, error) {
+	db := r.DB.Model(&domain.Volunteer{}).
+		Joins("JOIN users ON users.id = volunteer_details.user_id AND users.deleted_at IS NULL").
+		Joins("LEFT JOIN departments ON departments.id = volunteer_details.department_id").
+		Joins("LEFT JOIN roles ON roles.id = users.role_id").
+		Joins("LEFT JOIN volunteer_positions ON volunteer_positions.volunteer_id = volunteer_details.id AND volunteer_positions.ended_at IS NULL").
+		Joins("LEFT JOIN positions ON positions.id = volunteer_positions.position_id")
+	if query.Search != "" {
+		search := "%" + strings.ToLower(query.Search) + "%"
+		condition := "LOWER(users.name) LIKE ? OR LOWER(users.surname) LIKE ? OR LOWER(users.email) LIKE ?"
+		args := []interface{}{search, search, search}
+		if id, err := strconv.Atoi(query.Search); err == nil {
+			condition += " OR volunteer_details.id = ?"
+			args = append(args, id)
+		}
+		db = db.Where("("+condition+")", args...)
+	}
+	if query.Gender != "" {
+		db = db.Where("users.gender = ?", query.Gender)
+	}
+	if query.RoleID != nil {
+		db = db.Where("users.role_id = ?", *query.RoleID)
+	}
+	if query.DepartmentID != nil {
+		db = db.Where("volunteer_details.department_id = ?", *query.DepartmentID)
+	}
+	if query.PositionID != nil {
+		db = db.Where("volunteer_positions.position_id = ?", *query.PositionID)
+	}
+	if query.Status != nil {
+		db = db.Where("volunteer_details.status = ?", *query.Status)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = "id"
+	}
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	column, ok := volunteerSortColumns[sort]
+	if !ok {
+		return nil, 0, errors.New("invalid sort field")
+	}
+
+	volunteers := make([]*domain.VolunteerListing, 0)
+	err := db.Select("volunteer_details.id, volunteer_details.user_id, users.email, users.name, users.surname, users.gender, users.mobile, " +
+		"users.role_id, roles.name AS role_name, volunteer_details.department_id, departments.name AS department_name, " +
+		"volunteer_positions.position_id, positions.code AS position_code, positions.name AS position_name, volunteer_details.status, volunteer_details.created_at").
+		Order(column + " " + direction).
+		Order("volunteer_details.id " + direction).
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize).
+		Scan(&volunteers).Error
+	return volunteers, total, err
+}
+
+// AssignPosition gives the volunteer a position of the catalog, ending the
+// position they held until now.
+func (r *VolunteerRepository) AssignPosition(volunteerID int, positionID int, assignedBy int, notes string) (*domain.PositionAssignment, error) {
+	assignment := &domain.PositionAssignment{
+		VolunteerID: volunteerID,
+		PositionID:  positionID,
+		AssignedBy:  assignedBy,
+		Notes:       notes,
+		StartedAt:   time.Now(),
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").First(&domain.Volunteer{}, volunteerID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrVolunteerNotFound
+			}
+			return err
+		}
+		var position roleDomain.Position
+		if err := tx.First(&position, positionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return roleDomain.ErrPositionNotFound
+			}
+			return err
+		}
+		var current domain.PositionAssignment
+		err := tx.Where("volunteer_id = ? AND ended_at IS NULL", volunteerID).Take(&current).Error
+		switch {
+		case err == nil && current.PositionID == positionID:
+			return domain.ErrPositionAlreadyHeld
+		case err == nil:
+			if err := tx.Model(&current).Update("ended_at", assignment.StartedAt).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		if err := tx.Create(assignment).Error; err != nil {
+			return err
+		}
+		assignment.PositionCode = position.Code
+		assignment.PositionName = position.Name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// EndPosition ends the current position of the volunteer.
+func (r *VolunteerRepository) EndPosition(volunteerID int) error {
+	result := r.DB.Model(&domain.PositionAssignment{}).
+		Where("volunteer_id = ? AND ended_at IS NULL", volunteerID).
+		Update("ended_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNoCurrentPosition
+	}
+	return nil
+}
+
+// ListPositionHistory returns the positions the volunteer held, the current
+// one first.
+func (r *VolunteerRepository) ListPositionHistory(volunteerID int) ([]*domain.PositionAssignment, error) {
+	if err := r.DB.Select("id").First(&domain.Volunteer{}, volunteerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	assignments := make([]*domain.PositionAssignment, 0)
+	err := r.DB.Select("volunteer_positions.*, positions.code AS position_code, positions.name AS position_name").
+		Joins("JOIN positions ON positions.id = volunteer_positions.position_id").
+		Where("volunteer_positions.volunteer_id = ?", volunteerID).
+		Order("volunteer_positions.started_at DESC").
+		Order("volunteer_positions.id DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// DeactivateVolunteer sets the volunteer inactive and records the change. The
+// sessions of the user are revoked and, depending on policy, the user is
+// disabled or moved back from the volunteer to the applicant role.
+func (r *VolunteerRepository) DeactivateVolunteer(volunteerID int, changedBy int, reason string, policy string) (*domain.VolunteerStatusChange, error) {
+	return r.changeStatus(volunteerID, domain.VolunteerStatusInactive, changedBy, reason, func(tx *gorm.DB, userID int) error {
+		if policy == domain.DeactivationDemote {
+			if err := swapRole(tx, userID, roleDomain.RoleVolunteer, roleDomain.RoleApplicant); err != nil {
+				return err
+			}
+		} else if err := tx.Model(&authDomain.User{}).Where("id = ?", userID).Update("status", 0).Error; err != nil {
+			return err
+		}
+		// access tokens carry the role, make the user log in again
+		return tx.Model(&authDomain.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error
+	})
+}
+
+// ReactivateVolunteer sets the volunteer active again and records the change,
+// undoing what DeactivateVolunteer did to the user under either policy.
+func (r *VolunteerRepository) ReactivateVolunteer(volunteerID int, changedBy int, reason string) (*domain.VolunteerStatusChange, error) {
+	return r.changeStatus(volunteerID, domain.VolunteerStatusActive, changedBy, reason, func(tx *gorm.DB, userID int) error {
+		if err := tx.Model(&authDomain.User{}).Where("id = ?", userID).Update("status", 1).Error; err != nil {
+			return err
+		}
+		return swapRole(tx, userID, roleDomain.RoleApplicant, roleDomain.RoleVolunteer)
+	})
+}
+
+func (r *VolunteerRepository) changeStatus(volunteerID int, status int, changedBy int, reason string, cascade func(tx *gorm.DB, userID int) error) (*domain.VolunteerStatusChange, error) {
+	change := &domain.VolunteerStatusChange{
+		VolunteerID: volunteerID,
+		Status:      status,
+		Reason:      reason,
+		ChangedBy:   changedBy,
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var volunteer domain.Volunteer
+		if err := tx.First(&volunteer, volunteerID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrVolunteerNotFound
+			}
+			return err
+		}
+		// the status is checked again in the update in case of a concurrent change
+		result := tx.Model(&domain.Volunteer{}).
+			Where("id = ? AND status <> ?", volunteerID, status).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			if status == domain.VolunteerStatusInactive {
+				return domain.ErrVolunteerInactive
+			}
+			return domain.ErrVolunteerActive
+		}
+		if err := cascade(tx, volunteer.UserID); err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// swapRole gives the user the role named to if they have the role named from,
+// users with any other role keep it.
+func swapRole(tx *gorm.DB, userID int, from string, to string) error {
+	return tx.Model(&authDomain.User{}).
+		Where("id = ? AND role_id = (?)", userID, tx.Model(&roleDomain.Role{}).Select("id").Where("name = ?", from)).
+		Update("role_id", tx.Model(&roleDomain.Role{}).Select("id").Where("name = ?", to)).Error
+}
+
+// ListStatusChanges returns the deactivations and reactivations of the
+// volunteer, the latest first.
+func (r *VolunteerRepository) ListStatusChanges(volunteerID int) ([]*domain.VolunteerStatusChange, error) {
+	if err := r.DB.Select("id").First(&domain.Volunteer{}, volunteerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVolunteerNotFound
+		}
+		return nil, err
+	}
+	changes := make([]*domain.VolunteerStatusChange, 0)
+	err := r.DB.Where("volunteer_id = ?", volunteerID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&changes).Error
+	return changes, err
+}
@@ -2,115 +2,252 @@ package storage
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/internal/testdb"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 )
 
-type MockDB struct {
-	mock.Mock
-	gorm.DB
-}
-
-func (m *MockDB) Create(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Save(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Delete(value interface{}, conds ...interface{}) *gorm.DB {
-	args := m.Called(value, conds)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) First(dest interface{}, conds ...interface{}) *gorm.DB {
-	args := m.Called(dest, conds)
-	return args.Get(0).(*gorm.DB)
-}
-
-func TestCreateVolunteer(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
-	volunteer := &domain.Volunteer{
-		ID:           1,
-		UserID:       5,
-		DepartmentID: 3,
-		Status:       0,
-	}
-	mockDB.On("Create", volunteer).Return(&gorm.DB{Error: nil})
-
-	err := repo.CreateVolunteer(volunteer)
-
-	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+func setupVolunteerDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.Volunteer{})
+	assert.NoError(t, db.Create(&domain.Volunteer{ID: 1, UserID: 1, DepartmentID: 2, Status: 1}).Error)
+	return db
 }
 
 func TestUpdateVolunteer(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
+	db := setupVolunteerDB(t)
+	repo := NewVolunteerRepository(db)
 
 	volunteer := &domain.Volunteer{
 		ID:           1,
+		UserID:       1,
 		DepartmentID: 4,
 		Status:       0,
 	}
-	mockDB.On("Save", volunteer).Return(&gorm.DB{Error: nil})
 
 	err := repo.UpdateVolunteer(volunteer)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	var stored domain.Volunteer
+	assert.NoError(t, db.First(&stored, 1).Error)
+	assert.Equal(t, 4, stored.DepartmentID)
+	assert.Equal(t, 0, stored.Status)
 }
 
 func TestDeleteVolunteer(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
-
-	mockDB.On("Delete", &domain.Volunteer{}, 1).Return(&gorm.DB{Error: nil})
+	db := setupVolunteerDB(t)
+	repo := NewVolunteerRepository(db)
 
 	err := repo.DeleteVolunteer(1)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.ErrorIs(t, db.First(&domain.Volunteer{}, 1).Error, gorm.ErrRecordNotFound)
 }
 
 func TestFindVolunteerByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
-
-	volunteer := &domain.Volunteer{
-		ID:           1,
-		DepartmentID: 4,
-		Status:       0,
-	}
-	mockDB.On("First", &volunteer, 1).Return(&gorm.DB{Error: nil}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(**domain.Volunteer)
-		*arg = volunteer
-	})
+	repo := NewVolunteerRepository(setupVolunteerDB(t))
 
 	result, err := repo.FindVolunteerByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, volunteer, result)
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, 2, result.DepartmentID)
 }
 
 func TestFindVolunteerByID_NotFound(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
-
-	mockDB.On("First", mock.Anything, 1).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	repo := NewVolunteerRepository(setupVolunteerDB(t))
 
-	result, err := repo.FindVolunteerByID(1)
+	result, err := repo.FindVolunteerByID(2)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	mockDB.AssertExpectations(t)
+}
+
+// setupDirectoryDB returns a database with four volunteers, the one of user 4
+// belonging to a deleted user.
+func setupDirectoryDB(t *testing.T) *gorm.DB {
+	db := testdb.Open(t, &domain.Volunteer{}, &domain.PositionAssignment{}, &roleDomain.Position{})
+	testdb.Exec(t, db,
+		"CREATE TABLE roles (id integer primary key, name text)",
+		"CREATE TABLE departments (id integer primary key, name text)",
+		"CREATE TABLE users (id integer primary key, role_id integer, email text, name text, surname text, gender text, mobile text, deleted_at datetime)",
+		"INSERT INTO roles (id, name) VALUES (1, 'volunteer'), (2, 'admin')",
+		"INSERT INTO departments (id, name) VALUES (1, 'Kitchen'), (2, 'Garden')",
+		"INSERT INTO users (id, role_id, email, name, surname, gender) VALUES "+
+			"(1, 1, 'anna@example.com', 'Anna', 'Smith', 'female'), "+
+			"(2, 1, 'bob@example.com', 'Bob', 'Jones', 'male'), "+
+			"(3, 2, 'carl@example.com', 'Carl', 'Annan', 'male')",
+		"INSERT INTO users (id, role_id, email, name, surname, gender, deleted_at) VALUES (4, 1, 'dan@example.com', 'Dan', 'Ann', 'male', CURRENT_TIMESTAMP)",
+	)
+	for _, volunteer := range []*domain.Volunteer{
+		{UserID: 1, DepartmentID: 1, Status: 1},
+		{UserID: 2, DepartmentID: 2, Status: 1},
+		{UserID: 3, DepartmentID: 2, Status: 0},
+		{UserID: 4, DepartmentID: 1, Status: 1},
+	} {
+		assert.NoError(t, db.Create(volunteer).Error)
+	}
+	return db
+}
+
+func TestListVolunteers(t *testing.T) {
+	repo := NewVolunteerRepository(setupDirectoryDB(t))
+
+	t.Run("search and sort", func(t *testing.T) {
+		volunteers, total, err := repo.ListVolunteers(dto.ListVolunteerQuery{Page: 1, PageSize: 10, Search: "ann", Sort: "-name"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		if assert.Len(t, volunteers, 2) {
+			assert.Equal(t, "Carl", volunteers[0].Name)
+			assert.Equal(t, "admin", volunteers[0].RoleName)
+			assert.Equal(t, "Anna", volunteers[1].Name)
+			assert.Equal(t, "Kitchen", volunteers[1].DepartmentName)
+		}
+	})
+
+	t.Run("search by id", func(t *testing.T) {
+		volunteers, _, err := repo.ListVolunteers(dto.ListVolunteerQuery{Page: 1, PageSize: 10, Search: "2"})
+		assert.NoError(t, err)
+		if assert.Len(t, volunteers, 1) {
+			assert.Equal(t, "bob@example.com", volunteers[0].Email)
+		}
+	})
+
+	t.Run("filters and pagination", func(t *testing.T) {
+		role, department, status := 1, 2, 1
+		volunteers, total, err := repo.ListVolunteers(dto.ListVolunteerQuery{Page: 1, PageSize: 10, Gender: "male", RoleID: &role, DepartmentID: &department, Status: &status})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		if assert.Len(t, volunteers, 1) {
+			assert.Equal(t, "Bob", volunteers[0].Name)
+		}
+
+		volunteers, total, err = repo.ListVolunteers(dto.ListVolunteerQuery{Page: 2, PageSize: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		if assert.Len(t, volunteers, 1) {
+			assert.Equal(t, 3, volunteers[0].UserID)
+		}
+	})
+}
+
+func TestAssignPosition(t *testing.T) {
+	db := setupDirectoryDB(t)
+	repo := NewVolunteerRepository(db)
+	committee := &roleDomain.Position{Code: "COM", Name: "Committee", Rank: 1}
+	civil := &roleDomain.Position{Code: "CVL", Name: "Civil volunteer", Rank: 3}
+	db.Create(committee)
+	db.Create(civil)
+
+	first, err := repo.AssignPosition(1, int(civil.Id), 7, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "CVL", first.PositionCode)
+	_, err = repo.AssignPosition(1, int(civil.Id), 7, "")
+	assert.ErrorIs(t, err, domain.ErrPositionAlreadyHeld)
+	_, err = repo.AssignPosition(1, int(committee.Id), 7, "elected")
+	assert.NoError(t, err)
+	_, err = repo.AssignPosition(2, int(civil.Id), 7, "")
+	assert.NoError(t, err)
+
+	_, err = repo.AssignPosition(99, int(civil.Id), 7, "")
+	assert.ErrorIs(t, err, domain.ErrVolunteerNotFound)
+	_, err = repo.AssignPosition(1, 99, 7, "")
+	assert.ErrorIs(t, err, roleDomain.ErrPositionNotFound)
+
+	history, err := repo.ListPositionHistory(1)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, "COM", history[0].PositionCode)
+		assert.Nil(t, history[0].EndedAt)
+		assert.Equal(t, "CVL", history[1].PositionCode)
+		assert.NotNil(t, history[1].EndedAt)
+	}
+
+	volunteers, _, err := repo.ListVolunteers(dto.ListVolunteerQuery{Page: 1, PageSize: 10, Sort: "position"})
+	assert.NoError(t, err)
+	if assert.Len(t, volunteers, 3) {
+		assert.Equal(t, "COM", volunteers[0].PositionCode)
+		assert.Equal(t, "CVL", volunteers[1].PositionCode)
+		assert.Nil(t, volunteers[2].PositionID)
+	}
+
+	assert.NoError(t, repo.EndPosition(1))
+	assert.ErrorIs(t, repo.EndPosition(1), domain.ErrNoCurrentPosition)
+}
+
+func setupStatusDB(t *testing.T) (*gorm.DB, *authDomain.User, *domain.Volunteer) {
+	db := setupOnboardingDB(t)
+	if err := db.AutoMigrate(&domain.VolunteerStatusChange{}, &authDomain.RefreshToken{}); err != nil {
+		t.Fatalf("could not migrate test DB: %v", err)
+	}
+	assert.NoError(t, db.Create(&roleDomain.Role{Id: 2, Name: roleDomain.RoleApplicant, Status: 1}).Error)
+	user := &authDomain.User{RoleID: 3, Email: "anna@example.com", Password: "hash", Name: "Anna", Surname: "Smith", Gender: "female", Mobile: "123", Status: 1}
+	assert.NoError(t, db.Create(user).Error)
+	volunteer := &domain.Volunteer{UserID: user.ID, DepartmentID: 1, Status: domain.VolunteerStatusActive}
+	assert.NoError(t, db.Create(volunteer).Error)
+	assert.NoError(t, db.Create(&authDomain.RefreshToken{UserID: user.ID, FamilyID: "f", TokenHash: "h", ExpiresAt: time.Now().Add(time.Hour)}).Error)
+	return db, user, volunteer
+}
+
+func TestDeactivateVolunteer(t *testing.T) {
+	t.Run("block login", func(t *testing.T) {
+		db, user, volunteer := setupStatusDB(t)
+		repo := NewVolunteerRepository(db)
+
+		change, err := repo.DeactivateVolunteer(volunteer.ID, 9, "moved abroad", domain.DeactivationBlockLogin)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.VolunteerStatusInactive, change.Status)
+
+		var saved authDomain.User
+		db.First(&saved, user.ID)
+		assert.Equal(t, 0, saved.Status)
+		assert.Equal(t, 3, saved.RoleID)
+		var token authDomain.RefreshToken
+		db.First(&token)
+		assert.NotNil(t, token.RevokedAt)
+
+		_, err = repo.DeactivateVolunteer(volunteer.ID, 9, "again", domain.DeactivationBlockLogin)
+		assert.ErrorIs(t, err, domain.ErrVolunteerInactive)
+
+		_, err = repo.ReactivateVolunteer(volunteer.ID, 9, "back from abroad")
+		assert.NoError(t, err)
+		db.First(&saved, user.ID)
+		assert.Equal(t, 1, saved.Status)
+		changes, err := repo.ListStatusChanges(volunteer.ID)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+
+		_, err = repo.ReactivateVolunteer(volunteer.ID, 9, "again")
+		assert.ErrorIs(t, err, domain.ErrVolunteerActive)
+	})
+
+	t.Run("demote", func(t *testing.T) {
+		db, user, volunteer := setupStatusDB(t)
+		repo := NewVolunteerRepository(db)
+
+		_, err := repo.DeactivateVolunteer(volunteer.ID, 9, "on leave", domain.DeactivationDemote)
+		assert.NoError(t, err)
+
+		var saved authDomain.User
+		db.First(&saved, user.ID)
+		assert.Equal(t, 1, saved.Status)
+		assert.Equal(t, 2, saved.RoleID)
+
+		_, err = repo.ReactivateVolunteer(volunteer.ID, 9, "back")
+		assert.NoError(t, err)
+		db.First(&saved, user.ID)
+		assert.Equal(t, 3, saved.RoleID)
+	})
+
+	t.Run("unknown volunteer", func(t *testing.T) {
+		db, _, _ := setupStatusDB(t)
+		_, err := NewVolunteerRepository(db).DeactivateVolunteer(99, 9, "gone", domain.DeactivationBlockLogin)
+		assert.ErrorIs(t, err, domain.ErrVolunteerNotFound)
+	})
 }
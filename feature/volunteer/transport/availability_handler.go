@@ -0,0 +1,192 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityHandler struct {
+	AvailabilityUsecase usecase.AvailabilityUsecaseInterface
+}
+
+func NewAvailabilityHandler(availabilityUsecase usecase.AvailabilityUsecaseInterface) *AvailabilityHandler {
+	return &AvailabilityHandler{AvailabilityUsecase: availabilityUsecase}
+}
+
+// GetMyAvailability godoc
+// @Summary Get my availability
+// @Description Get the weekly slots of the current volunteer and their exceptions from today on
+// @Produce json
+// @Tags availability
+// @Security bearerToken
+// @Success 200 {object} dto.MyAvailabilityDTO
+// @Failure 404 string error
+// @Router /api/v1/me/availability [get]
+func (h *AvailabilityHandler) GetMyAvailability(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	availability, err := h.AvailabilityUsecase.GetMyAvailability(userId.(int))
+	if err != nil {
+		c.JSON(availabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// ReplaceMySlots godoc
+// @Summary Set my weekly availability
+// @Description Replace the weekly slots of the current volunteer. Weekdays count from Sunday (0) and slots of a day cannot overlap
+// @Produce json
+// @Tags availability
+// @Security bearerToken
+// @Param request body dto.AvailabilitySlotsDTO true "Weekly Slots Request"
+// @Success 200 {object} dto.MyAvailabilityDTO
+// @Failure 400 string error
+// @Failure 404 string error
+// @Router /api/v1/me/availability/slots [put]
+func (h *AvailabilityHandler) ReplaceMySlots(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.AvailabilitySlotsDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	availability, err := h.AvailabilityUsecase.ReplaceMySlots(userId.(int), input)
+	if err != nil {
+		c.JSON(availabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// AddMyException godoc
+// @Summary Add an availability exception
+// @Description Make the current volunteer unavailable, or available with available set, on a day, for the whole day or between start_time and end_time
+// @Produce json
+// @Tags availability
+// @Security bearerToken
+// @Param request body dto.AvailabilityExceptionDTO true "Availability Exception Request"
+// @Success 201 {object} dto.AvailabilityExceptionResponseDTO
+// @Failure 400 string error
+// @Failure 404 string error
+// @Router /api/v1/me/availability/exceptions [post]
+func (h *AvailabilityHandler) AddMyException(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.AvailabilityExceptionDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exception, err := h.AvailabilityUsecase.AddMyException(userId.(int), input)
+	if err != nil {
+		c.JSON(availabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, exception)
+}
+
+// DeleteMyException godoc
+// @Summary Delete an availability exception
+// @Description Delete an exception of the current volunteer
+// @Produce json
+// @Tags availability
+// @Security bearerToken
+// @Param id path int true "Exception ID"
+// @Success 200 {string} message "Exception deleted successfully"
+// @Failure 404 string error
+// @Router /api/v1/me/availability/exceptions/{id} [delete]
+func (h *AvailabilityHandler) DeleteMyException(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exception ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.AvailabilityUsecase.DeleteMyException(userId.(int), id); err != nil {
+		c.JSON(availabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Exception deleted successfully"})
+}
+
+// ListAvailability godoc
+// @Summary List availability
+// @Description Get when active volunteers are available each day of a period of at most 31 days, the coming week by default. Coordinators who belong to a department only see its volunteers
+// @Produce json
+// @Tags availability
+// @Security bearerToken
+// @Param department_id query int false "Department ID"
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param from query string false "From day, YYYY-MM-DD"
+// @Param to query string false "To day, YYYY-MM-DD"
+// @Success 200 {array} dto.VolunteerAvailabilityDTO
+// @Failure 400 string error
+// @Failure 403 string error
+// @Router /api/v1/availability/ [get]
+func (h *AvailabilityHandler) ListAvailability(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.AvailabilityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	availability, err := h.AvailabilityUsecase.ListAvailability(userId.(int), query)
+	if err != nil {
+		c.JSON(availabilityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// availabilityErrorStatus maps availability errors to HTTP status codes.
+func availabilityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrVolunteerNotFound), errors.Is(err, domain.ErrExceptionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidTimeRange), errors.Is(err, domain.ErrSlotOverlap),
+		errors.Is(err, domain.ErrAvailabilityPeriod):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrOutsideDepartment):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,140 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvailabilityUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityUsecase) GetMyAvailability(userID int) (*dto.MyAvailabilityDTO, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MyAvailabilityDTO), args.Error(1)
+}
+
+func (m *MockAvailabilityUsecase) ReplaceMySlots(userID int, input dto.AvailabilitySlotsDTO) (*dto.MyAvailabilityDTO, error) {
+	args := m.Called(userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MyAvailabilityDTO), args.Error(1)
+}
+
+func (m *MockAvailabilityUsecase) AddMyException(userID int, input dto.AvailabilityExceptionDTO) (*dto.AvailabilityExceptionResponseDTO, error) {
+	args := m.Called(userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AvailabilityExceptionResponseDTO), args.Error(1)
+}
+
+func (m *MockAvailabilityUsecase) DeleteMyException(userID int, id int) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityUsecase) ListAvailability(adminID int, query dto.AvailabilityQuery) ([]dto.VolunteerAvailabilityDTO, error) {
+	args := m.Called(adminID, query)
+	return args.Get(0).([]dto.VolunteerAvailabilityDTO), args.Error(1)
+}
+
+func TestReplaceMySlots(t *testing.T) {
+	mockUsecase := new(MockAvailabilityUsecase)
+	handler := NewAvailabilityHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PUT("/api/v1/me/availability/slots", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.ReplaceMySlots(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		slots := []dto.AvailabilitySlotDTO{{Weekday: 0, StartTime: "09:00", EndTime: "12:00"}}
+		mockUsecase.On("ReplaceMySlots", 11, dto.AvailabilitySlotsDTO{Slots: slots}).
+			Return(&dto.MyAvailabilityDTO{Slots: slots, Exceptions: []dto.AvailabilityExceptionResponseDTO{}}, nil)
+
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/me/availability/slots", strings.NewReader(`{"slots":[{"weekday":0,"start_time":"09:00","end_time":"12:00"}]}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		slots := []dto.AvailabilitySlotDTO{
+			{Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+			{Weekday: 1, StartTime: "10:00", EndTime: "11:00"},
+		}
+		mockUsecase.On("ReplaceMySlots", 11, dto.AvailabilitySlotsDTO{Slots: slots}).Return(nil, domain.ErrSlotOverlap)
+
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/me/availability/slots", strings.NewReader(
+			`{"slots":[{"weekday":1,"start_time":"09:00","end_time":"12:00"},{"weekday":1,"start_time":"10:00","end_time":"11:00"}]}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid weekday", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/me/availability/slots", strings.NewReader(`{"slots":[{"weekday":7,"start_time":"09:00","end_time":"12:00"}]}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestAddMyException(t *testing.T) {
+	mockUsecase := new(MockAvailabilityUsecase)
+	handler := NewAvailabilityHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/me/availability/exceptions", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.AddMyException(c)
+	})
+
+	t.Run("whole day", func(t *testing.T) {
+		mockUsecase.On("AddMyException", 11, dto.AvailabilityExceptionDTO{Date: "2024-03-05", Note: "holiday"}).
+			Return(&dto.AvailabilityExceptionResponseDTO{ID: 1, Date: "2024-03-05", Note: "holiday"}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/me/availability/exceptions", strings.NewReader(`{"date":"2024-03-05","note":"holiday"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("start time without end time", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/me/availability/exceptions", strings.NewReader(`{"date":"2024-03-05","start_time":"10:00"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
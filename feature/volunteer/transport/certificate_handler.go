@@ -0,0 +1,279 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CertificateHandler struct {
+	CertificateUsecase usecase.CertificateUsecaseInterface
+}
+
+func NewCertificateHandler(certificateUsecase usecase.CertificateUsecaseInterface) *CertificateHandler {
+	return &CertificateHandler{CertificateUsecase: certificateUsecase}
+}
+
+// IssueCertificate godoc
+// @Summary Issue a certificate
+// @Description Sign a certificate of service for the hours a volunteer had approved between two days. Nobody signs their own certificate
+// @Produce json
+// @Tags certificate
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.IssueCertificateDTO true "Issue Certificate Request"
+// @Success 201 {object} dto.CertificateDTO
+// @Failure 400 string error
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/certificates [post]
+func (h *CertificateHandler) IssueCertificate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.IssueCertificateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	certificate, err := h.CertificateUsecase.IssueCertificate(userId.(int), id, input)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, certificate)
+}
+
+// ListCertificates godoc
+// @Summary List the certificates of a volunteer
+// @Description List the certificates issued to a volunteer, the latest first
+// @Produce json
+// @Tags certificate
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.CertificateDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/volunteer/{id}/certificates [get]
+func (h *CertificateHandler) ListCertificates(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	certificates, err := h.CertificateUsecase.ListCertificates(userId.(int), id)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, certificates)
+}
+
+// RevokeCertificate godoc
+// @Summary Revoke a certificate
+// @Description Revoke a certificate issued to a volunteer, its verification code then reports it as not valid
+// @Produce json
+// @Tags certificate
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param certificateId path int true "Certificate ID"
+// @Param request body dto.RevokeCertificateDTO true "Revoke Certificate Request"
+// @Success 200 {object} dto.CertificateDTO
+// @Failure 400 string error
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/certificates/{certificateId}/revoke [post]
+func (h *CertificateHandler) RevokeCertificate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	certificateID, err := strconv.Atoi(c.Param("certificateId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid certificate ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.RevokeCertificateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	certificate, err := h.CertificateUsecase.RevokeCertificate(userId.(int), id, certificateID, input)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, certificate)
+}
+
+// CertificatePDF godoc
+// @Summary Download a certificate
+// @Description Download a certificate issued to a volunteer as a PDF
+// @Produce application/pdf
+// @Tags certificate
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param certificateId path int true "Certificate ID"
+// @Success 200 {file} binary
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/certificates/{certificateId}/pdf [get]
+func (h *CertificateHandler) CertificatePDF(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	certificateID, err := strconv.Atoi(c.Param("certificateId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid certificate ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	pdf, err := h.CertificateUsecase.CertificatePDF(userId.(int), id, certificateID)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="certificate-%d.pdf"`, certificateID))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// ListMyCertificates godoc
+// @Summary List my certificates
+// @Description List the certificates issued to the current volunteer, the latest first
+// @Produce json
+// @Tags certificate
+// @Security bearerToken
+// @Success 200 {array} dto.CertificateDTO
+// @Failure 404 string error
+// @Router /api/v1/me/certificates [get]
+func (h *CertificateHandler) ListMyCertificates(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	certificates, err := h.CertificateUsecase.ListMyCertificates(userId.(int))
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, certificates)
+}
+
+// MyCertificatePDF godoc
+// @Summary Download my certificate
+// @Description Download a certificate issued to the current volunteer as a PDF
+// @Produce application/pdf
+// @Tags certificate
+// @Security bearerToken
+// @Param id path int true "Certificate ID"
+// @Success 200 {file} binary
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/me/certificates/{id}/pdf [get]
+func (h *CertificateHandler) MyCertificatePDF(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid certificate ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	pdf, err := h.CertificateUsecase.MyCertificatePDF(userId.(int), id)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="certificate-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// VerifyCertificate godoc
+// @Summary Verify a certificate
+// @Description Check a certificate by the verification code printed on it. Valid is false when the certificate was revoked
+// @Produce json
+// @Tags certificate
+// @Param code path string true "Verification code"
+// @Success 200 {object} dto.CertificateVerificationDTO
+// @Failure 404 string error
+// @Router /api/v1/certificates/{code}/verify [get]
+func (h *CertificateHandler) VerifyCertificate(c *gin.Context) {
+	verification, err := h.CertificateUsecase.VerifyCertificate(c.Param("code"))
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// certificateErrorStatus maps certificate errors to HTTP status codes.
+func certificateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrCertificateNotFound), errors.Is(err, domain.ErrVolunteerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrCertificateRevoked), errors.Is(err, domain.ErrNoApprovedHours):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrCertificatePeriod):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrOwnCertificate), errors.Is(err, domain.ErrOutsideDepartment):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
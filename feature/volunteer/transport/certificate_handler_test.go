@@ -0,0 +1,197 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCertificateUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCertificateUsecase) IssueCertificate(adminID int, volunteerID int, input dto.IssueCertificateDTO) (*dto.CertificateDTO, error) {
+	args := m.Called(adminID, volunteerID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CertificateDTO), args.Error(1)
+}
+
+func (m *MockCertificateUsecase) ListCertificates(adminID int, volunteerID int) ([]dto.CertificateDTO, error) {
+	args := m.Called(adminID, volunteerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.CertificateDTO), args.Error(1)
+}
+
+func (m *MockCertificateUsecase) RevokeCertificate(adminID int, volunteerID int, id int, input dto.RevokeCertificateDTO) (*dto.CertificateDTO, error) {
+	args := m.Called(adminID, volunteerID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CertificateDTO), args.Error(1)
+}
+
+func (m *MockCertificateUsecase) CertificatePDF(adminID int, volunteerID int, id int) ([]byte, error) {
+	args := m.Called(adminID, volunteerID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockCertificateUsecase) ListMyCertificates(userID int) ([]dto.CertificateDTO, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.CertificateDTO), args.Error(1)
+}
+
+func (m *MockCertificateUsecase) MyCertificatePDF(userID int, id int) ([]byte, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockCertificateUsecase) VerifyCertificate(code string) (*dto.CertificateVerificationDTO, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CertificateVerificationDTO), args.Error(1)
+}
+
+func TestIssueCertificate(t *testing.T) {
+	mockUsecase := new(MockCertificateUsecase)
+	handler := NewCertificateHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/volunteer/:id/certificates", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.IssueCertificate(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		input := dto.IssueCertificateDTO{From: "2024-03-01", To: "2024-03-31"}
+		mockUsecase.On("IssueCertificate", 7, 3, input).Return(&dto.CertificateDTO{ID: 1, Code: "AB2C-DE3F-GH4J-KL5M"}, nil).Once()
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/3/certificates", strings.NewReader(`{"from":"2024-03-01","to":"2024-03-31"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("no approved hours", func(t *testing.T) {
+		input := dto.IssueCertificateDTO{From: "2024-03-01", To: "2024-03-31"}
+		mockUsecase.On("IssueCertificate", 7, 3, input).Return(nil, domain.ErrNoApprovedHours).Once()
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/3/certificates", strings.NewReader(`{"from":"2024-03-01","to":"2024-03-31"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/3/certificates", strings.NewReader(`{"from":"March","to":"2024-03-31"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestCertificatePDF(t *testing.T) {
+	mockUsecase := new(MockCertificateUsecase)
+	handler := NewCertificateHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/me/certificates/:id/pdf", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.MyCertificatePDF(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("MyCertificatePDF", 11, 5).Return([]byte("%PDF-1.3"), nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/me/certificates/5/pdf", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), `filename="certificate-5.pdf"`)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		mockUsecase.On("MyCertificatePDF", 11, 6).Return(nil, domain.ErrCertificateRevoked)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/me/certificates/6/pdf", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	mockUsecase := new(MockCertificateUsecase)
+	handler := NewCertificateHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/certificates/:code/verify", handler.VerifyCertificate)
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("VerifyCertificate", "AB2C-DE3F-GH4J-KL5M").Return(&dto.CertificateVerificationDTO{Code: "AB2C-DE3F-GH4J-KL5M", Valid: true}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/certificates/AB2C-DE3F-GH4J-KL5M/verify", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"valid":true`)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		mockUsecase.On("VerifyCertificate", "ZZZZ").Return(nil, domain.ErrCertificateNotFound)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/certificates/ZZZZ/verify", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
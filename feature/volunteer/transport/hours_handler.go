@@ -0,0 +1,368 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type HoursHandler struct {
+	HoursUsecase usecase.HoursUsecaseInterface
+}
+
+func NewHoursHandler(hoursUsecase usecase.HoursUsecaseInterface) *HoursHandler {
+	return &HoursHandler{HoursUsecase: hoursUsecase}
+}
+
+// LogMyHours godoc
+// @Summary Log hours
+// @Description Log time the current volunteer spent on an activity they were confirmed for, or for a department
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param request body dto.LogHoursDTO true "Log Hours Request"
+// @Success 201 {object} dto.HoursEntryDTO
+// @Failure 400 string error
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/me/hours [post]
+func (h *HoursHandler) LogMyHours(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.LogHoursDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.HoursUsecase.LogHours(userId.(int), input)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ListMyHours godoc
+// @Summary List my hours
+// @Description List the hours logged by the current volunteer, the latest day first
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param status query string false "pending, approved or disputed"
+// @Param from query string false "From day, YYYY-MM-DD"
+// @Param to query string false "To day, YYYY-MM-DD"
+// @Param page query int false "Page, from 1"
+// @Param page_size query int false "Entries per page, at most 100"
+// @Success 200 {object} dto.ListHoursDTO
+// @Router /api/v1/me/hours [get]
+func (h *HoursHandler) ListMyHours(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.ListHoursQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.HoursUsecase.ListMyHours(userId.(int), query)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// UpdateMyHours godoc
+// @Summary Correct hours
+// @Description Correct hours of the current volunteer that were not approved, they go back to pending
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param id path int true "Hours entry ID"
+// @Param request body dto.LogHoursDTO true "Log Hours Request"
+// @Success 200 {object} dto.HoursEntryDTO
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/me/hours/{id} [put]
+func (h *HoursHandler) UpdateMyHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hours entry ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.LogHoursDTO
+	if err = c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.HoursUsecase.UpdateMyHours(userId.(int), id, input)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteMyHours godoc
+// @Summary Delete hours
+// @Description Delete hours of the current volunteer that were not approved
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param id path int true "Hours entry ID"
+// @Success 200 {string} message "Hours deleted successfully"
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/me/hours/{id} [delete]
+func (h *HoursHandler) DeleteMyHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hours entry ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.HoursUsecase.DeleteMyHours(userId.(int), id); err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hours deleted successfully"})
+}
+
+// ListHours godoc
+// @Summary List hours
+// @Description List logged hours for review, the latest day first. Coordinators who belong to a department only see that department
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param status query string false "pending, approved or disputed"
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param department_id query int false "Department ID"
+// @Param from query string false "From day, YYYY-MM-DD"
+// @Param to query string false "To day, YYYY-MM-DD"
+// @Param page query int false "Page, from 1"
+// @Param page_size query int false "Entries per page, at most 100"
+// @Success 200 {object} dto.ListHoursDTO
+// @Failure 403 string error
+// @Router /api/v1/hours/ [get]
+func (h *HoursHandler) ListHours(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.ListHoursQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.HoursUsecase.ListHours(userId.(int), query)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// ApproveHours godoc
+// @Summary Approve hours
+// @Description Approve pending hours, they count towards the totals
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param id path int true "Hours entry ID"
+// @Param request body dto.ApproveHoursDTO false "Approve Hours Request"
+// @Success 200 {object} dto.HoursEntryDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/hours/{id}/approve [post]
+func (h *HoursHandler) ApproveHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hours entry ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.ApproveHoursDTO
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	entry, err := h.HoursUsecase.ApproveHours(userId.(int), id, input)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DisputeHours godoc
+// @Summary Dispute hours
+// @Description Dispute pending hours with a reason, the volunteer can correct them
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param id path int true "Hours entry ID"
+// @Param request body dto.DisputeHoursDTO true "Dispute Hours Request"
+// @Success 200 {object} dto.HoursEntryDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/hours/{id}/dispute [post]
+func (h *HoursHandler) DisputeHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hours entry ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.DisputeHoursDTO
+	if err = c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.HoursUsecase.DisputeHours(userId.(int), id, input)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// VolunteerTotals godoc
+// @Summary Hours per volunteer
+// @Description Sum the hours of every volunteer over a range of days, the most approved hours first
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param department_id query int false "Department ID"
+// @Param from query string false "From day, YYYY-MM-DD"
+// @Param to query string false "To day, YYYY-MM-DD"
+// @Success 200 {array} dto.VolunteerHoursTotalDTO
+// @Failure 403 string error
+// @Router /api/v1/hours/totals/volunteers [get]
+func (h *HoursHandler) VolunteerTotals(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.HoursTotalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	totals, err := h.HoursUsecase.VolunteerTotals(userId.(int), query)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// DepartmentTotals godoc
+// @Summary Hours per department
+// @Description Sum the hours logged for every department over a range of days, the most approved hours first
+// @Produce json
+// @Tags hours
+// @Security bearerToken
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param department_id query int false "Department ID"
+// @Param from query string false "From day, YYYY-MM-DD"
+// @Param to query string false "To day, YYYY-MM-DD"
+// @Success 200 {array} dto.DepartmentHoursTotalDTO
+// @Failure 403 string error
+// @Router /api/v1/hours/totals/departments [get]
+func (h *HoursHandler) DepartmentTotals(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.HoursTotalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	totals, err := h.HoursUsecase.DepartmentTotals(userId.(int), query)
+	if err != nil {
+		c.JSON(hoursErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// hoursErrorStatus maps hours errors to HTTP status codes.
+func hoursErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrHoursNotFound), errors.Is(err, domain.ErrVolunteerNotFound),
+		errors.Is(err, activityDomain.ErrActivityNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrHoursInFuture):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrOwnHours), errors.Is(err, domain.ErrNotSignedUp),
+		errors.Is(err, domain.ErrOutsideDepartment):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrHoursApproved), errors.Is(err, domain.ErrHoursNotPending):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,192 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHoursUsecase struct {
+	mock.Mock
+}
+
+func (m *MockHoursUsecase) LogHours(userID int, input dto.LogHoursDTO) (*dto.HoursEntryDTO, error) {
+	args := m.Called(userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.HoursEntryDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) ListMyHours(userID int, query dto.ListHoursQuery) (*dto.ListHoursDTO, error) {
+	args := m.Called(userID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ListHoursDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) UpdateMyHours(userID int, id int, input dto.LogHoursDTO) (*dto.HoursEntryDTO, error) {
+	args := m.Called(userID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.HoursEntryDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) DeleteMyHours(userID int, id int) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockHoursUsecase) ListHours(reviewerID int, query dto.ListHoursQuery) (*dto.ListHoursDTO, error) {
+	args := m.Called(reviewerID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ListHoursDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) ApproveHours(reviewerID int, id int, input dto.ApproveHoursDTO) (*dto.HoursEntryDTO, error) {
+	args := m.Called(reviewerID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.HoursEntryDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) DisputeHours(reviewerID int, id int, input dto.DisputeHoursDTO) (*dto.HoursEntryDTO, error) {
+	args := m.Called(reviewerID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.HoursEntryDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) VolunteerTotals(reviewerID int, query dto.HoursTotalsQuery) ([]dto.VolunteerHoursTotalDTO, error) {
+	args := m.Called(reviewerID, query)
+	return args.Get(0).([]dto.VolunteerHoursTotalDTO), args.Error(1)
+}
+
+func (m *MockHoursUsecase) DepartmentTotals(reviewerID int, query dto.HoursTotalsQuery) ([]dto.DepartmentHoursTotalDTO, error) {
+	args := m.Called(reviewerID, query)
+	return args.Get(0).([]dto.DepartmentHoursTotalDTO), args.Error(1)
+}
+
+func TestLogMyHours(t *testing.T) {
+	mockUsecase := new(MockHoursUsecase)
+	handler := NewHoursHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/me/hours", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.LogMyHours(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("LogHours", 11, dto.LogHoursDTO{Date: "2024-03-01", Minutes: 90, Notes: "sorting"}).
+			Return(&dto.HoursEntryDTO{ID: 8, Date: "2024-03-01", Minutes: 90, Status: domain.HoursPending}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/me/hours", strings.NewReader(`{"date":"2024-03-01","minutes":90,"notes":"sorting"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"pending"`)
+	})
+
+	t.Run("future date", func(t *testing.T) {
+		mockUsecase.On("LogHours", 11, dto.LogHoursDTO{Date: "2099-03-01", Minutes: 60}).Return(nil, domain.ErrHoursInFuture)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/me/hours", strings.NewReader(`{"date":"2099-03-01","minutes":60}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("too many minutes", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/me/hours", strings.NewReader(`{"date":"2024-03-01","minutes":1500}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReviewHoursHandlers(t *testing.T) {
+	mockUsecase := new(MockHoursUsecase)
+	handler := NewHoursHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/hours/:id/approve", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.ApproveHours(c)
+	})
+	r.POST("/api/v1/hours/:id/dispute", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.DisputeHours(c)
+	})
+
+	t.Run("approve without a note", func(t *testing.T) {
+		mockUsecase.On("ApproveHours", 7, 8, dto.ApproveHoursDTO{}).
+			Return(&dto.HoursEntryDTO{ID: 8, Status: domain.HoursApproved}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/hours/8/approve", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("approve twice", func(t *testing.T) {
+		mockUsecase.On("ApproveHours", 7, 9, dto.ApproveHoursDTO{}).Return(nil, domain.ErrHoursNotPending)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/hours/9/approve", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("dispute outside department", func(t *testing.T) {
+		mockUsecase.On("DisputeHours", 7, 8, dto.DisputeHoursDTO{Reason: "wrong day"}).Return(nil, domain.ErrOutsideDepartment)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/hours/8/dispute", strings.NewReader(`{"reason":"wrong day"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("dispute without a reason", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/hours/8/dispute", strings.NewReader(`{}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
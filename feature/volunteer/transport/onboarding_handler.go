@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type OnboardingHandler struct {
+	OnboardingUsecase usecase.OnboardingUsecaseInterface
+}
+
+func NewOnboardingHandler(onboardingUsecase usecase.OnboardingUsecaseInterface) *OnboardingHandler {
+	return &OnboardingHandler{OnboardingUsecase: onboardingUsecase}
+}
+
+// OnboardVolunteer godoc
+// @Summary Onboard volunteer
+// @Description Create a verified account, identity and volunteer record for someone added by an admin, and email them an account setup link
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param request body dto.OnboardVolunteerDTO true "Onboard Volunteer Request"
+// @Success 201 {object} dto.OnboardVolunteerResponseDTO
+// @Failure 400 string error
+// @Failure 403 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/ [post]
+func (h *OnboardingHandler) OnboardVolunteer(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.OnboardVolunteerDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	volunteer, err := h.OnboardingUsecase.OnboardVolunteer(userId.(int), input)
+	if errors.Is(err, domain.ErrOutsideDepartment) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrEmailTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, volunteer)
+}
@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOnboardingUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOnboardingUsecase) OnboardVolunteer(adminID int, input dto.OnboardVolunteerDTO) (*dto.OnboardVolunteerResponseDTO, error) {
+	args := m.Called(adminID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OnboardVolunteerResponseDTO), args.Error(1)
+}
+
+func TestOnboardVolunteer(t *testing.T) {
+	mockUsecase := new(MockOnboardingUsecase)
+	handler := NewOnboardingHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/volunteer", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.OnboardVolunteer(c)
+	})
+
+	body := func(email string) string {
+		return `{"email":"` + email + `","name":"Anna","surname":"Smith","gender":"female","dob":"1990-04-01",` +
+			`"mobile":"123","country_id":1,"resident_country_id":1,"department_id":2,` +
+			`"identity":{"number":"X1","type":"passport","expiry_date":"2030-01-01","place_issued":"Hanoi"}}`
+	}
+	input := func(email string) dto.OnboardVolunteerDTO {
+		return dto.OnboardVolunteerDTO{
+			Email: email, Name: "Anna", Surname: "Smith", Gender: "female", DOB: "1990-04-01",
+			Mobile: "123", CountryID: 1, ResidentCountryID: 1, DepartmentID: 2,
+			Identity: dto.OnboardIdentityDTO{Number: "X1", Type: "passport", ExpiryDate: "2030-01-01", PlaceIssued: "Hanoi"},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("OnboardVolunteer", 7, input("anna@example.com")).
+			Return(&dto.OnboardVolunteerResponseDTO{ID: 5, UserID: 11, Email: "anna@example.com", OnboardedBy: 7}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(body("anna@example.com")))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"onboarded_by":7`)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		mockUsecase.On("OnboardVolunteer", 7, input("bob@example.com")).Return(nil, domain.ErrEmailTaken)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(body("bob@example.com")))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		mockUsecase.On("OnboardVolunteer", 7, input("carl@example.com")).Return(nil, domain.ErrOutsideDepartment)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(body("carl@example.com")))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("missing identity", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(`{"email":"anna@example.com","name":"Anna"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
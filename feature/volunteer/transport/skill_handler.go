@@ -0,0 +1,408 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type SkillHandler struct {
+	SkillUsecase usecase.SkillUsecaseInterface
+}
+
+func NewSkillHandler(skillUsecase usecase.SkillUsecaseInterface) *SkillHandler {
+	return &SkillHandler{SkillUsecase: skillUsecase}
+}
+
+// CreateSkill godoc
+// @Summary Create skill
+// @Description Add a skill to the catalog, such as a language, first aid or a driving licence. Names are unique regardless of case
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param request body dto.SkillDTO true "Skill Request"
+// @Success 201 {object} dto.SkillResponseDTO
+// @Failure 400 string error
+// @Failure 409 string error
+// @Router /api/v1/skill/ [post]
+func (h *SkillHandler) CreateSkill(c *gin.Context) {
+	var input dto.SkillDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.SkillUsecase.CreateSkill(input)
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, skill)
+}
+
+// ListSkills godoc
+// @Summary List skills
+// @Description List the skill catalog by category and name
+// @Produce json
+// @Tags skill
+// @Param category query string false "Category"
+// @Success 200 {array} dto.SkillResponseDTO
+// @Router /api/v1/skill/ [get]
+func (h *SkillHandler) ListSkills(c *gin.Context) {
+	var query dto.ListSkillQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skills, err := h.SkillUsecase.ListSkills(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// GetSkillByID godoc
+// @Summary Get skill
+// @Description Get a skill of the catalog
+// @Produce json
+// @Tags skill
+// @Param id path int true "Skill ID"
+// @Success 200 {object} dto.SkillResponseDTO
+// @Failure 404 string error
+// @Router /api/v1/skill/{id} [get]
+func (h *SkillHandler) GetSkillByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	skill, err := h.SkillUsecase.FindSkillByID(id)
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skill)
+}
+
+// UpdateSkill godoc
+// @Summary Update skill
+// @Description Update a skill of the catalog
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param id path int true "Skill ID"
+// @Param request body dto.SkillDTO true "Skill Request"
+// @Success 200 {object} dto.SkillResponseDTO
+// @Failure 400 string error
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/skill/{id} [put]
+func (h *SkillHandler) UpdateSkill(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	var input dto.SkillDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.SkillUsecase.UpdateSkill(id, input)
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skill)
+}
+
+// DeleteSkill godoc
+// @Summary Delete skill
+// @Description Delete a skill that no volunteer declared and no activity requires
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param id path int true "Skill ID"
+// @Success 200 {string} message "Skill deleted successfully"
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/skill/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	if err := h.SkillUsecase.DeleteSkill(id); err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skill deleted successfully"})
+}
+
+// ListMySkills godoc
+// @Summary List my skills
+// @Description List the skills the current volunteer declared and whether they were verified
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Success 200 {array} dto.VolunteerSkillDTO
+// @Failure 404 string error
+// @Router /api/v1/me/skills [get]
+func (h *SkillHandler) ListMySkills(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	skills, err := h.SkillUsecase.ListMySkills(userId.(int))
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// DeclareMySkill godoc
+// @Summary Declare a skill
+// @Description Declare a skill of the current volunteer or change its note. Changing the note drops the verification
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param skillId path int true "Skill ID"
+// @Param request body dto.DeclareSkillDTO true "Declare Skill Request"
+// @Success 200 {object} dto.VolunteerSkillDTO
+// @Failure 400 string error
+// @Failure 404 string error
+// @Router /api/v1/me/skills/{skillId} [put]
+func (h *SkillHandler) DeclareMySkill(c *gin.Context) {
+	skillID, err := strconv.Atoi(c.Param("skillId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.DeclareSkillDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.SkillUsecase.DeclareMySkill(userId.(int), skillID, input)
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skill)
+}
+
+// RemoveMySkill godoc
+// @Summary Remove a skill
+// @Description Remove a skill the current volunteer declared
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param skillId path int true "Skill ID"
+// @Success 200 {string} message "Skill removed successfully"
+// @Failure 404 string error
+// @Router /api/v1/me/skills/{skillId} [delete]
+func (h *SkillHandler) RemoveMySkill(c *gin.Context) {
+	skillID, err := strconv.Atoi(c.Param("skillId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.SkillUsecase.RemoveMySkill(userId.(int), skillID); err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skill removed successfully"})
+}
+
+// ListVolunteerSkills godoc
+// @Summary List the skills of a volunteer
+// @Description List the skills a volunteer declared and whether they were verified
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.VolunteerSkillDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/volunteer/{id}/skills [get]
+func (h *SkillHandler) ListVolunteerSkills(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	skills, err := h.SkillUsecase.ListVolunteerSkills(userId.(int), id)
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// VerifySkill godoc
+// @Summary Verify a skill
+// @Description Verify a skill a volunteer declared, never one of your own
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param skillId path int true "Skill ID"
+// @Success 200 {object} dto.VolunteerSkillDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/volunteer/{id}/skills/{skillId}/verify [post]
+func (h *SkillHandler) VerifySkill(c *gin.Context) {
+	h.verifySkill(c, true)
+}
+
+// UnverifySkill godoc
+// @Summary Withdraw a skill verification
+// @Description Withdraw the verification of a skill a volunteer declared
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param skillId path int true "Skill ID"
+// @Success 200 {object} dto.VolunteerSkillDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/volunteer/{id}/skills/{skillId}/verify [delete]
+func (h *SkillHandler) UnverifySkill(c *gin.Context) {
+	h.verifySkill(c, false)
+}
+
+func (h *SkillHandler) verifySkill(c *gin.Context, verified bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	skillID, err := strconv.Atoi(c.Param("skillId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var skill *dto.VolunteerSkillDTO
+	if verified {
+		skill, err = h.SkillUsecase.VerifySkill(userId.(int), id, skillID)
+	} else {
+		skill, err = h.SkillUsecase.UnverifySkill(userId.(int), id, skillID)
+	}
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skill)
+}
+
+// MatchVolunteers godoc
+// @Summary Match volunteers to an activity
+// @Description Rank the active volunteers who did not sign up for an activity by the required skills they hold (2 points when verified, 1 otherwise), their availability (3 points for the whole activity, 1 for part of it) and its department (2 points)
+// @Produce json
+// @Tags skill
+// @Security bearerToken
+// @Param id path int true "Activity ID"
+// @Param limit query int false "Volunteers returned, 20 by default and at most 100"
+// @Param all_skills query bool false "Only volunteers holding every required skill"
+// @Param verified_only query bool false "Only count verified skills"
+// @Success 200 {array} dto.VolunteerMatchDTO
+// @Failure 403 string error
+// @Failure 404 string error
+// @Router /api/v1/activity/{id}/matches [get]
+func (h *SkillHandler) MatchVolunteers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var query dto.MatchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	matches, err := h.SkillUsecase.MatchVolunteers(userId.(int), id, query)
+	if err != nil {
+		c.JSON(skillErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+// skillErrorStatus maps skill errors to HTTP status codes.
+func skillErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrSkillNotFound), errors.Is(err, domain.ErrVolunteerSkillNotFound),
+		errors.Is(err, domain.ErrVolunteerNotFound), errors.Is(err, activityDomain.ErrActivityNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrSkillNameTaken), errors.Is(err, domain.ErrSkillInUse):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrOwnSkill), errors.Is(err, domain.ErrOutsideDepartment):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,244 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSkillUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSkillUsecase) CreateSkill(input dto.SkillDTO) (*dto.SkillResponseDTO, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SkillResponseDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) UpdateSkill(id int, input dto.SkillDTO) (*dto.SkillResponseDTO, error) {
+	args := m.Called(id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SkillResponseDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) DeleteSkill(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSkillUsecase) FindSkillByID(id int) (*dto.SkillResponseDTO, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SkillResponseDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) ListSkills(query dto.ListSkillQuery) ([]dto.SkillResponseDTO, error) {
+	args := m.Called(query)
+	return args.Get(0).([]dto.SkillResponseDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) ListMySkills(userID int) ([]dto.VolunteerSkillDTO, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]dto.VolunteerSkillDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) DeclareMySkill(userID int, skillID int, input dto.DeclareSkillDTO) (*dto.VolunteerSkillDTO, error) {
+	args := m.Called(userID, skillID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VolunteerSkillDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) RemoveMySkill(userID int, skillID int) error {
+	args := m.Called(userID, skillID)
+	return args.Error(0)
+}
+
+func (m *MockSkillUsecase) ListVolunteerSkills(adminID int, volunteerID int) ([]dto.VolunteerSkillDTO, error) {
+	args := m.Called(adminID, volunteerID)
+	return args.Get(0).([]dto.VolunteerSkillDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) VerifySkill(adminID int, volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error) {
+	args := m.Called(adminID, volunteerID, skillID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VolunteerSkillDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) UnverifySkill(adminID int, volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error) {
+	args := m.Called(adminID, volunteerID, skillID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VolunteerSkillDTO), args.Error(1)
+}
+
+func (m *MockSkillUsecase) MatchVolunteers(adminID int, activityID int, query dto.MatchQuery) ([]dto.VolunteerMatchDTO, error) {
+	args := m.Called(adminID, activityID, query)
+	return args.Get(0).([]dto.VolunteerMatchDTO), args.Error(1)
+}
+
+func TestCreateSkill(t *testing.T) {
+	mockUsecase := new(MockSkillUsecase)
+	handler := NewSkillHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/skill/", handler.CreateSkill)
+
+	t.Run("success", func(t *testing.T) {
+		input := dto.SkillDTO{Name: "First aid", Category: "health"}
+		mockUsecase.On("CreateSkill", input).Return(&dto.SkillResponseDTO{ID: 1, Name: "First aid", Category: "health"}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/skill/", strings.NewReader(`{"name":"First aid","category":"health"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		input := dto.SkillDTO{Name: "Spanish"}
+		mockUsecase.On("CreateSkill", input).Return(nil, domain.ErrSkillNameTaken)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/skill/", strings.NewReader(`{"name":"Spanish"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestDeclareMySkill(t *testing.T) {
+	mockUsecase := new(MockSkillUsecase)
+	handler := NewSkillHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PUT("/api/v1/me/skills/:skillId", func(c *gin.Context) {
+		c.Set("userId", 11)
+		handler.DeclareMySkill(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("DeclareMySkill", 11, 2, dto.DeclareSkillDTO{Note: "C1"}).Return(&dto.VolunteerSkillDTO{SkillID: 2, Name: "Spanish", Note: "C1"}, nil)
+
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/me/skills/2", strings.NewReader(`{"note":"C1"}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"verified":false`)
+	})
+
+	t.Run("unknown skill", func(t *testing.T) {
+		mockUsecase.On("DeclareMySkill", 11, 9, dto.DeclareSkillDTO{}).Return(nil, domain.ErrSkillNotFound)
+
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/me/skills/9", strings.NewReader(`{}`))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestVerifySkill(t *testing.T) {
+	mockUsecase := new(MockSkillUsecase)
+	handler := NewSkillHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/volunteer/:id/skills/:skillId/verify", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.VerifySkill(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("VerifySkill", 7, 3, 2).Return(&dto.VolunteerSkillDTO{SkillID: 2, Verified: true}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/3/skills/2/verify", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("own skill", func(t *testing.T) {
+		mockUsecase.On("VerifySkill", 7, 4, 2).Return(nil, domain.ErrOwnSkill)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/4/skills/2/verify", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestMatchVolunteers(t *testing.T) {
+	mockUsecase := new(MockSkillUsecase)
+	handler := NewSkillHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/activity/:id/matches", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.MatchVolunteers(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		query := dto.MatchQuery{Limit: 5, AllSkills: true}
+		mockUsecase.On("MatchVolunteers", 7, 4, query).Return([]dto.VolunteerMatchDTO{{VolunteerID: 1, Score: 7}}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/activity/4/matches?limit=5&all_skills=true", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"score":7`)
+	})
+
+	t.Run("limit too high", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/activity/4/matches?limit=500", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
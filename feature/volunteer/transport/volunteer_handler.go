@@ -1,9 +1,12 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
 	"github.com/gin-gonic/gin"
@@ -17,29 +20,6 @@ func NewVolunteerHandler(volUsecase usecase.VolunteerUsecaseInterface) *Voluntee
 	return &VolunteerHandler{VolUsecaseH: volUsecase}
 }
 
-// CreateVolunteer godoc
-// @Summary Create volunteer
-// @Description Create volunteer
-// @Produce json
-// @Tags volunteer
-// @Param request body dto.VolunteerCreateDTO true "Create Volunteer Request"
-// @Success 201 {string} message "Volunteer created successfully"
-// @Router /api/v1/volunteer/ [post]
-func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
-	var input dto.VolunteerCreateDTO
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	if err := h.VolUsecaseH.CreateVolunteer(input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusCreated, gin.H{"message": "User created successfully"})
-}
-
 // UpdateVolunteer godoc
 // @Summary Update volunteer
 // @Description Update volunteer
@@ -48,8 +28,15 @@ func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
 // @Param id path int true "Volunteer ID"
 // @Param request body dto.VolunteerUpdateDTO true "Update Volunteer Request"
 // @Success 200 {string} message "Volunteer updated successfully"
+// @Security bearerToken
+// @Failure 401 string error
 // @Router /api/v1/volunteer/{id} [put]
 func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
+	if _, exists := c.Get("userId"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
@@ -77,8 +64,15 @@ func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 // @Tags volunteer
 // @Param id path int true "Volunteer ID"
 // @Success 200 {string} message "Volunteer deleted successfully"
+// @Security bearerToken
+// @Failure 401 string error
 // @Router /api/v1/volunteer/{id} [delete]
 func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
+	if _, exists := c.Get("userId"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
@@ -93,6 +87,39 @@ func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Volunteer deleted successfully"})
 }
 
+// ListVolunteers godoc
+// @Summary List volunteers
+// @Description Get a page of the volunteer directory with the user, department, role and current position of every volunteer
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param page query int false "Page, starting at 1"
+// @Param page_size query int false "Page size, at most 100"
+// @Param search query string false "Name, surname or email contains, or volunteer ID"
+// @Param gender query string false "Gender" Enums(male, female, other)
+// @Param role_id query int false "Role ID"
+// @Param department_id query int false "Department ID"
+// @Param position_id query int false "Current position ID"
+// @Param status query int false "Volunteer status"
+// @Param sort query string false "Sort field, prefix with - for descending" Enums(id, -id, name, -name, email, -email, department, -department, position, -position, created_at, -created_at)
+// @Success 200 {object} dto.ListVolunteerDTO
+// @Router /api/v1/volunteer/ [get]
+func (h *VolunteerHandler) ListVolunteers(c *gin.Context) {
+	var query dto.ListVolunteerQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	volunteers, err := h.VolUsecaseH.ListVolunteers(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, volunteers)
+}
+
 // FindVolunteerByID godoc
 // @Summary Find volunteer by ID
 // @Description Find volunteer by ID
@@ -100,8 +127,15 @@ func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
 // @Tags volunteer
 // @Param id path int true "Volunteer ID"
 // @Success 200 {object} domain.Volunteer
+// @Security bearerToken
+// @Failure 401 string error
 // @Router /api/v1/volunteer/{id} [get]
 func (h *VolunteerHandler) FindVolunteerByID(c *gin.Context) {
+	if _, exists := c.Get("userId"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
@@ -116,3 +150,238 @@ func (h *VolunteerHandler) FindVolunteerByID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, volunteer)
 }
+
+// ListDeletedVolunteers godoc
+// @Summary List deleted volunteers
+// @Description Get the volunteers in the trash, most recently deleted first
+// @Produce json
+// @Tags admin
+// @Success 200 {array} dto.VolunteerResponseDTO
+// @Security bearerToken
+// @Router /api/v1/admin/trash/volunteers [get]
+func (h *VolunteerHandler) ListDeletedVolunteers(c *gin.Context) {
+	volunteers, err := h.VolUsecaseH.ListDeletedVolunteers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, volunteers)
+}
+
+// RestoreVolunteer godoc
+// @Summary Restore volunteer
+// @Description Take a deleted volunteer out of the trash
+// @Produce json
+// @Tags admin
+// @Param id path int true "Volunteer ID"
+// @Success 200 string message
+// @Failure 404 string error
+// @Security bearerToken
+// @Router /api/v1/admin/trash/volunteers/{id}/restore [post]
+func (h *VolunteerHandler) RestoreVolunteer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	if err = h.VolUsecaseH.RestoreVolunteer(id); err != nil {
+		if errors.Is(err, domain.ErrVolunteerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Volunteer restored successfully"})
+}
+
+// AssignPosition godoc
+// @Summary Assign position
+// @Description Give the volunteer a position of the catalog, ending the position they held until now
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.AssignPositionDTO true "Assign Position Request"
+// @Success 201 {object} dto.PositionAssignmentDTO
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/positions [post]
+func (h *VolunteerHandler) AssignPosition(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.AssignPositionDTO
+	if err = c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, err := h.VolUsecaseH.AssignPosition(id, userId.(int), input)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// EndPosition godoc
+// @Summary End current position
+// @Description End the position the volunteer currently holds
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Success 200 {string} message "Position ended successfully"
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/positions/current [delete]
+func (h *VolunteerHandler) EndPosition(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	if err = h.VolUsecaseH.EndPosition(id); err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Position ended successfully"})
+}
+
+// ListPositionHistory godoc
+// @Summary List position history
+// @Description Get the positions the volunteer held, the current one first
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.PositionAssignmentDTO
+// @Failure 404 string error
+// @Router /api/v1/volunteer/{id}/positions [get]
+func (h *VolunteerHandler) ListPositionHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	history, err := h.VolUsecaseH.ListPositionHistory(id)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// DeactivateVolunteer godoc
+// @Summary Deactivate volunteer
+// @Description Set the volunteer inactive for a reason. Depending on VOLUNTEER_DEACTIVATION_POLICY the user can no longer log in or is moved back to the applicant role
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.VolunteerStatusChangeDTO true "Deactivation reason"
+// @Success 200 {object} dto.VolunteerStatusChangeResponseDTO
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/deactivate [post]
+func (h *VolunteerHandler) DeactivateVolunteer(c *gin.Context) {
+	h.changeStatus(c, h.VolUsecaseH.DeactivateVolunteer)
+}
+
+// ReactivateVolunteer godoc
+// @Summary Reactivate volunteer
+// @Description Set a deactivated volunteer active again, restoring their login and role
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.VolunteerStatusChangeDTO true "Reactivation reason"
+// @Success 200 {object} dto.VolunteerStatusChangeResponseDTO
+// @Failure 404 string error
+// @Failure 409 string error
+// @Router /api/v1/volunteer/{id}/reactivate [post]
+func (h *VolunteerHandler) ReactivateVolunteer(c *gin.Context) {
+	h.changeStatus(c, h.VolUsecaseH.ReactivateVolunteer)
+}
+
+func (h *VolunteerHandler) changeStatus(c *gin.Context, change func(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input dto.VolunteerStatusChangeDTO
+	if err = c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := change(id, userId.(int), input)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListHistory godoc
+// @Summary Volunteer history
+// @Description Get the position changes, deactivations and reactivations of the volunteer, the latest first
+// @Produce json
+// @Tags volunteer
+// @Security bearerToken
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.VolunteerHistoryEntryDTO
+// @Failure 404 string error
+// @Router /api/v1/volunteer/{id}/history [get]
+func (h *VolunteerHandler) ListHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	history, err := h.VolUsecaseH.ListHistory(id)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// volunteerErrorStatus maps volunteer errors to HTTP status codes.
+func volunteerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrVolunteerNotFound), errors.Is(err, roleDomain.ErrPositionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrPositionAlreadyHeld), errors.Is(err, domain.ErrNoCurrentPosition),
+		errors.Is(err, domain.ErrVolunteerInactive), errors.Is(err, domain.ErrVolunteerActive):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -17,11 +18,6 @@ type MockVolunteerUsecase struct {
 	mock.Mock
 }
 
-func (m *MockVolunteerUsecase) CreateVolunteer(input dto.VolunteerCreateDTO) error {
-	args := m.Called(input)
-	return args.Error(0)
-}
-
 func (m *MockVolunteerUsecase) UpdateVolunteer(id int, input dto.VolunteerUpdateDTO) error {
 	args := m.Called(id, input)
 	return args.Error(0)
@@ -32,50 +28,69 @@ func (m *MockVolunteerUsecase) DeleteVolunteer(id int) error {
 	return args.Error(0)
 }
 
-func (m *MockVolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error) {
-	args := m.Called(id)
-	return args.Get(0).(*dto.VolunteerResponseDTO), args.Error(1)
+func (m *MockVolunteerUsecase) ListDeletedVolunteers() ([]*dto.VolunteerResponseDTO, error) {
+	args := m.Called()
+	return args.Get(0).([]*dto.VolunteerResponseDTO), args.Error(1)
 }
 
-func TestCreateVolunteer(t *testing.T) {
-	mockUsecase := new(MockVolunteerUsecase)
-	handler := NewVolunteerHandler(mockUsecase)
+func (m *MockVolunteerUsecase) RestoreVolunteer(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
 
-	gin.SetMode(gin.TestMode)
-	r := gin.Default()
-	r.POST("/api/v1/volunteer", handler.CreateVolunteer)
+func (m *MockVolunteerUsecase) ListVolunteers(query dto.ListVolunteerQuery) (*dto.ListVolunteerDTO, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ListVolunteerDTO), args.Error(1)
+}
 
-	t.Run("success", func(t *testing.T) {
-		mockInput := dto.VolunteerCreateDTO{
-			UserID:       1,
-			DepartmentID: 2,
-			Status:       0,
-		}
-		mockUsecase.On("CreateVolunteer", mockInput).Return(nil)
+func (m *MockVolunteerUsecase) AssignPosition(id int, adminID int, input dto.AssignPositionDTO) (*dto.PositionAssignmentDTO, error) {
+	args := m.Called(id, adminID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PositionAssignmentDTO), args.Error(1)
+}
 
-		body := `{"user_id":1,"department_id":2,"status":"Active"}`
-		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(body))
-		assert.NoError(t, err)
-		req.Header.Set("Content-Type", "application/json")
+func (m *MockVolunteerUsecase) EndPosition(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
 
-		rr := httptest.NewRecorder()
-		r.ServeHTTP(rr, req)
+func (m *MockVolunteerUsecase) ListPositionHistory(id int) ([]dto.PositionAssignmentDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).([]dto.PositionAssignmentDTO), args.Error(1)
+}
 
-		assert.Equal(t, http.StatusCreated, rr.Code)
-		mockUsecase.AssertExpectations(t)
-	})
+func (m *MockVolunteerUsecase) DeactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error) {
+	args := m.Called(id, adminID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VolunteerStatusChangeResponseDTO), args.Error(1)
+}
 
-	t.Run("bad request", func(t *testing.T) {
-		body := `{"user_id":"abc","department_id":2,"status":"Active"}`
-		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(body))
-		assert.NoError(t, err)
-		req.Header.Set("Content-Type", "application/json")
+func (m *MockVolunteerUsecase) ReactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error) {
+	args := m.Called(id, adminID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VolunteerStatusChangeResponseDTO), args.Error(1)
+}
 
-		rr := httptest.NewRecorder()
-		r.ServeHTTP(rr, req)
+func (m *MockVolunteerUsecase) ListHistory(id int) ([]dto.VolunteerHistoryEntryDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).([]dto.VolunteerHistoryEntryDTO), args.Error(1)
+}
 
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
-	})
+func (m *MockVolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VolunteerResponseDTO), args.Error(1)
 }
 
 func TestUpdateVolunteer(t *testing.T) {
@@ -84,12 +99,14 @@ func TestUpdateVolunteer(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
-	r.PUT("/api/v1/volunteer/:id", handler.UpdateVolunteer)
+	r.PUT("/api/v1/volunteer/:id", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.UpdateVolunteer(c)
+	})
 
 	t.Run("success", func(t *testing.T) {
 		mockInput := dto.VolunteerUpdateDTO{
 			DepartmentID: 2,
-			Status:       1,
 		}
 		mockUsecase.On("UpdateVolunteer", 1, mockInput).Return(nil)
 
@@ -124,7 +141,10 @@ func TestDeleteVolunteer(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
-	r.DELETE("/api/v1/volunteer/:id", handler.DeleteVolunteer)
+	r.DELETE("/api/v1/volunteer/:id", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.DeleteVolunteer(c)
+	})
 
 	t.Run("success", func(t *testing.T) {
 		mockUsecase.On("DeleteVolunteer", 1).Return(nil)
@@ -156,7 +176,10 @@ func TestFindVolunteerByID(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
-	r.GET("/api/v1/volunteer/:id", handler.FindVolunteerByID)
+	r.GET("/api/v1/volunteer/:id", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.FindVolunteerByID(c)
+	})
 
 	t.Run("success", func(t *testing.T) {
 		mockVolunteer := &dto.VolunteerResponseDTO{
@@ -178,9 +201,9 @@ func TestFindVolunteerByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUsecase.On("FindVolunteerByID", 1).Return(nil, errors.New("volunteer not found"))
+		mockUsecase.On("FindVolunteerByID", 2).Return(nil, errors.New("volunteer not found"))
 
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/volunteer/1", nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/volunteer/2", nil)
 		assert.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -188,4 +211,169 @@ func TestFindVolunteerByID(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
-}
\ No newline at end of file
+}
+func TestListVolunteers(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/api/v1/volunteer", handler.ListVolunteers)
+
+	t.Run("success", func(t *testing.T) {
+		role := 3
+		query := dto.ListVolunteerQuery{Search: "ann", Gender: "female", RoleID: &role, Sort: "-name"}
+		mockUsecase.On("ListVolunteers", query).Return(&dto.ListVolunteerDTO{
+			Volunteers: []dto.VolunteerListItemDTO{{ID: 1, Name: "Anna", DepartmentName: "Kitchen"}},
+			Page:       1,
+			PageSize:   20,
+			Total:      1,
+			TotalPages: 1,
+		}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/volunteer?search=ann&gender=female&role_id=3&sort=-name", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"department_name":"Kitchen"`)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/volunteer?gender=unknown", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestAssignPosition(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/volunteer/:id/positions", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.AssignPosition(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("AssignPosition", 1, 7, dto.AssignPositionDTO{PositionID: 2}).
+			Return(&dto.PositionAssignmentDTO{ID: 5, VolunteerID: 1, PositionID: 2, PositionCode: "MNVC"}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/1/positions", strings.NewReader(`{"position_id":2}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"position_code":"MNVC"`)
+	})
+
+	t.Run("already held", func(t *testing.T) {
+		mockUsecase.On("AssignPosition", 1, 7, dto.AssignPositionDTO{PositionID: 3}).Return(nil, domain.ErrPositionAlreadyHeld)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/1/positions", strings.NewReader(`{"position_id":3}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("unknown volunteer", func(t *testing.T) {
+		mockUsecase.On("AssignPosition", 9, 7, dto.AssignPositionDTO{PositionID: 2}).Return(nil, domain.ErrVolunteerNotFound)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/9/positions", strings.NewReader(`{"position_id":2}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestDeactivateVolunteer(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/api/v1/volunteer/:id/deactivate", func(c *gin.Context) {
+		c.Set("userId", 7)
+		handler.DeactivateVolunteer(c)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("DeactivateVolunteer", 1, 7, dto.VolunteerStatusChangeDTO{Reason: "moved abroad"}).
+			Return(&dto.VolunteerStatusChangeResponseDTO{ID: 3, VolunteerID: 1, Status: 0, Reason: "moved abroad", ChangedBy: 7}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/1/deactivate", strings.NewReader(`{"reason":"moved abroad"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"changed_by":7`)
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/1/deactivate", strings.NewReader(`{}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("already deactivated", func(t *testing.T) {
+		mockUsecase.On("DeactivateVolunteer", 2, 7, dto.VolunteerStatusChangeDTO{Reason: "again"}).Return(nil, domain.ErrVolunteerInactive)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer/2/deactivate", strings.NewReader(`{"reason":"again"}`))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestVolunteerRecordRequiresAuth(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.PUT("/api/v1/volunteer/:id", handler.UpdateVolunteer)
+	r.DELETE("/api/v1/volunteer/:id", handler.DeleteVolunteer)
+	r.GET("/api/v1/volunteer/:id", handler.FindVolunteerByID)
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete, http.MethodGet} {
+		t.Run(method, func(t *testing.T) {
+			req, err := http.NewRequest(method, "/api/v1/volunteer/1", strings.NewReader(`{"department_id":2}`))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		})
+	}
+	mockUsecase.AssertNotCalled(t, "UpdateVolunteer", mock.Anything, mock.Anything)
+	mockUsecase.AssertNotCalled(t, "DeleteVolunteer", mock.Anything)
+	mockUsecase.AssertNotCalled(t, "FindVolunteerByID", mock.Anything)
+}
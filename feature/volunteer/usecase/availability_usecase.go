@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+// maxAvailabilityDays is the longest period coordinators can look up at once.
+const maxAvailabilityDays = 31
+
+type AvailabilityUsecaseInterface interface {
+	GetMyAvailability(userID int) (*dto.MyAvailabilityDTO, error)
+	ReplaceMySlots(userID int, input dto.AvailabilitySlotsDTO) (*dto.MyAvailabilityDTO, error)
+	AddMyException(userID int, input dto.AvailabilityExceptionDTO) (*dto.AvailabilityExceptionResponseDTO, error)
+	DeleteMyException(userID int, id int) error
+	ListAvailability(adminID int, query dto.AvailabilityQuery) ([]dto.VolunteerAvailabilityDTO, error)
+}
+
+// AvailabilityUsecase lets volunteers declare when they are usually free and
+// the days that differ, and coordinators see who is free over a period.
+// Coordinators who belong to a department only see its volunteers.
+type AvailabilityUsecase struct {
+	AvailabilityRepo storage.AvailabilityRepositoryInterface
+	DepartmentScope  departmentUsecase.DepartmentScopeInterface
+}
+
+func NewAvailabilityUsecase(availabilityRepo storage.AvailabilityRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface) *AvailabilityUsecase {
+	return &AvailabilityUsecase{AvailabilityRepo: availabilityRepo, DepartmentScope: departmentScope}
+}
+
+// GetMyAvailability returns the weekly slots of the volunteer and their
+// exceptions from today on.
+func (u *AvailabilityUsecase) GetMyAvailability(userID int) (*dto.MyAvailabilityDTO, error) {
+	volunteer, err := u.AvailabilityRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.myAvailability(volunteer.ID)
+}
+
+// ReplaceMySlots replaces the weekly slots of the volunteer. Slots of a day
+// cannot overlap.
+func (u *AvailabilityUsecase) ReplaceMySlots(userID int, input dto.AvailabilitySlotsDTO) (*dto.MyAvailabilityDTO, error) {
+	volunteer, err := u.AvailabilityRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]*domain.AvailabilitySlot, 0, len(input.Slots))
+	byDay := make(map[int][]window)
+	for _, slot := range input.Slots {
+		w, err := parseWindow(slot.StartTime, slot.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range byDay[slot.Weekday] {
+			if w.start < other.end && other.start < w.end {
+				return nil, domain.ErrSlotOverlap
+			}
+		}
+		byDay[slot.Weekday] = append(byDay[slot.Weekday], w)
+		slots = append(slots, &domain.AvailabilitySlot{
+			VolunteerID: volunteer.ID,
+			Weekday:     slot.Weekday,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+		})
+	}
+	if err := u.AvailabilityRepo.ReplaceSlots(volunteer.ID, slots); err != nil {
+		return nil, err
+	}
+	return u.myAvailability(volunteer.ID)
+}
+
+func (u *AvailabilityUsecase) AddMyException(userID int, input dto.AvailabilityExceptionDTO) (*dto.AvailabilityExceptionResponseDTO, error) {
+	volunteer, err := u.AvailabilityRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(time.DateOnly, input.Date)
+	if err != nil {
+		return nil, err
+	}
+	if input.StartTime != "" {
+		if _, err := parseWindow(input.StartTime, input.EndTime); err != nil {
+			return nil, err
+		}
+	}
+	exception := &domain.AvailabilityException{
+		VolunteerID: volunteer.ID,
+		Date:        date,
+		StartTime:   input.StartTime,
+		EndTime:     input.EndTime,
+		Available:   input.Available,
+		Note:        input.Note,
+	}
+	if err := u.AvailabilityRepo.CreateException(exception); err != nil {
+		return nil, err
+	}
+	response := toAvailabilityException(exception)
+	return &response, nil
+}
+
+func (u *AvailabilityUsecase) DeleteMyException(userID int, id int) error {
+	volunteer, err := u.AvailabilityRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return err
+	}
+	return u.AvailabilityRepo.DeleteException(volunteer.ID, id)
+}
+
+// ListAvailability returns, for every active volunteer matching the query,
+// the periods they are available each day from From to To, the coming week
+// by default.
+func (u *AvailabilityUsecase) ListAvailability(adminID int, query dto.AvailabilityQuery) ([]dto.VolunteerAvailabilityDTO, error) {
+	departmentID, err := u.DepartmentScope.ScopeDepartment(adminID, query.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := today()
+	if query.From != nil {
+		from = *query.From
+	}
+	to := from.AddDate(0, 0, 6)
+	if query.To != nil {
+		to = *query.To
+	}
+	if to.Before(from) || !to.Before(from.AddDate(0, 0, maxAvailabilityDays)) {
+		return nil, domain.ErrAvailabilityPeriod
+	}
+
+	volunteers, err := u.AvailabilityRepo.ListActiveVolunteers(departmentID, query.VolunteerID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.VolunteerAvailabilityDTO, 0, len(volunteers))
+	if len(volunteers) == 0 {
+		return response, nil
+	}
+	ids := make([]int, 0, len(volunteers))
+	for _, volunteer := range volunteers {
+		ids = append(ids, volunteer.ID)
+	}
+	slots, err := u.AvailabilityRepo.ListSlots(ids)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := u.AvailabilityRepo.ListExceptions(ids, from, &to)
+	if err != nil {
+		return nil, err
+	}
+	slotsOf := make(map[int][]*domain.AvailabilitySlot)
+	for _, slot := range slots {
+		slotsOf[slot.VolunteerID] = append(slotsOf[slot.VolunteerID], slot)
+	}
+	exceptionsOf := make(map[int][]*domain.AvailabilityException)
+	for _, exception := range exceptions {
+		exceptionsOf[exception.VolunteerID] = append(exceptionsOf[exception.VolunteerID], exception)
+	}
+	for _, volunteer := range volunteers {
+		response = append(response, dto.VolunteerAvailabilityDTO{
+			VolunteerID:  volunteer.ID,
+			Name:         volunteer.Name,
+			Surname:      volunteer.Surname,
+			DepartmentID: volunteer.DepartmentID,
+			Windows:      resolveAvailability(slotsOf[volunteer.ID], exceptionsOf[volunteer.ID], from, to),
+		})
+	}
+	return response, nil
+}
+
+func (u *AvailabilityUsecase) myAvailability(volunteerID int) (*dto.MyAvailabilityDTO, error) {
+	slots, err := u.AvailabilityRepo.ListSlots([]int{volunteerID})
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := u.AvailabilityRepo.ListExceptions([]int{volunteerID}, today(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.MyAvailabilityDTO{
+		Slots:      make([]dto.AvailabilitySlotDTO, 0, len(slots)),
+		Exceptions: make([]dto.AvailabilityExceptionResponseDTO, 0, len(exceptions)),
+	}
+	for _, slot := range slots {
+		response.Slots = append(response.Slots, dto.AvailabilitySlotDTO{
+			Weekday:   slot.Weekday,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+		})
+	}
+	for _, exception := range exceptions {
+		response.Exceptions = append(response.Exceptions, toAvailabilityException(exception))
+	}
+	return response, nil
+}
+
+// window is a period of a day in minutes from midnight, end excluded.
+type window struct {
+	start, end int
+}
+
+// wholeDay is the window of an exception without times.
+var wholeDay = window{start: 0, end: 24 * 60}
+
+// resolveAvailability returns the periods the volunteer is available each day
+// from from to to.
+func resolveAvailability(slots []*domain.AvailabilitySlot, exceptions []*domain.AvailabilityException, from time.Time, to time.Time) []dto.AvailabilityWindowDTO {
+	windows := make([]dto.AvailabilityWindowDTO, 0)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, w := range availableWindows(slots, exceptions, day) {
+			windows = append(windows, dto.AvailabilityWindowDTO{
+				Date:      day.Format(time.DateOnly),
+				StartTime: formatClock(w.start),
+				EndTime:   formatClock(w.end),
+			})
+		}
+	}
+	return windows
+}
+
+// availableWindows returns the periods the volunteer is available on a day.
+// A day starts with the weekly slots of its weekday, then loses the periods of
+// the unavailable exceptions and gains those of the available ones.
+func availableWindows(slots []*domain.AvailabilitySlot, exceptions []*domain.AvailabilityException, day time.Time) []window {
+	var available []window
+	for _, slot := range slots {
+		if slot.Weekday == int(day.Weekday()) {
+			if w, err := parseWindow(slot.StartTime, slot.EndTime); err == nil {
+				available = append(available, w)
+			}
+		}
+	}
+	var added []window
+	for _, exception := range exceptions {
+		if exception.Date.Format(time.DateOnly) != day.Format(time.DateOnly) {
+			continue
+		}
+		w := wholeDay
+		if exception.StartTime != "" {
+			var err error
+			if w, err = parseWindow(exception.StartTime, exception.EndTime); err != nil {
+				continue
+			}
+		}
+		if exception.Available {
+			added = append(added, w)
+		} else {
+			available = subtractWindow(available, w)
+		}
+	}
+	return mergeWindows(append(available, added...))
+}
+
+// availabilityDuring tells whether the volunteer is available for the whole
+// period from start to end, for part of it or not at all. Slots and
+// exceptions are clock times and days in location, so the period is first
+// moved there. It may span several days, each of them is checked against its
+// own windows.
+func availabilityDuring(slots []*domain.AvailabilitySlot, exceptions []*domain.AvailabilityException, start time.Time, end time.Time, location *time.Location) string {
+	start, end = start.In(location), end.In(location)
+	full, some := true, false
+	year, month, date := start.Date()
+	for day := time.Date(year, month, date, 0, 0, 0, 0, location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		need := window{start: 0, end: 24 * 60}
+		if start.After(day) {
+			need.start = start.Hour()*60 + start.Minute()
+		}
+		if next := day.AddDate(0, 0, 1); end.Before(next) {
+			need.end = end.Hour()*60 + end.Minute()
+		}
+		if need.start >= need.end {
+			continue
+		}
+		covered := false
+		for _, w := range availableWindows(slots, exceptions, day) {
+			if w.start < need.end && need.start < w.end {
+				some = true
+			}
+			if w.start <= need.start && need.end <= w.end {
+				covered = true
+			}
+		}
+		full = full && covered
+	}
+	switch {
+	case full && some:
+		return domain.MatchAvailable
+	case some:
+		return domain.MatchPartial
+	default:
+		return domain.MatchUnavailable
+	}
+}
+
+func subtractWindow(windows []window, cut window) []window {
+	result := make([]window, 0, len(windows))
+	for _, w := range windows {
+		if cut.end <= w.start || w.end <= cut.start {
+			result = append(result, w)
+			continue
+		}
+		if w.start < cut.start {
+			result = append(result, window{start: w.start, end: cut.start})
+		}
+		if cut.end < w.end {
+			result = append(result, window{start: cut.end, end: w.end})
+		}
+	}
+	return result
+}
+
+func mergeWindows(windows []window) []window {
+	sort.Slice(windows, func(i, j int) bool { return windows[i].start < windows[j].start })
+	merged := make([]window, 0, len(windows))
+	for _, w := range windows {
+		if n := len(merged); n > 0 && w.start <= merged[n-1].end {
+			if w.end > merged[n-1].end {
+				merged[n-1].end = w.end
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+	return merged
+}
+
+func parseWindow(start string, end string) (window, error) {
+	s, err := time.Parse("15:04", start)
+	if err != nil {
+		return window{}, err
+	}
+	e, err := time.Parse("15:04", end)
+	if err != nil {
+		return window{}, err
+	}
+	w := window{start: s.Hour()*60 + s.Minute(), end: e.Hour()*60 + e.Minute()}
+	if w.start >= w.end {
+		return window{}, domain.ErrInvalidTimeRange
+	}
+	return w, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// today returns the current day at midnight UTC, like the days bound from
+// query parameters.
+func today() time.Time {
+	year, month, day := time.Now().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// dayIn returns the day t falls on in location, at midnight UTC like the days
+// of exceptions.
+func dayIn(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func toAvailabilityException(exception *domain.AvailabilityException) dto.AvailabilityExceptionResponseDTO {
+	return dto.AvailabilityExceptionResponseDTO{
+		ID:        exception.ID,
+		Date:      exception.Date.Format(time.DateOnly),
+		StartTime: exception.StartTime,
+		EndTime:   exception.EndTime,
+		Available: exception.Available,
+		Note:      exception.Note,
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Volunteer), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) ReplaceSlots(volunteerID int, slots []*domain.AvailabilitySlot) error {
+	args := m.Called(volunteerID, slots)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) ListSlots(volunteerIDs []int) ([]*domain.AvailabilitySlot, error) {
+	args := m.Called(volunteerIDs)
+	return args.Get(0).([]*domain.AvailabilitySlot), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) CreateException(exception *domain.AvailabilityException) error {
+	args := m.Called(exception)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) DeleteException(volunteerID int, id int) error {
+	args := m.Called(volunteerID, id)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) ListExceptions(volunteerIDs []int, from time.Time, to *time.Time) ([]*domain.AvailabilityException, error) {
+	args := m.Called(volunteerIDs, from, to)
+	return args.Get(0).([]*domain.AvailabilityException), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) ListActiveVolunteers(departmentID *int, volunteerID *int) ([]*domain.VolunteerListing, error) {
+	args := m.Called(departmentID, volunteerID)
+	return args.Get(0).([]*domain.VolunteerListing), args.Error(1)
+}
+
+func date(value string) time.Time {
+	d, _ := time.Parse(time.DateOnly, value)
+	return d
+}
+
+func TestResolveAvailability(t *testing.T) {
+	// 2024-03-04 is a Monday
+	slots := []*domain.AvailabilitySlot{
+		{Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+		{Weekday: 1, StartTime: "14:00", EndTime: "18:00"},
+		{Weekday: 2, StartTime: "09:00", EndTime: "17:00"},
+	}
+	exceptions := []*domain.AvailabilityException{
+		{Date: date("2024-03-04"), StartTime: "10:00", EndTime: "15:00"},
+		{Date: date("2024-03-04"), StartTime: "17:00", EndTime: "20:00", Available: true},
+		{Date: date("2024-03-05")},
+		{Date: date("2024-03-06"), Available: true},
+	}
+
+	windows := resolveAvailability(slots, exceptions, date("2024-03-04"), date("2024-03-07"))
+	assert.Equal(t, []dto.AvailabilityWindowDTO{
+		{Date: "2024-03-04", StartTime: "09:00", EndTime: "10:00"},
+		{Date: "2024-03-04", StartTime: "15:00", EndTime: "20:00"},
+		{Date: "2024-03-06", StartTime: "00:00", EndTime: "24:00"},
+	}, windows)
+}
+
+func TestReplaceMySlots(t *testing.T) {
+	volunteer := &domain.Volunteer{ID: 3, UserID: 11}
+
+	t.Run("overlap", func(t *testing.T) {
+		mockRepo := new(MockAvailabilityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAvailabilityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+
+		_, err := usecase.ReplaceMySlots(11, dto.AvailabilitySlotsDTO{Slots: []dto.AvailabilitySlotDTO{
+			{Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+			{Weekday: 1, StartTime: "11:00", EndTime: "13:00"},
+		}})
+		assert.ErrorIs(t, err, domain.ErrSlotOverlap)
+		mockRepo.AssertNotCalled(t, "ReplaceSlots", mock.Anything, mock.Anything)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		mockRepo := new(MockAvailabilityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAvailabilityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+
+		_, err := usecase.ReplaceMySlots(11, dto.AvailabilitySlotsDTO{Slots: []dto.AvailabilitySlotDTO{
+			{Weekday: 1, StartTime: "12:00", EndTime: "09:00"},
+		}})
+		assert.ErrorIs(t, err, domain.ErrInvalidTimeRange)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockAvailabilityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAvailabilityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("ReplaceSlots", 3, mock.MatchedBy(func(slots []*domain.AvailabilitySlot) bool {
+			return len(slots) == 2 && slots[0].VolunteerID == 3
+		})).Return(nil)
+		mockRepo.On("ListSlots", []int{3}).Return([]*domain.AvailabilitySlot{}, nil)
+		mockRepo.On("ListExceptions", []int{3}, mock.Anything, (*time.Time)(nil)).Return([]*domain.AvailabilityException{}, nil)
+
+		_, err := usecase.ReplaceMySlots(11, dto.AvailabilitySlotsDTO{Slots: []dto.AvailabilitySlotDTO{
+			{Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+			{Weekday: 2, StartTime: "11:00", EndTime: "13:00"},
+		}})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestListAvailability(t *testing.T) {
+	kitchen, garden := 1, 2
+
+	t.Run("scoped to the coordinator's department", func(t *testing.T) {
+		mockRepo := new(MockAvailabilityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAvailabilityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+		from, to := date("2024-03-04"), date("2024-03-05")
+
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+		mockRepo.On("ListActiveVolunteers", &kitchen, (*int)(nil)).
+			Return([]*domain.VolunteerListing{{ID: 3, Name: "Anna", DepartmentID: 1}}, nil)
+		mockRepo.On("ListSlots", []int{3}).
+			Return([]*domain.AvailabilitySlot{{VolunteerID: 3, Weekday: 1, StartTime: "09:00", EndTime: "12:00"}}, nil)
+		mockRepo.On("ListExceptions", []int{3}, from, &to).Return([]*domain.AvailabilityException{}, nil)
+
+		result, err := usecase.ListAvailability(7, dto.AvailabilityQuery{From: &from, To: &to})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, []dto.AvailabilityWindowDTO{{Date: "2024-03-04", StartTime: "09:00", EndTime: "12:00"}}, result[0].Windows)
+	})
+
+	t.Run("other department", func(t *testing.T) {
+		mockRepo := new(MockAvailabilityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAvailabilityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+
+		_, err := usecase.ListAvailability(7, dto.AvailabilityQuery{DepartmentID: &garden})
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	})
+
+	t.Run("period too long", func(t *testing.T) {
+		mockRepo := new(MockAvailabilityRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewAvailabilityUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+		from, to := date("2024-03-01"), date("2024-04-01")
+
+		departments.On("FindUserDepartment", 7).Return(nil, nil)
+		departments.On("HasAllDepartments", 7).Return(true, nil)
+
+		_, err := usecase.ListAvailability(7, dto.AvailabilityQuery{From: &from, To: &to})
+		assert.ErrorIs(t, err, domain.ErrAvailabilityPeriod)
+		mockRepo.AssertNotCalled(t, "ListActiveVolunteers", mock.Anything, mock.Anything)
+	})
+}
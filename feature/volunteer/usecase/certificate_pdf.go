@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+//go:embed templates/certificate.txt.tmpl
+var defaultCertificateTemplate string
+
+// The certificate font is embedded as UTF-8 TrueType so that names outside
+// Latin-1, Vietnamese ones for instance, print as written.
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	certificateFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	certificateFontBold []byte
+)
+
+const certificateFontFamily = "DejaVu"
+
+// CertificateData is the data certificate templates are executed with.
+type CertificateData struct {
+	Name       string
+	Department string
+	From       string
+	To         string
+	Hours      string
+	Signer     string
+	IssuedOn   string
+	Code       string
+	VerifyURL  string
+}
+
+// LoadCertificateTemplate parses the template certificates are rendered from,
+// the file at path or the built-in template when path is empty. The template
+// must define "title" and "footer".
+func LoadCertificateTemplate(path string) (*template.Template, error) {
+	text := defaultCertificateTemplate
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = string(content)
+	}
+	tmpl, err := template.New("certificate").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"title", "footer"} {
+		if tmpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("certificate template does not define %q", name)
+		}
+	}
+	return tmpl, nil
+}
+
+// certificateDate is how days are written on certificates.
+const certificateDate = "2 January 2006"
+
+// renderCertificate lays out the certificate on a landscape A4 page: the
+// title, the body paragraphs, the signature and, at the bottom, the footer
+// next to a QR code of the verification link. The document dates are those of
+// the certificate and its catalog is sorted so that the same certificate
+// always gives the same file.
+func renderCertificate(tmpl *template.Template, certificate *domain.Certificate, verifyURL string) ([]byte, error) {
+	data := CertificateData{
+		Name:       certificate.VolunteerName,
+		Department: certificate.DepartmentName,
+		From:       certificate.PeriodFrom.Format(certificateDate),
+		To:         certificate.PeriodTo.Format(certificateDate),
+		Hours:      formatHours(certificate.TotalMinutes),
+		Signer:     certificate.SignerName,
+		IssuedOn:   certificate.IssuedAt.Format(certificateDate),
+		Code:       certificate.Code,
+		VerifyURL:  verifyURL,
+	}
+	var title, body, footer bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&title, "title", data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(&body, data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.ExecuteTemplate(&footer, "footer", data); err != nil {
+		return nil, err
+	}
+	qr, err := qrcode.Encode(verifyURL, qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(certificateFontFamily, "", certificateFont)
+	pdf.AddUTF8FontFromBytes(certificateFontFamily, "B", certificateFontBold)
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(certificate.IssuedAt)
+	pdf.SetModificationDate(certificate.IssuedAt)
+	pdf.SetTitle(strings.TrimSpace(title.String()), true)
+	pdf.SetAuthor(certificate.SignerName, true)
+	pdf.SetSubject(certificate.VolunteerName, true)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	width, height := pdf.GetPageSize()
+	pdf.SetDrawColor(60, 90, 140)
+	pdf.SetLineWidth(1.2)
+	pdf.Rect(10, 10, width-20, height-20, "D")
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(14, 14, width-28, height-28, "D")
+
+	pdf.SetTextColor(30, 50, 90)
+	pdf.SetFont(certificateFontFamily, "B", 28)
+	pdf.SetXY(25, 32)
+	pdf.CellFormat(width-50, 14, strings.TrimSpace(title.String()), "", 1, "C", false, 0, "")
+	pdf.Ln(10)
+
+	pdf.SetTextColor(0, 0, 0)
+	for _, paragraph := range strings.Split(strings.TrimSpace(body.String()), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		lineHeight := 8.0
+		if strings.HasPrefix(paragraph, "# ") {
+			paragraph = strings.TrimPrefix(paragraph, "# ")
+			pdf.SetFont(certificateFontFamily, "B", 24)
+			lineHeight = 12
+		} else {
+			pdf.SetFont(certificateFontFamily, "", 14)
+		}
+		pdf.SetX(35)
+		pdf.MultiCell(width-70, lineHeight, strings.Join(strings.Fields(paragraph), " "), "", "C", false)
+		pdf.Ln(4)
+	}
+
+	signatureY := height - 62
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Line(35, signatureY, 115, signatureY)
+	pdf.SetFont(certificateFontFamily, "B", 12)
+	pdf.SetXY(35, signatureY+2)
+	pdf.CellFormat(80, 6, certificate.SignerName, "", 2, "C", false, 0, "")
+	pdf.SetFont(certificateFontFamily, "", 10)
+	pdf.CellFormat(80, 5, "Signed on "+data.IssuedOn, "", 0, "C", false, 0, "")
+
+	qrSize := 32.0
+	pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", width-35-qrSize, signatureY-qrSize+12, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.SetFont(certificateFontFamily, "", 9)
+	pdf.SetTextColor(80, 80, 80)
+	pdf.SetXY(35, height-32)
+	pdf.MultiCell(width-70, 5, strings.TrimSpace(footer.String()), "", "C", false)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// formatHours writes minutes as hours with at most two decimals, 90 minutes
+// being 1.5.
+func formatHours(minutes int) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", toHours(minutes)), "0"), ".")
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"text/template"
+	"time"
+
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+type CertificateUsecaseInterface interface {
+	IssueCertificate(adminID int, volunteerID int, input dto.IssueCertificateDTO) (*dto.CertificateDTO, error)
+	ListCertificates(adminID int, volunteerID int) ([]dto.CertificateDTO, error)
+	RevokeCertificate(adminID int, volunteerID int, id int, input dto.RevokeCertificateDTO) (*dto.CertificateDTO, error)
+	CertificatePDF(adminID int, volunteerID int, id int) ([]byte, error)
+	ListMyCertificates(userID int) ([]dto.CertificateDTO, error)
+	MyCertificatePDF(userID int, id int) ([]byte, error)
+	VerifyCertificate(code string) (*dto.CertificateVerificationDTO, error)
+}
+
+// CertificateUsecase issues certificates of service for the approved hours of
+// a volunteer. Coordinators who belong to a department only issue
+// certificates to the volunteers of their department.
+type CertificateUsecase struct {
+	CertificateRepo storage.CertificateRepositoryInterface
+	DepartmentScope departmentUsecase.DepartmentScopeInterface
+	Template        *template.Template
+	baseURL         string
+}
+
+func NewCertificateUsecase(certificateRepo storage.CertificateRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface, tmpl *template.Template, baseURL string) *CertificateUsecase {
+	return &CertificateUsecase{
+		CertificateRepo: certificateRepo,
+		DepartmentScope: departmentScope,
+		Template:        tmpl,
+		baseURL:         strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// IssueCertificate signs a certificate for the hours approved between From
+// and To. Nobody signs their own certificate.
+func (u *CertificateUsecase) IssueCertificate(adminID int, volunteerID int, input dto.IssueCertificateDTO) (*dto.CertificateDTO, error) {
+	from, err := time.Parse(time.DateOnly, input.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := time.Parse(time.DateOnly, input.To)
+	if err != nil {
+		return nil, err
+	}
+	if from.After(to) || to.After(today()) {
+		return nil, domain.ErrCertificatePeriod
+	}
+	volunteer, err := u.CertificateRepo.FindVolunteerListing(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	if volunteer.UserID == adminID {
+		return nil, domain.ErrOwnCertificate
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, volunteer.DepartmentID); err != nil {
+		return nil, err
+	}
+	minutes, err := u.CertificateRepo.SumApprovedMinutes(volunteer.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if minutes == 0 {
+		return nil, domain.ErrNoApprovedHours
+	}
+	signer, err := u.CertificateRepo.FindUserName(adminID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := newCertificateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &domain.Certificate{
+		Code:           code,
+		VolunteerID:    volunteer.ID,
+		VolunteerName:  strings.TrimSpace(volunteer.Name + " " + volunteer.Surname),
+		DepartmentID:   volunteer.DepartmentID,
+		DepartmentName: volunteer.DepartmentName,
+		PeriodFrom:     from,
+		PeriodTo:       to,
+		TotalMinutes:   minutes,
+		SignedBy:       adminID,
+		SignerName:     signer,
+		IssuedAt:       time.Now().UTC().Truncate(time.Second),
+	}
+	if err := u.CertificateRepo.CreateCertificate(certificate); err != nil {
+		return nil, err
+	}
+	response := u.toCertificate(certificate)
+	return &response, nil
+}
+
+func (u *CertificateUsecase) ListCertificates(adminID int, volunteerID int) ([]dto.CertificateDTO, error) {
+	volunteer, err := u.CertificateRepo.FindVolunteerListing(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, volunteer.DepartmentID); err != nil {
+		return nil, err
+	}
+	return u.listCertificates(volunteer.ID)
+}
+
+// RevokeCertificate invalidates a certificate, its verification code then
+// reports it as no longer valid.
+func (u *CertificateUsecase) RevokeCertificate(adminID int, volunteerID int, id int, input dto.RevokeCertificateDTO) (*dto.CertificateDTO, error) {
+	if _, err := u.findCertificate(adminID, volunteerID, id); err != nil {
+		return nil, err
+	}
+	if err := u.CertificateRepo.RevokeCertificate(id, adminID, input.Reason); err != nil {
+		return nil, err
+	}
+	certificate, err := u.CertificateRepo.FindCertificateByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := u.toCertificate(certificate)
+	return &response, nil
+}
+
+func (u *CertificateUsecase) CertificatePDF(adminID int, volunteerID int, id int) ([]byte, error) {
+	certificate, err := u.findCertificate(adminID, volunteerID, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.render(certificate)
+}
+
+func (u *CertificateUsecase) ListMyCertificates(userID int) ([]dto.CertificateDTO, error) {
+	volunteer, err := u.CertificateRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.listCertificates(volunteer.ID)
+}
+
+func (u *CertificateUsecase) MyCertificatePDF(userID int, id int) ([]byte, error) {
+	volunteer, err := u.CertificateRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := u.CertificateRepo.FindCertificateByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if certificate.VolunteerID != volunteer.ID {
+		return nil, domain.ErrCertificateNotFound
+	}
+	return u.render(certificate)
+}
+
+// VerifyCertificate looks a certificate up by the code printed on it. The
+// code is accepted in any case and with or without its dashes.
+func (u *CertificateUsecase) VerifyCertificate(code string) (*dto.CertificateVerificationDTO, error) {
+	code, ok := normalizeCertificateCode(code)
+	if !ok {
+		return nil, domain.ErrCertificateNotFound
+	}
+	certificate, err := u.CertificateRepo.FindCertificateByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CertificateVerificationDTO{
+		Code:           certificate.Code,
+		Valid:          certificate.RevokedAt == nil,
+		VolunteerName:  certificate.VolunteerName,
+		DepartmentName: certificate.DepartmentName,
+		From:           certificate.PeriodFrom.Format(time.DateOnly),
+		To:             certificate.PeriodTo.Format(time.DateOnly),
+		TotalHours:     toHours(certificate.TotalMinutes),
+		SignerName:     certificate.SignerName,
+		IssuedAt:       certificate.IssuedAt,
+		RevokedAt:      certificate.RevokedAt,
+	}, nil
+}
+
+// findCertificate returns a certificate of the volunteer, provided the admin
+// may manage the volunteer.
+func (u *CertificateUsecase) findCertificate(adminID int, volunteerID int, id int) (*domain.Certificate, error) {
+	certificate, err := u.CertificateRepo.FindCertificateByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if certificate.VolunteerID != volunteerID {
+		return nil, domain.ErrCertificateNotFound
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, certificate.DepartmentID); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+func (u *CertificateUsecase) render(certificate *domain.Certificate) ([]byte, error) {
+	if certificate.RevokedAt != nil {
+		return nil, domain.ErrCertificateRevoked
+	}
+	return renderCertificate(u.Template, certificate, u.verifyURL(certificate.Code))
+}
+
+func (u *CertificateUsecase) listCertificates(volunteerID int) ([]dto.CertificateDTO, error) {
+	certificates, err := u.CertificateRepo.ListCertificates(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.CertificateDTO, 0, len(certificates))
+	for _, certificate := range certificates {
+		response = append(response, u.toCertificate(certificate))
+	}
+	return response, nil
+}
+
+func (u *CertificateUsecase) verifyURL(code string) string {
+	return u.baseURL + "/api/v1/certificates/" + code + "/verify"
+}
+
+func (u *CertificateUsecase) toCertificate(certificate *domain.Certificate) dto.CertificateDTO {
+	return dto.CertificateDTO{
+		ID:             certificate.ID,
+		Code:           certificate.Code,
+		VolunteerID:    certificate.VolunteerID,
+		VolunteerName:  certificate.VolunteerName,
+		DepartmentID:   certificate.DepartmentID,
+		DepartmentName: certificate.DepartmentName,
+		From:           certificate.PeriodFrom.Format(time.DateOnly),
+		To:             certificate.PeriodTo.Format(time.DateOnly),
+		TotalMinutes:   certificate.TotalMinutes,
+		TotalHours:     toHours(certificate.TotalMinutes),
+		SignedBy:       certificate.SignedBy,
+		SignerName:     certificate.SignerName,
+		IssuedAt:       certificate.IssuedAt,
+		RevokedAt:      certificate.RevokedAt,
+		RevokeReason:   certificate.RevokeReason,
+		VerifyURL:      u.verifyURL(certificate.Code),
+	}
+}
+
+// certificateCodeLength is the number of characters of a verification code,
+// not counting the dashes between its groups of four.
+const certificateCodeLength = 16
+
+// newCertificateCode returns a random code such as 7K2Q-M4XD-PLRA-53TB, 80
+// bits written in base32 so it is easy to read out and type.
+func newCertificateCode() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return groupCertificateCode(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)), nil
+}
+
+func normalizeCertificateCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != certificateCodeLength {
+		return "", false
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(code); err != nil {
+		return "", false
+	}
+	return groupCertificateCode(code), true
+}
+
+func groupCertificateCode(code string) string {
+	groups := make([]string, 0, len(code)/4)
+	for i := 0; i < len(code); i += 4 {
+		groups = append(groups, code[i:i+4])
+	}
+	return strings.Join(groups, "-")
+}
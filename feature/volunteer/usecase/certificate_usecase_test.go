@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCertificateRepository struct {
+	mock.Mock
+}
+
+func (m *MockCertificateRepository) CreateCertificate(certificate *domain.Certificate) error {
+	args := m.Called(certificate)
+	return args.Error(0)
+}
+
+func (m *MockCertificateRepository) FindCertificateByID(id int) (*domain.Certificate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Certificate), args.Error(1)
+}
+
+func (m *MockCertificateRepository) FindCertificateByCode(code string) (*domain.Certificate, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Certificate), args.Error(1)
+}
+
+func (m *MockCertificateRepository) ListCertificates(volunteerID int) ([]*domain.Certificate, error) {
+	args := m.Called(volunteerID)
+	return args.Get(0).([]*domain.Certificate), args.Error(1)
+}
+
+func (m *MockCertificateRepository) RevokeCertificate(id int, revokedBy int, reason string) error {
+	args := m.Called(id, revokedBy, reason)
+	return args.Error(0)
+}
+
+func (m *MockCertificateRepository) SumApprovedMinutes(volunteerID int, from time.Time, to time.Time) (int, error) {
+	args := m.Called(volunteerID, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCertificateRepository) FindVolunteerListing(id int) (*domain.VolunteerListing, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VolunteerListing), args.Error(1)
+}
+
+func (m *MockCertificateRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Volunteer), args.Error(1)
+}
+
+func (m *MockCertificateRepository) FindUserName(userID int) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
+func newTestCertificateUsecase(t *testing.T, repo *MockCertificateRepository, departments *MockDepartmentScopeRepository) *CertificateUsecase {
+	tmpl, err := LoadCertificateTemplate("")
+	assert.NoError(t, err)
+	return NewCertificateUsecase(repo, departmentUsecase.NewDepartmentScope(departments), tmpl, "https://volunteer.example.org/")
+}
+
+func TestIssueCertificate(t *testing.T) {
+	kitchen, garden := 1, 2
+	volunteer := &domain.VolunteerListing{ID: 3, UserID: 11, Name: "Anna", Surname: "Smith", DepartmentID: kitchen, DepartmentName: "Kitchen"}
+	period := dto.IssueCertificateDTO{From: "2024-03-01", To: "2024-03-31"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindVolunteerListing", 3).Return(volunteer, nil)
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+		mockRepo.On("SumApprovedMinutes", 3, date("2024-03-01"), date("2024-03-31")).Return(150, nil)
+		mockRepo.On("FindUserName", 7).Return("Carl Annan", nil)
+		mockRepo.On("CreateCertificate", mock.AnythingOfType("*domain.Certificate")).Return(nil)
+
+		certificate, err := usecase.IssueCertificate(7, 3, period)
+		assert.NoError(t, err)
+		assert.Equal(t, "Anna Smith", certificate.VolunteerName)
+		assert.Equal(t, "Carl Annan", certificate.SignerName)
+		assert.Equal(t, 2.5, certificate.TotalHours)
+		assert.Regexp(t, `^[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}$`, certificate.Code)
+		assert.Equal(t, "https://volunteer.example.org/api/v1/certificates/"+certificate.Code+"/verify", certificate.VerifyURL)
+	})
+
+	t.Run("own certificate", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindVolunteerListing", 3).Return(volunteer, nil)
+
+		_, err := usecase.IssueCertificate(11, 3, period)
+		assert.ErrorIs(t, err, domain.ErrOwnCertificate)
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindVolunteerListing", 3).Return(volunteer, nil)
+		departments.On("FindUserDepartment", 7).Return(&garden, nil)
+
+		_, err := usecase.IssueCertificate(7, 3, period)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		usecase := newTestCertificateUsecase(t, new(MockCertificateRepository), new(MockDepartmentScopeRepository))
+		_, err := usecase.IssueCertificate(7, 3, dto.IssueCertificateDTO{From: "2024-03-31", To: "2024-03-01"})
+		assert.ErrorIs(t, err, domain.ErrCertificatePeriod)
+
+		tomorrow := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+		_, err = usecase.IssueCertificate(7, 3, dto.IssueCertificateDTO{From: "2024-03-01", To: tomorrow})
+		assert.ErrorIs(t, err, domain.ErrCertificatePeriod)
+	})
+
+	t.Run("no approved hours", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindVolunteerListing", 3).Return(volunteer, nil)
+		departments.On("FindUserDepartment", 7).Return(nil, nil)
+		departments.On("HasAllDepartments", 7).Return(true, nil)
+		mockRepo.On("SumApprovedMinutes", 3, date("2024-03-01"), date("2024-03-31")).Return(0, nil)
+
+		_, err := usecase.IssueCertificate(7, 3, period)
+		assert.ErrorIs(t, err, domain.ErrNoApprovedHours)
+		mockRepo.AssertNotCalled(t, "CreateCertificate", mock.Anything)
+	})
+}
+
+func TestRenderCertificateUnicodeName(t *testing.T) {
+	tmpl, err := LoadCertificateTemplate("")
+	assert.NoError(t, err)
+	name := "Nguyễn Thị Hương"
+	certificate := &domain.Certificate{Code: "AB2C-DE3F-GH4J-KL5M", VolunteerName: name, DepartmentName: "Bếp ăn",
+		PeriodFrom: date("2024-03-01"), PeriodTo: date("2024-03-31"), TotalMinutes: 60, SignerName: "Trần Văn Đức",
+		IssuedAt: time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)}
+
+	pdf, err := renderCertificate(tmpl, certificate, "https://volunteer.example.org/certificates/AB2C-DE3F-GH4J-KL5M/verify")
+	assert.NoError(t, err)
+	content := pdfContent(t, pdf)
+	for _, text := range []string{name, "Trần Văn Đức"} {
+		assert.True(t, bytes.Contains(content, utf16BE(text)), "%q is not printed as written", text)
+	}
+}
+
+// pdfContent inflates and concatenates the compressed streams of a PDF.
+func pdfContent(t *testing.T, pdf []byte) []byte {
+	var content bytes.Buffer
+	for _, match := range regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`).FindAllSubmatch(pdf, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(match[1]))
+		if err != nil {
+			continue
+		}
+		_, err = io.Copy(&content, r)
+		assert.NoError(t, err)
+	}
+	return content.Bytes()
+}
+
+// utf16BE is how text set in a UTF-8 font is written in the page content.
+func utf16BE(s string) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = append(b, byte(u>>8), byte(u))
+	}
+	return b
+}
+
+func TestCertificatePDF(t *testing.T) {
+	certificate := &domain.Certificate{ID: 5, Code: "AB2C-DE3F-GH4J-KL5M", VolunteerID: 3, VolunteerName: "Zoë Müller",
+		DepartmentID: 1, DepartmentName: "Kitchen", PeriodFrom: date("2024-03-01"), PeriodTo: date("2024-03-31"),
+		TotalMinutes: 150, SignedBy: 7, SignerName: "Carl Annan", IssuedAt: time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindCertificateByID", 5).Return(certificate, nil)
+		departments.On("FindUserDepartment", 7).Return(nil, nil)
+		departments.On("HasAllDepartments", 7).Return(true, nil)
+
+		pdf, err := usecase.CertificatePDF(7, 3, 5)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+
+		again, err := usecase.CertificatePDF(7, 3, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, pdf, again)
+	})
+
+	t.Run("other volunteer", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindCertificateByID", 5).Return(certificate, nil)
+
+		_, err := usecase.CertificatePDF(7, 4, 5)
+		assert.ErrorIs(t, err, domain.ErrCertificateNotFound)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		revoked := *certificate
+		revokedAt := time.Now()
+		revoked.RevokedAt = &revokedAt
+		mockRepo.On("FindVolunteerByUserID", 11).Return(&domain.Volunteer{ID: 3, UserID: 11}, nil)
+		mockRepo.On("FindCertificateByID", 5).Return(&revoked, nil)
+
+		_, err := usecase.MyCertificatePDF(11, 5)
+		assert.ErrorIs(t, err, domain.ErrCertificateRevoked)
+	})
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	revokedAt := time.Now()
+	certificate := &domain.Certificate{Code: "AB2C-DE3F-GH4J-KL5M", VolunteerName: "Anna Smith", TotalMinutes: 90,
+		PeriodFrom: date("2024-03-01"), PeriodTo: date("2024-03-31")}
+
+	t.Run("code in any form", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		mockRepo.On("FindCertificateByCode", "AB2C-DE3F-GH4J-KL5M").Return(certificate, nil)
+
+		verification, err := usecase.VerifyCertificate("ab2c de3f gh4j kl5m")
+		assert.NoError(t, err)
+		assert.True(t, verification.Valid)
+		assert.Equal(t, 1.5, verification.TotalHours)
+		assert.Equal(t, "2024-03-01", verification.From)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+		revoked := *certificate
+		revoked.RevokedAt = &revokedAt
+		mockRepo.On("FindCertificateByCode", "AB2C-DE3F-GH4J-KL5M").Return(&revoked, nil)
+
+		verification, err := usecase.VerifyCertificate("AB2CDE3FGH4JKL5M")
+		assert.NoError(t, err)
+		assert.False(t, verification.Valid)
+	})
+
+	t.Run("malformed code", func(t *testing.T) {
+		mockRepo := new(MockCertificateRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := newTestCertificateUsecase(t, mockRepo, departments)
+
+		_, err := usecase.VerifyCertificate("AB2C-DE3F")
+		assert.ErrorIs(t, err, domain.ErrCertificateNotFound)
+		mockRepo.AssertNotCalled(t, "FindCertificateByCode", mock.Anything)
+	})
+}
+
+func TestLoadCertificateTemplate(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "custom.tmpl")
+	assert.NoError(t, os.WriteFile(custom, []byte(`{{define "title"}}Thank you{{end}}{{define "footer"}}{{.Code}}{{end}}{{.Name}}`), 0o600))
+	_, err := LoadCertificateTemplate(custom)
+	assert.NoError(t, err)
+
+	missing := filepath.Join(dir, "missing.tmpl")
+	assert.NoError(t, os.WriteFile(missing, []byte(`{{.Name}}`), 0o600))
+	_, err = LoadCertificateTemplate(missing)
+	assert.Error(t, err)
+}
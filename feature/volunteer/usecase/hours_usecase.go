@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"time"
+
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+type HoursUsecaseInterface interface {
+	LogHours(userID int, input dto.LogHoursDTO) (*dto.HoursEntryDTO, error)
+	ListMyHours(userID int, query dto.ListHoursQuery) (*dto.ListHoursDTO, error)
+	UpdateMyHours(userID int, id int, input dto.LogHoursDTO) (*dto.HoursEntryDTO, error)
+	DeleteMyHours(userID int, id int) error
+	ListHours(reviewerID int, query dto.ListHoursQuery) (*dto.ListHoursDTO, error)
+	ApproveHours(reviewerID int, id int, input dto.ApproveHoursDTO) (*dto.HoursEntryDTO, error)
+	DisputeHours(reviewerID int, id int, input dto.DisputeHoursDTO) (*dto.HoursEntryDTO, error)
+	VolunteerTotals(reviewerID int, query dto.HoursTotalsQuery) ([]dto.VolunteerHoursTotalDTO, error)
+	DepartmentTotals(reviewerID int, query dto.HoursTotalsQuery) ([]dto.DepartmentHoursTotalDTO, error)
+}
+
+// HoursUsecase lets volunteers log the time they contribute and coordinators
+// review it. Coordinators who belong to a department only see and review the
+// hours of their department.
+type HoursUsecase struct {
+	HoursRepo       storage.HoursRepositoryInterface
+	DepartmentScope departmentUsecase.DepartmentScopeInterface
+}
+
+func NewHoursUsecase(hoursRepo storage.HoursRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface) *HoursUsecase {
+	return &HoursUsecase{HoursRepo: hoursRepo, DepartmentScope: departmentScope}
+}
+
+func (u *HoursUsecase) LogHours(userID int, input dto.LogHoursDTO) (*dto.HoursEntryDTO, error) {
+	volunteer, err := u.HoursRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	entry := &domain.HoursEntry{VolunteerID: volunteer.ID, Status: domain.HoursPending}
+	if err := u.applyHours(entry, volunteer, input); err != nil {
+		return nil, err
+	}
+	if err := u.HoursRepo.CreateHours(entry); err != nil {
+		return nil, err
+	}
+	return u.findHours(entry.ID)
+}
+
+func (u *HoursUsecase) ListMyHours(userID int, query dto.ListHoursQuery) (*dto.ListHoursDTO, error) {
+	volunteer, err := u.HoursRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	query.VolunteerID = &volunteer.ID
+	query.DepartmentID = nil
+	return u.listHours(query)
+}
+
+// UpdateMyHours corrects an entry of the volunteer that was not approved, it
+// goes back to pending.
+func (u *HoursUsecase) UpdateMyHours(userID int, id int, input dto.LogHoursDTO) (*dto.HoursEntryDTO, error) {
+	volunteer, entry, err := u.findOwnHours(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status == domain.HoursApproved {
+		return nil, domain.ErrHoursApproved
+	}
+	if err := u.applyHours(entry, volunteer, input); err != nil {
+		return nil, err
+	}
+	if err := u.HoursRepo.UpdateHours(entry); err != nil {
+		return nil, err
+	}
+	return u.findHours(id)
+}
+
+func (u *HoursUsecase) DeleteMyHours(userID int, id int) error {
+	if _, _, err := u.findOwnHours(userID, id); err != nil {
+		return err
+	}
+	return u.HoursRepo.DeleteHours(id)
+}
+
+func (u *HoursUsecase) ListHours(reviewerID int, query dto.ListHoursQuery) (*dto.ListHoursDTO, error) {
+	departmentID, err := u.DepartmentScope.ScopeDepartment(reviewerID, query.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	query.DepartmentID = departmentID
+	return u.listHours(query)
+}
+
+func (u *HoursUsecase) ApproveHours(reviewerID int, id int, input dto.ApproveHoursDTO) (*dto.HoursEntryDTO, error) {
+	return u.reviewHours(reviewerID, id, domain.HoursApproved, input.Note)
+}
+
+func (u *HoursUsecase) DisputeHours(reviewerID int, id int, input dto.DisputeHoursDTO) (*dto.HoursEntryDTO, error) {
+	return u.reviewHours(reviewerID, id, domain.HoursDisputed, input.Reason)
+}
+
+func (u *HoursUsecase) VolunteerTotals(reviewerID int, query dto.HoursTotalsQuery) ([]dto.VolunteerHoursTotalDTO, error) {
+	departmentID, err := u.DepartmentScope.ScopeDepartment(reviewerID, query.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	query.DepartmentID = departmentID
+	totals, err := u.HoursRepo.VolunteerTotals(query)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.VolunteerHoursTotalDTO, 0, len(totals))
+	for _, total := range totals {
+		response = append(response, dto.VolunteerHoursTotalDTO{
+			VolunteerID:     total.VolunteerID,
+			Name:            total.Name,
+			Surname:         total.Surname,
+			ApprovedMinutes: total.ApprovedMinutes,
+			ApprovedHours:   toHours(total.ApprovedMinutes),
+			PendingMinutes:  total.PendingMinutes,
+			DisputedMinutes: total.DisputedMinutes,
+			Entries:         total.Entries,
+		})
+	}
+	return response, nil
+}
+
+func (u *HoursUsecase) DepartmentTotals(reviewerID int, query dto.HoursTotalsQuery) ([]dto.DepartmentHoursTotalDTO, error) {
+	departmentID, err := u.DepartmentScope.ScopeDepartment(reviewerID, query.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	query.DepartmentID = departmentID
+	totals, err := u.HoursRepo.DepartmentTotals(query)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.DepartmentHoursTotalDTO, 0, len(totals))
+	for _, total := range totals {
+		response = append(response, dto.DepartmentHoursTotalDTO{
+			DepartmentID:    total.DepartmentID,
+			DepartmentName:  total.DepartmentName,
+			ApprovedMinutes: total.ApprovedMinutes,
+			ApprovedHours:   toHours(total.ApprovedMinutes),
+			PendingMinutes:  total.PendingMinutes,
+			DisputedMinutes: total.DisputedMinutes,
+			Volunteers:      total.Volunteers,
+		})
+	}
+	return response, nil
+}
+
+func (u *HoursUsecase) reviewHours(reviewerID int, id int, status string, note string) (*dto.HoursEntryDTO, error) {
+	entry, err := u.HoursRepo.FindHoursByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID == reviewerID {
+		return nil, domain.ErrOwnHours
+	}
+	if _, err := u.DepartmentScope.ScopeDepartment(reviewerID, &entry.DepartmentID); err != nil {
+		return nil, err
+	}
+	if err := u.HoursRepo.ReviewHours(id, status, reviewerID, note); err != nil {
+		return nil, err
+	}
+	return u.findHours(id)
+}
+
+// applyHours fills the entry from the input, hours logged for an activity go
+// to its department and need a confirmed sign-up.
+func (u *HoursUsecase) applyHours(entry *domain.HoursEntry, volunteer *domain.Volunteer, input dto.LogHoursDTO) error {
+	date, err := time.Parse(time.DateOnly, input.Date)
+	if err != nil {
+		return err
+	}
+	if date.After(time.Now()) {
+		return domain.ErrHoursInFuture
+	}
+	entry.Date = date
+	entry.ActivityID = input.ActivityID
+	entry.Minutes = input.Minutes
+	entry.Notes = input.Notes
+	switch {
+	case input.ActivityID != nil:
+		activity, err := u.HoursRepo.FindSignedUpActivity(*input.ActivityID, volunteer.ID)
+		if err != nil {
+			return err
+		}
+		entry.DepartmentID = activity.DepartmentID
+	case input.DepartmentID != nil && *input.DepartmentID != volunteer.DepartmentID:
+		return domain.ErrOutsideDepartment
+	default:
+		entry.DepartmentID = volunteer.DepartmentID
+	}
+	return nil
+}
+
+func (u *HoursUsecase) findOwnHours(userID int, id int) (*domain.Volunteer, *domain.HoursEntry, error) {
+	volunteer, err := u.HoursRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry, err := u.HoursRepo.FindHoursByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry.VolunteerID != volunteer.ID {
+		return nil, nil, domain.ErrHoursNotFound
+	}
+	return volunteer, entry, nil
+}
+
+func (u *HoursUsecase) findHours(id int) (*dto.HoursEntryDTO, error) {
+	entry, err := u.HoursRepo.FindHoursByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toHoursEntry(entry)
+	return &response, nil
+}
+
+func (u *HoursUsecase) listHours(query dto.ListHoursQuery) (*dto.ListHoursDTO, error) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultVolunteerPageSize
+	}
+	entries, total, err := u.HoursRepo.ListHours(query)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.ListHoursDTO{
+		Entries:    make([]dto.HoursEntryDTO, 0, len(entries)),
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Total:      total,
+		TotalPages: int((total + int64(query.PageSize) - 1) / int64(query.PageSize)),
+	}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, toHoursEntry(entry))
+	}
+	return response, nil
+}
+
+func toHoursEntry(entry *domain.HoursEntry) dto.HoursEntryDTO {
+	return dto.HoursEntryDTO{
+		ID:             entry.ID,
+		VolunteerID:    entry.VolunteerID,
+		Name:           entry.Name,
+		Surname:        entry.Surname,
+		Date:           entry.Date.Format(time.DateOnly),
+		ActivityID:     entry.ActivityID,
+		DepartmentID:   entry.DepartmentID,
+		DepartmentName: entry.DepartmentName,
+		Minutes:        entry.Minutes,
+		Notes:          entry.Notes,
+		Status:         entry.Status,
+		ReviewedBy:     entry.ReviewedBy,
+		ReviewedAt:     entry.ReviewedAt,
+		ReviewNote:     entry.ReviewNote,
+		CreatedAt:      entry.CreatedAt,
+	}
+}
+
+func toHours(minutes int) float64 {
+	return float64(minutes) / 60
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHoursRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoursRepository) CreateHours(entry *domain.HoursEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockHoursRepository) UpdateHours(entry *domain.HoursEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockHoursRepository) DeleteHours(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockHoursRepository) FindHoursByID(id int) (*domain.HoursEntry, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.HoursEntry), args.Error(1)
+}
+
+func (m *MockHoursRepository) ListHours(query dto.ListHoursQuery) ([]*domain.HoursEntry, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*domain.HoursEntry), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockHoursRepository) ReviewHours(id int, status string, reviewerID int, note string) error {
+	args := m.Called(id, status, reviewerID, note)
+	return args.Error(0)
+}
+
+func (m *MockHoursRepository) VolunteerTotals(query dto.HoursTotalsQuery) ([]*domain.VolunteerHoursTotal, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*domain.VolunteerHoursTotal), args.Error(1)
+}
+
+func (m *MockHoursRepository) DepartmentTotals(query dto.HoursTotalsQuery) ([]*domain.DepartmentHoursTotal, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*domain.DepartmentHoursTotal), args.Error(1)
+}
+
+func (m *MockHoursRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Volunteer), args.Error(1)
+}
+
+func (m *MockHoursRepository) FindSignedUpActivity(activityID int, volunteerID int) (*activityDomain.Activity, error) {
+	args := m.Called(activityID, volunteerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activityDomain.Activity), args.Error(1)
+}
+
+// MockDepartmentScopeRepository finds the department of the admins, the
+// department scope itself is not mocked.
+type MockDepartmentScopeRepository struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentScopeRepository) FindUserDepartment(userID int) (*int, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *MockDepartmentScopeRepository) HasAllDepartments(userID int) (bool, error) {
+	args := m.Called(userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestLogHours(t *testing.T) {
+	volunteer := &domain.Volunteer{ID: 3, UserID: 11, DepartmentID: 1}
+	activityID := 4
+
+	t.Run("activity hours go to its department", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindSignedUpActivity", 4, 3).Return(&activityDomain.Activity{ID: 4, DepartmentID: 2}, nil)
+		mockRepo.On("CreateHours", mock.MatchedBy(func(entry *domain.HoursEntry) bool {
+			return entry.VolunteerID == 3 && entry.DepartmentID == 2 && entry.Minutes == 90 && entry.Status == domain.HoursPending
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.HoursEntry).ID = 8
+		}).Return(nil)
+		mockRepo.On("FindHoursByID", 8).Return(&domain.HoursEntry{ID: 8, VolunteerID: 3, Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), DepartmentID: 2, Minutes: 90, Status: domain.HoursPending}, nil)
+
+		result, err := usecase.LogHours(11, dto.LogHoursDTO{Date: "2024-03-01", ActivityID: &activityID, Minutes: 90})
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-03-01", result.Date)
+		assert.Equal(t, 2, result.DepartmentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("future date", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+
+		_, err := usecase.LogHours(11, dto.LogHoursDTO{Date: time.Now().AddDate(0, 0, 2).Format(time.DateOnly), Minutes: 60})
+		assert.ErrorIs(t, err, domain.ErrHoursInFuture)
+		mockRepo.AssertNotCalled(t, "CreateHours", mock.Anything)
+	})
+
+	t.Run("not signed up", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindSignedUpActivity", 4, 3).Return(nil, domain.ErrNotSignedUp)
+
+		_, err := usecase.LogHours(11, dto.LogHoursDTO{Date: "2024-03-01", ActivityID: &activityID, Minutes: 60})
+		assert.ErrorIs(t, err, domain.ErrNotSignedUp)
+	})
+
+	t.Run("hours go to the own department", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("CreateHours", mock.MatchedBy(func(entry *domain.HoursEntry) bool {
+			return entry.DepartmentID == 1
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.HoursEntry).ID = 9
+		}).Return(nil)
+		mockRepo.On("FindHoursByID", 9).Return(&domain.HoursEntry{ID: 9, VolunteerID: 3, Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), DepartmentID: 1, Minutes: 60, Status: domain.HoursPending}, nil)
+
+		result, err := usecase.LogHours(11, dto.LogHoursDTO{Date: "2024-03-01", Minutes: 60})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.DepartmentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("another department", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+		other := 2
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+
+		_, err := usecase.LogHours(11, dto.LogHoursDTO{Date: "2024-03-01", DepartmentID: &other, Minutes: 60})
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "CreateHours", mock.Anything)
+	})
+}
+
+func TestUpdateMyHours(t *testing.T) {
+	volunteer := &domain.Volunteer{ID: 3, UserID: 11, DepartmentID: 1}
+
+	t.Run("someone else's hours", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindHoursByID", 8).Return(&domain.HoursEntry{ID: 8, VolunteerID: 5, Status: domain.HoursPending}, nil)
+
+		_, err := usecase.UpdateMyHours(11, 8, dto.LogHoursDTO{Date: "2024-03-01", Minutes: 60})
+		assert.ErrorIs(t, err, domain.ErrHoursNotFound)
+	})
+
+	t.Run("approved", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindHoursByID", 8).Return(&domain.HoursEntry{ID: 8, VolunteerID: 3, Status: domain.HoursApproved}, nil)
+
+		_, err := usecase.UpdateMyHours(11, 8, dto.LogHoursDTO{Date: "2024-03-01", Minutes: 60})
+		assert.ErrorIs(t, err, domain.ErrHoursApproved)
+		mockRepo.AssertNotCalled(t, "UpdateHours", mock.Anything)
+	})
+}
+
+func TestReviewHours(t *testing.T) {
+	kitchen := 1
+
+	t.Run("approve", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		entry := &domain.HoursEntry{ID: 8, UserID: 11, DepartmentID: 1, Status: domain.HoursPending}
+		mockRepo.On("FindHoursByID", 8).Return(entry, nil)
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+		mockRepo.On("ReviewHours", 8, domain.HoursApproved, 7, "thanks").Return(nil)
+
+		_, err := usecase.ApproveHours(7, 8, dto.ApproveHoursDTO{Note: "thanks"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("own hours", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindHoursByID", 8).Return(&domain.HoursEntry{ID: 8, UserID: 7, DepartmentID: 1}, nil)
+
+		_, err := usecase.DisputeHours(7, 8, dto.DisputeHoursDTO{Reason: "wrong"})
+		assert.ErrorIs(t, err, domain.ErrOwnHours)
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		mockRepo := new(MockHoursRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+		mockRepo.On("FindHoursByID", 8).Return(&domain.HoursEntry{ID: 8, UserID: 11, DepartmentID: 2}, nil)
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+
+		_, err := usecase.DisputeHours(7, 8, dto.DisputeHoursDTO{Reason: "wrong"})
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "ReviewHours", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHoursTotals(t *testing.T) {
+	kitchen := 1
+	mockRepo := new(MockHoursRepository)
+	departments := new(MockDepartmentScopeRepository)
+	usecase := NewHoursUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments))
+
+	departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+	mockRepo.On("VolunteerTotals", dto.HoursTotalsQuery{DepartmentID: &kitchen}).
+		Return([]*domain.VolunteerHoursTotal{{VolunteerID: 3, Name: "Anna", ApprovedMinutes: 90, Entries: 2}}, nil)
+
+	totals, err := usecase.VolunteerTotals(7, dto.HoursTotalsQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, totals[0].ApprovedHours)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	authUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+type OnboardingUsecaseInterface interface {
+	OnboardVolunteer(adminID int, input dto.OnboardVolunteerDTO) (*dto.OnboardVolunteerResponseDTO, error)
+}
+
+// OnboardingUsecase lets an admin add a volunteer directly. The volunteer
+// chooses their password by following the account setup link, which is a
+// password reset token with a longer lifetime.
+type OnboardingUsecase struct {
+	OnboardingRepo  storage.OnboardingRepositoryInterface
+	DepartmentScope departmentUsecase.DepartmentScopeInterface
+	hasher          hasher.PasswordHasher
+	setupURL        string
+	setupTTL        time.Duration
+}
+
+func NewOnboardingUsecase(onboardingRepo storage.OnboardingRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface, passwordHasher hasher.PasswordHasher, setupURL string, setupTTL time.Duration) *OnboardingUsecase {
+	return &OnboardingUsecase{
+		OnboardingRepo:  onboardingRepo,
+		DepartmentScope: departmentScope,
+		hasher:          passwordHasher,
+		setupURL:        setupURL,
+		setupTTL:        setupTTL,
+	}
+}
+
+// OnboardVolunteer adds a volunteer to a department the admin manages.
+func (u *OnboardingUsecase) OnboardVolunteer(adminID int, input dto.OnboardVolunteerDTO) (*dto.OnboardVolunteerResponseDTO, error) {
+	if err := u.DepartmentScope.CheckDepartment(adminID, input.DepartmentID); err != nil {
+		return nil, err
+	}
+	dob, err := time.Parse(time.DateOnly, input.DOB)
+	if err != nil {
+		return nil, err
+	}
+	expiry, err := time.Parse(time.DateOnly, input.Identity.ExpiryDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// nobody knows this password, the account is unusable until set up
+	placeholder, err := authUsecase.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	password, err := u.hasher.Hash(placeholder)
+	if err != nil {
+		return nil, err
+	}
+	// same format as password reset, so the link is redeemed by the reset password endpoint
+	raw, err := authUsecase.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	departmentID := input.DepartmentID
+	user := &authDomain.User{
+		DepartmentID:      &departmentID,
+		Email:             strings.TrimSpace(input.Email),
+		Password:          password,
+		Name:              input.Name,
+		Surname:           input.Surname,
+		Gender:            input.Gender,
+		Dob:               dob,
+		Mobile:            input.Mobile,
+		CountryID:         input.CountryID,
+		ResidentCountryID: input.ResidentCountryID,
+		Status:            1,
+	}
+	identity := &identityDomain.UserIdentity{
+		Number:      input.Identity.Number,
+		Type:        input.Identity.Type,
+		Status:      1,
+		ExpiryDate:  expiry,
+		PlaceIssued: input.Identity.PlaceIssued,
+	}
+	volunteer := &domain.Volunteer{
+		DepartmentID: input.DepartmentID,
+		Status:       1,
+		OnboardedBy:  &adminID,
+	}
+	token := &authDomain.PasswordResetToken{
+		TokenHash: authUsecase.HashToken(raw),
+		ExpiresAt: time.Now().Add(u.setupTTL),
+	}
+	msg, err := mailer.Render(user.Email, mailer.TemplateAccountSetup, mailer.LinkData{
+		Name:      user.Name,
+		Link:      fmt.Sprintf("%s?token=%s", u.setupURL, url.QueryEscape(raw)),
+		ExpiresIn: u.setupTTL.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.OnboardingRepo.OnboardVolunteer(user, identity, volunteer, token, msg); err != nil {
+		return nil, err
+	}
+	return &dto.OnboardVolunteerResponseDTO{
+		ID:          volunteer.ID,
+		UserID:      user.ID,
+		IdentityID:  identity.ID,
+		Email:       user.Email,
+		OnboardedBy: adminID,
+	}, nil
+}
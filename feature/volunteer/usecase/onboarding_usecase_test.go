@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	authDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOnboardingRepository struct {
+	mock.Mock
+}
+
+func (m *MockOnboardingRepository) OnboardVolunteer(user *authDomain.User, identity *identityDomain.UserIdentity, volunteer *domain.Volunteer, setupToken *authDomain.PasswordResetToken, setupEmail mailer.Message) error {
+	args := m.Called(user, identity, volunteer, setupToken, setupEmail)
+	return args.Error(0)
+}
+
+func TestOnboardVolunteer(t *testing.T) {
+	passwordHasher, err := hasher.New(hasher.AlgorithmBcrypt)
+	assert.NoError(t, err)
+	input := dto.OnboardVolunteerDTO{
+		Email:             "anna@example.com",
+		Name:              "Anna",
+		Surname:           "Smith",
+		Gender:            "female",
+		DOB:               "1990-04-01",
+		Mobile:            "123",
+		CountryID:         1,
+		ResidentCountryID: 1,
+		DepartmentID:      2,
+		Identity: dto.OnboardIdentityDTO{
+			Number:      "X1",
+			Type:        "passport",
+			ExpiryDate:  "2030-01-01",
+			PlaceIssued: "Hanoi",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockOnboardingRepository)
+		departments := new(MockDepartmentScopeRepository)
+		departments.On("FindUserDepartment", 7).Return(&input.DepartmentID, nil)
+		usecase := NewOnboardingUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), passwordHasher, "https://app.example.com/reset-password", 72*time.Hour)
+
+		mockRepo.On("OnboardVolunteer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				user := args.Get(0).(*authDomain.User)
+				assert.Equal(t, "anna@example.com", user.Email)
+				assert.Equal(t, 1990, user.Dob.Year())
+				assert.NotEmpty(t, user.Password)
+				assert.Equal(t, 2, *user.DepartmentID)
+
+				volunteer := args.Get(2).(*domain.Volunteer)
+				assert.Equal(t, 7, *volunteer.OnboardedBy)
+
+				token := args.Get(3).(*authDomain.PasswordResetToken)
+				assert.Len(t, token.TokenHash, 64)
+				assert.WithinDuration(t, time.Now().Add(72*time.Hour), token.ExpiresAt, time.Minute)
+
+				msg := args.Get(4).(mailer.Message)
+				assert.Equal(t, "anna@example.com", msg.To)
+				assert.Contains(t, msg.Body, "https://app.example.com/reset-password?token=")
+
+				user.ID = 11
+				volunteer.ID = 5
+			}).Return(nil)
+
+		result, err := usecase.OnboardVolunteer(7, input)
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.OnboardVolunteerResponseDTO{ID: 5, UserID: 11, Email: "anna@example.com", OnboardedBy: 7}, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		mockRepo := new(MockOnboardingRepository)
+		departments := new(MockDepartmentScopeRepository)
+		departments.On("FindUserDepartment", 7).Return(&input.DepartmentID, nil)
+		usecase := NewOnboardingUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), passwordHasher, "https://app.example.com/reset-password", 72*time.Hour)
+		mockRepo.On("OnboardVolunteer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrEmailTaken)
+
+		result, err := usecase.OnboardVolunteer(7, input)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, domain.ErrEmailTaken))
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		mockRepo := new(MockOnboardingRepository)
+		departments := new(MockDepartmentScopeRepository)
+		other := 3
+		departments.On("FindUserDepartment", 7).Return(&other, nil)
+		usecase := NewOnboardingUsecase(mockRepo, departmentUsecase.NewDepartmentScope(departments), passwordHasher, "https://app.example.com/reset-password", 72*time.Hour)
+
+		result, err := usecase.OnboardVolunteer(7, input)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "OnboardVolunteer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
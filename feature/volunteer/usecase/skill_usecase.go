@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+// Points a volunteer gets when matched to an activity, per required skill,
+// for their availability over the activity and for belonging to its
+// department.
+const (
+	verifiedSkillScore  = 2
+	declaredSkillScore  = 1
+	availableScore      = 3
+	partialScore        = 1
+	sameDepartmentScore = 2
+)
+
+const defaultMatchLimit = 20
+
+type SkillUsecaseInterface interface {
+	CreateSkill(input dto.SkillDTO) (*dto.SkillResponseDTO, error)
+	UpdateSkill(id int, input dto.SkillDTO) (*dto.SkillResponseDTO, error)
+	DeleteSkill(id int) error
+	FindSkillByID(id int) (*dto.SkillResponseDTO, error)
+	ListSkills(query dto.ListSkillQuery) ([]dto.SkillResponseDTO, error)
+	ListMySkills(userID int) ([]dto.VolunteerSkillDTO, error)
+	DeclareMySkill(userID int, skillID int, input dto.DeclareSkillDTO) (*dto.VolunteerSkillDTO, error)
+	RemoveMySkill(userID int, skillID int) error
+	ListVolunteerSkills(adminID int, volunteerID int) ([]dto.VolunteerSkillDTO, error)
+	VerifySkill(adminID int, volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error)
+	UnverifySkill(adminID int, volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error)
+	MatchVolunteers(adminID int, activityID int, query dto.MatchQuery) ([]dto.VolunteerMatchDTO, error)
+}
+
+// SkillUsecase manages the skill catalog, the skills volunteers declare and
+// their verification, and ranks volunteers for activities. Coordinators who
+// belong to a department only verify its volunteers and match its
+// activities. The weekly slots of volunteers are read in location when they
+// are compared with the time of an activity.
+type SkillUsecase struct {
+	SkillRepo        storage.SkillRepositoryInterface
+	AvailabilityRepo storage.AvailabilityRepositoryInterface
+	DepartmentScope  departmentUsecase.DepartmentScopeInterface
+	location         *time.Location
+}
+
+func NewSkillUsecase(skillRepo storage.SkillRepositoryInterface, availabilityRepo storage.AvailabilityRepositoryInterface, departmentScope departmentUsecase.DepartmentScopeInterface, location *time.Location) *SkillUsecase {
+	return &SkillUsecase{SkillRepo: skillRepo, AvailabilityRepo: availabilityRepo, DepartmentScope: departmentScope, location: location}
+}
+
+func (u *SkillUsecase) CreateSkill(input dto.SkillDTO) (*dto.SkillResponseDTO, error) {
+	skill := &domain.Skill{Name: input.Name, Category: input.Category, Description: input.Description}
+	if err := u.SkillRepo.CreateSkill(skill); err != nil {
+		return nil, err
+	}
+	response := toSkill(skill)
+	return &response, nil
+}
+
+func (u *SkillUsecase) UpdateSkill(id int, input dto.SkillDTO) (*dto.SkillResponseDTO, error) {
+	skill, err := u.SkillRepo.FindSkillByID(id)
+	if err != nil {
+		return nil, err
+	}
+	skill.Name = input.Name
+	skill.Category = input.Category
+	skill.Description = input.Description
+	if err := u.SkillRepo.UpdateSkill(skill); err != nil {
+		return nil, err
+	}
+	response := toSkill(skill)
+	return &response, nil
+}
+
+func (u *SkillUsecase) DeleteSkill(id int) error {
+	return u.SkillRepo.DeleteSkill(id)
+}
+
+func (u *SkillUsecase) FindSkillByID(id int) (*dto.SkillResponseDTO, error) {
+	skill, err := u.SkillRepo.FindSkillByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := toSkill(skill)
+	return &response, nil
+}
+
+func (u *SkillUsecase) ListSkills(query dto.ListSkillQuery) ([]dto.SkillResponseDTO, error) {
+	skills, err := u.SkillRepo.ListSkills(query.Category)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.SkillResponseDTO, 0, len(skills))
+	for _, skill := range skills {
+		response = append(response, toSkill(skill))
+	}
+	return response, nil
+}
+
+func (u *SkillUsecase) ListMySkills(userID int) ([]dto.VolunteerSkillDTO, error) {
+	volunteer, err := u.SkillRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.listSkills(volunteer.ID)
+}
+
+// DeclareMySkill declares a skill of the volunteer or changes its note. A
+// changed note drops the verification, the coordinator has to verify it again.
+func (u *SkillUsecase) DeclareMySkill(userID int, skillID int, input dto.DeclareSkillDTO) (*dto.VolunteerSkillDTO, error) {
+	volunteer, err := u.SkillRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.SkillRepo.FindSkillByID(skillID); err != nil {
+		return nil, err
+	}
+	skill, err := u.SkillRepo.FindVolunteerSkill(volunteer.ID, skillID)
+	switch {
+	case errors.Is(err, domain.ErrVolunteerSkillNotFound):
+		skill = &domain.VolunteerSkill{VolunteerID: volunteer.ID, SkillID: skillID}
+	case err != nil:
+		return nil, err
+	case skill.Note == input.Note:
+		response := toVolunteerSkill(skill)
+		return &response, nil
+	}
+	skill.Note = input.Note
+	skill.VerifiedBy = nil
+	skill.VerifiedAt = nil
+	if err := u.SkillRepo.SaveVolunteerSkill(skill); err != nil {
+		return nil, err
+	}
+	return u.findSkill(volunteer.ID, skillID)
+}
+
+func (u *SkillUsecase) RemoveMySkill(userID int, skillID int) error {
+	volunteer, err := u.SkillRepo.FindVolunteerByUserID(userID)
+	if err != nil {
+		return err
+	}
+	return u.SkillRepo.DeleteVolunteerSkill(volunteer.ID, skillID)
+}
+
+func (u *SkillUsecase) ListVolunteerSkills(adminID int, volunteerID int) ([]dto.VolunteerSkillDTO, error) {
+	volunteer, err := u.SkillRepo.FindVolunteerByID(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, volunteer.DepartmentID); err != nil {
+		return nil, err
+	}
+	return u.listSkills(volunteer.ID)
+}
+
+// VerifySkill marks a skill the volunteer declared as verified by the admin.
+// Nobody verifies their own skills.
+func (u *SkillUsecase) VerifySkill(adminID int, volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error) {
+	return u.verify(adminID, volunteerID, skillID, &adminID)
+}
+
+func (u *SkillUsecase) UnverifySkill(adminID int, volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error) {
+	return u.verify(adminID, volunteerID, skillID, nil)
+}
+
+// MatchVolunteers ranks the active volunteers who did not sign up for the
+// activity yet. Each required skill they declared scores, more when it was
+// verified, as does being available for the activity and belonging to its
+// department. The best matches come first, up to the limit.
+func (u *SkillUsecase) MatchVolunteers(adminID int, activityID int, query dto.MatchQuery) ([]dto.VolunteerMatchDTO, error) {
+	activity, err := u.SkillRepo.FindActivity(activityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, activity.DepartmentID); err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultMatchLimit
+	}
+
+	volunteers, err := u.AvailabilityRepo.ListActiveVolunteers(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	signedUp, err := u.SkillRepo.ListSignedUpVolunteerIDs(activityID)
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[int]bool, len(signedUp))
+	for _, id := range signedUp {
+		excluded[id] = true
+	}
+	candidates := make([]*domain.VolunteerListing, 0, len(volunteers))
+	ids := make([]int, 0, len(volunteers))
+	for _, volunteer := range volunteers {
+		if !excluded[volunteer.ID] {
+			candidates = append(candidates, volunteer)
+			ids = append(ids, volunteer.ID)
+		}
+	}
+	matches := make([]dto.VolunteerMatchDTO, 0)
+	if len(candidates) == 0 {
+		return matches, nil
+	}
+
+	skills, err := u.SkillRepo.ListVolunteerSkills(ids)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := u.AvailabilityRepo.ListSlots(ids)
+	if err != nil {
+		return nil, err
+	}
+	startDay := dayIn(activity.StartsAt, u.location)
+	endDay := dayIn(activity.EndsAt, u.location)
+	exceptions, err := u.AvailabilityRepo.ListExceptions(ids, startDay, &endDay)
+	if err != nil {
+		return nil, err
+	}
+	skillsOf := make(map[int]map[int]*domain.VolunteerSkill)
+	for _, skill := range skills {
+		if skillsOf[skill.VolunteerID] == nil {
+			skillsOf[skill.VolunteerID] = make(map[int]*domain.VolunteerSkill)
+		}
+		skillsOf[skill.VolunteerID][skill.SkillID] = skill
+	}
+	slotsOf := make(map[int][]*domain.AvailabilitySlot)
+	for _, slot := range slots {
+		slotsOf[slot.VolunteerID] = append(slotsOf[slot.VolunteerID], slot)
+	}
+	exceptionsOf := make(map[int][]*domain.AvailabilityException)
+	for _, exception := range exceptions {
+		exceptionsOf[exception.VolunteerID] = append(exceptionsOf[exception.VolunteerID], exception)
+	}
+
+	for _, volunteer := range candidates {
+		match := dto.VolunteerMatchDTO{
+			VolunteerID:     volunteer.ID,
+			UserID:          volunteer.UserID,
+			Name:            volunteer.Name,
+			Surname:         volunteer.Surname,
+			DepartmentID:    volunteer.DepartmentID,
+			SameDepartment:  volunteer.DepartmentID == activity.DepartmentID,
+			MatchedSkills:   make([]dto.MatchedSkillDTO, 0),
+			MissingSkillIDs: make([]int, 0),
+			Availability:    availabilityDuring(slotsOf[volunteer.ID], exceptionsOf[volunteer.ID], activity.StartsAt, activity.EndsAt, u.location),
+		}
+		for _, skillID := range activity.SkillIDs {
+			skill, ok := skillsOf[volunteer.ID][skillID]
+			if !ok || (query.VerifiedOnly && skill.VerifiedBy == nil) {
+				match.MissingSkillIDs = append(match.MissingSkillIDs, skillID)
+				continue
+			}
+			match.MatchedSkills = append(match.MatchedSkills, dto.MatchedSkillDTO{
+				SkillID:  skillID,
+				Name:     skill.SkillName,
+				Verified: skill.VerifiedBy != nil,
+			})
+			if skill.VerifiedBy != nil {
+				match.Score += verifiedSkillScore
+			} else {
+				match.Score += declaredSkillScore
+			}
+		}
+		if query.AllSkills && len(match.MissingSkillIDs) > 0 {
+			continue
+		}
+		switch match.Availability {
+		case domain.MatchAvailable:
+			match.Score += availableScore
+		case domain.MatchPartial:
+			match.Score += partialScore
+		}
+		if match.SameDepartment {
+			match.Score += sameDepartmentScore
+		}
+		matches = append(matches, match)
+	}
+	// candidates come by name, which breaks the ties
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return len(matches[i].MatchedSkills) > len(matches[j].MatchedSkills)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (u *SkillUsecase) verify(adminID int, volunteerID int, skillID int, verifiedBy *int) (*dto.VolunteerSkillDTO, error) {
+	volunteer, err := u.SkillRepo.FindVolunteerByID(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	if volunteer.UserID == adminID {
+		return nil, domain.ErrOwnSkill
+	}
+	if err := u.DepartmentScope.CheckDepartment(adminID, volunteer.DepartmentID); err != nil {
+		return nil, err
+	}
+	if err := u.SkillRepo.VerifyVolunteerSkill(volunteer.ID, skillID, verifiedBy); err != nil {
+		return nil, err
+	}
+	return u.findSkill(volunteer.ID, skillID)
+}
+
+func (u *SkillUsecase) listSkills(volunteerID int) ([]dto.VolunteerSkillDTO, error) {
+	skills, err := u.SkillRepo.ListVolunteerSkills([]int{volunteerID})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.VolunteerSkillDTO, 0, len(skills))
+	for _, skill := range skills {
+		response = append(response, toVolunteerSkill(skill))
+	}
+	return response, nil
+}
+
+func (u *SkillUsecase) findSkill(volunteerID int, skillID int) (*dto.VolunteerSkillDTO, error) {
+	skill, err := u.SkillRepo.FindVolunteerSkill(volunteerID, skillID)
+	if err != nil {
+		return nil, err
+	}
+	response := toVolunteerSkill(skill)
+	return &response, nil
+}
+
+func toSkill(skill *domain.Skill) dto.SkillResponseDTO {
+	return dto.SkillResponseDTO{
+		ID:          skill.ID,
+		Name:        skill.Name,
+		Category:    skill.Category,
+		Description: skill.Description,
+	}
+}
+
+func toVolunteerSkill(skill *domain.VolunteerSkill) dto.VolunteerSkillDTO {
+	return dto.VolunteerSkillDTO{
+		SkillID:    skill.SkillID,
+		Name:       skill.SkillName,
+		Category:   skill.SkillCategory,
+		Note:       skill.Note,
+		Verified:   skill.VerifiedBy != nil,
+		VerifiedBy: skill.VerifiedBy,
+		VerifiedAt: skill.VerifiedAt,
+	}
+}
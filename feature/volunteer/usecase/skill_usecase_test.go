@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	activityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/activity/domain"
+	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSkillRepository struct {
+	mock.Mock
+}
+
+func (m *MockSkillRepository) CreateSkill(skill *domain.Skill) error {
+	args := m.Called(skill)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) UpdateSkill(skill *domain.Skill) error {
+	args := m.Called(skill)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) DeleteSkill(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) FindSkillByID(id int) (*domain.Skill, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Skill), args.Error(1)
+}
+
+func (m *MockSkillRepository) ListSkills(category string) ([]*domain.Skill, error) {
+	args := m.Called(category)
+	return args.Get(0).([]*domain.Skill), args.Error(1)
+}
+
+func (m *MockSkillRepository) FindVolunteerSkill(volunteerID int, skillID int) (*domain.VolunteerSkill, error) {
+	args := m.Called(volunteerID, skillID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VolunteerSkill), args.Error(1)
+}
+
+func (m *MockSkillRepository) ListVolunteerSkills(volunteerIDs []int) ([]*domain.VolunteerSkill, error) {
+	args := m.Called(volunteerIDs)
+	return args.Get(0).([]*domain.VolunteerSkill), args.Error(1)
+}
+
+func (m *MockSkillRepository) SaveVolunteerSkill(skill *domain.VolunteerSkill) error {
+	args := m.Called(skill)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) DeleteVolunteerSkill(volunteerID int, skillID int) error {
+	args := m.Called(volunteerID, skillID)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) VerifyVolunteerSkill(volunteerID int, skillID int, verifiedBy *int) error {
+	args := m.Called(volunteerID, skillID, verifiedBy)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Volunteer), args.Error(1)
+}
+
+func (m *MockSkillRepository) FindVolunteerByUserID(userID int) (*domain.Volunteer, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Volunteer), args.Error(1)
+}
+
+func (m *MockSkillRepository) FindActivity(id int) (*activityDomain.Activity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activityDomain.Activity), args.Error(1)
+}
+
+func (m *MockSkillRepository) ListSignedUpVolunteerIDs(activityID int) ([]int, error) {
+	args := m.Called(activityID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func TestDeclareMySkill(t *testing.T) {
+	volunteer := &domain.Volunteer{ID: 3, UserID: 11, DepartmentID: 1}
+	admin := 7
+
+	t.Run("new skill", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindSkillByID", 2).Return(&domain.Skill{ID: 2, Name: "Spanish"}, nil)
+		mockRepo.On("FindVolunteerSkill", 3, 2).Return(nil, domain.ErrVolunteerSkillNotFound).Once()
+		mockRepo.On("SaveVolunteerSkill", &domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, Note: "C1"}).Return(nil)
+		mockRepo.On("FindVolunteerSkill", 3, 2).Return(&domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, Note: "C1", SkillName: "Spanish"}, nil)
+
+		skill, err := usecase.DeclareMySkill(11, 2, dto.DeclareSkillDTO{Note: "C1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Spanish", skill.Name)
+		assert.False(t, skill.Verified)
+	})
+
+	t.Run("same note keeps the verification", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindSkillByID", 2).Return(&domain.Skill{ID: 2, Name: "Spanish"}, nil)
+		mockRepo.On("FindVolunteerSkill", 3, 2).Return(&domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, Note: "C1", VerifiedBy: &admin}, nil)
+
+		skill, err := usecase.DeclareMySkill(11, 2, dto.DeclareSkillDTO{Note: "C1"})
+		assert.NoError(t, err)
+		assert.True(t, skill.Verified)
+		mockRepo.AssertNotCalled(t, "SaveVolunteerSkill", mock.Anything)
+	})
+
+	t.Run("changed note drops the verification", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		verifiedAt := time.Now()
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindSkillByID", 2).Return(&domain.Skill{ID: 2, Name: "Spanish"}, nil)
+		mockRepo.On("FindVolunteerSkill", 3, 2).Return(&domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, Note: "B2", VerifiedBy: &admin, VerifiedAt: &verifiedAt}, nil).Once()
+		mockRepo.On("SaveVolunteerSkill", &domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, Note: "C1"}).Return(nil)
+		mockRepo.On("FindVolunteerSkill", 3, 2).Return(&domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, Note: "C1"}, nil)
+
+		skill, err := usecase.DeclareMySkill(11, 2, dto.DeclareSkillDTO{Note: "C1"})
+		assert.NoError(t, err)
+		assert.False(t, skill.Verified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown skill", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		mockRepo.On("FindVolunteerByUserID", 11).Return(volunteer, nil)
+		mockRepo.On("FindSkillByID", 9).Return(nil, domain.ErrSkillNotFound)
+
+		_, err := usecase.DeclareMySkill(11, 9, dto.DeclareSkillDTO{})
+		assert.ErrorIs(t, err, domain.ErrSkillNotFound)
+	})
+}
+
+func TestVerifySkill(t *testing.T) {
+	kitchen, garden := 1, 2
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		admin := 7
+		mockRepo.On("FindVolunteerByID", 3).Return(&domain.Volunteer{ID: 3, UserID: 11, DepartmentID: kitchen}, nil)
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+		mockRepo.On("VerifyVolunteerSkill", 3, 2, &admin).Return(nil)
+		mockRepo.On("FindVolunteerSkill", 3, 2).Return(&domain.VolunteerSkill{VolunteerID: 3, SkillID: 2, VerifiedBy: &admin}, nil)
+
+		skill, err := usecase.VerifySkill(7, 3, 2)
+		assert.NoError(t, err)
+		assert.True(t, skill.Verified)
+	})
+
+	t.Run("own skill", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		mockRepo.On("FindVolunteerByID", 3).Return(&domain.Volunteer{ID: 3, UserID: 7, DepartmentID: kitchen}, nil)
+
+		_, err := usecase.VerifySkill(7, 3, 2)
+		assert.ErrorIs(t, err, domain.ErrOwnSkill)
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		usecase := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC)
+		mockRepo.On("FindVolunteerByID", 3).Return(&domain.Volunteer{ID: 3, UserID: 11, DepartmentID: kitchen}, nil)
+		departments.On("FindUserDepartment", 7).Return(&garden, nil)
+
+		_, err := usecase.UnverifySkill(7, 3, 2)
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "VerifyVolunteerSkill", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAvailabilityDuring(t *testing.T) {
+	// 2024-03-04 is a Monday
+	slots := []*domain.AvailabilitySlot{
+		{Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+		{Weekday: 2, StartTime: "00:00", EndTime: "02:00"},
+	}
+	monday := date("2024-03-04")
+	at := func(day time.Time, hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	assert.Equal(t, domain.MatchAvailable, availabilityDuring(slots, nil, at(monday, 9), at(monday, 12), time.UTC))
+	assert.Equal(t, domain.MatchPartial, availabilityDuring(slots, nil, at(monday, 11), at(monday, 13), time.UTC))
+	assert.Equal(t, domain.MatchUnavailable, availabilityDuring(slots, nil, at(monday, 14), at(monday, 16), time.UTC))
+
+	away := []*domain.AvailabilityException{{Date: monday, StartTime: "10:00", EndTime: "11:00"}}
+	assert.Equal(t, domain.MatchPartial, availabilityDuring(slots, away, at(monday, 9), at(monday, 12), time.UTC))
+
+	// overnight, until 02:00 on Tuesday
+	free := []*domain.AvailabilityException{{Date: monday, Available: true}}
+	assert.Equal(t, domain.MatchAvailable, availabilityDuring(slots, free, at(monday, 22), at(monday, 26), time.UTC))
+	assert.Equal(t, domain.MatchPartial, availabilityDuring(slots, free, at(monday, 22), at(monday, 27), time.UTC))
+	assert.Equal(t, domain.MatchPartial, availabilityDuring(slots, nil, at(monday, 22), at(monday, 26), time.UTC))
+}
+
+func TestAvailabilityDuringInLocation(t *testing.T) {
+	// slots are clock times in Ho Chi Minh City, 7 hours ahead of UTC
+	saigon := time.FixedZone("ICT", 7*60*60)
+	slots := []*domain.AvailabilitySlot{{Weekday: 1, StartTime: "09:00", EndTime: "12:00"}}
+	monday := date("2024-03-04")
+
+	// 09:00 to 12:00 on Monday in Saigon, whatever zone the activity is given in
+	start, end := time.Date(2024, 3, 4, 2, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 5, 0, 0, 0, time.UTC)
+	assert.Equal(t, domain.MatchAvailable, availabilityDuring(slots, nil, start, end, saigon))
+	newYork := time.FixedZone("EST", -5*60*60)
+	assert.Equal(t, domain.MatchAvailable, availabilityDuring(slots, nil, start.In(newYork), end.In(newYork), saigon))
+	assert.Equal(t, domain.MatchUnavailable, availabilityDuring(slots, nil, start, end, time.UTC))
+
+	// Sunday 20:00 UTC is already Monday 03:00 in Saigon
+	away := []*domain.AvailabilityException{{Date: monday, Available: false}}
+	sunday := time.Date(2024, 3, 3, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, dayIn(sunday, saigon))
+	assert.Equal(t, domain.MatchUnavailable, availabilityDuring(slots, away, start, end, saigon))
+}
+
+func TestMatchVolunteers(t *testing.T) {
+	kitchen := 1
+	admin := 7
+	// 2030-03-04 is a Monday
+	activity := &activityDomain.Activity{
+		ID:           5,
+		DepartmentID: kitchen,
+		StartsAt:     time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC),
+		EndsAt:       time.Date(2030, 3, 4, 12, 0, 0, 0, time.UTC),
+		SkillIDs:     []int{1, 2},
+	}
+	volunteers := []*domain.VolunteerListing{
+		{ID: 1, UserID: 11, Name: "Anna", DepartmentID: 1},
+		{ID: 2, UserID: 12, Name: "Bob", DepartmentID: 2},
+		{ID: 3, UserID: 13, Name: "Carl", DepartmentID: 1},
+		{ID: 4, UserID: 14, Name: "Dan", DepartmentID: 1},
+	}
+	skills := []*domain.VolunteerSkill{
+		{VolunteerID: 1, SkillID: 1, SkillName: "First aid", VerifiedBy: &admin},
+		{VolunteerID: 2, SkillID: 1, SkillName: "First aid"},
+		{VolunteerID: 2, SkillID: 2, SkillName: "Spanish"},
+	}
+	slots := []*domain.AvailabilitySlot{
+		{VolunteerID: 1, Weekday: 1, StartTime: "08:00", EndTime: "13:00"},
+		{VolunteerID: 4, Weekday: 1, StartTime: "11:00", EndTime: "13:00"},
+	}
+
+	setup := func() *SkillUsecase {
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		availabilityRepo := new(MockAvailabilityRepository)
+		mockRepo.On("FindActivity", 5).Return(activity, nil)
+		departments.On("FindUserDepartment", 7).Return(&kitchen, nil)
+		mockRepo.On("ListSignedUpVolunteerIDs", 5).Return([]int{3}, nil)
+		mockRepo.On("ListVolunteerSkills", []int{1, 2, 4}).Return(skills, nil)
+		availabilityRepo.On("ListActiveVolunteers", (*int)(nil), (*int)(nil)).Return(volunteers, nil)
+		availabilityRepo.On("ListSlots", []int{1, 2, 4}).Return(slots, nil)
+		availabilityRepo.On("ListExceptions", []int{1, 2, 4}, date("2030-03-04"), mock.Anything).Return([]*domain.AvailabilityException{}, nil)
+		return NewSkillUsecase(mockRepo, availabilityRepo, departmentUsecase.NewDepartmentScope(departments), time.UTC)
+	}
+
+	t.Run("ranking", func(t *testing.T) {
+		matches, err := setup().MatchVolunteers(7, 5, dto.MatchQuery{})
+		assert.NoError(t, err)
+		if assert.Len(t, matches, 3) {
+			// verified first aid, available and same department
+			assert.Equal(t, "Anna", matches[0].Name)
+			assert.Equal(t, 7, matches[0].Score)
+			assert.Equal(t, []int{2}, matches[0].MissingSkillIDs)
+			// available for part of it and same department
+			assert.Equal(t, "Dan", matches[1].Name)
+			assert.Equal(t, domain.MatchPartial, matches[1].Availability)
+			assert.Equal(t, 3, matches[1].Score)
+			// both skills unverified, another department
+			assert.Equal(t, "Bob", matches[2].Name)
+			assert.Equal(t, 2, matches[2].Score)
+			assert.False(t, matches[2].SameDepartment)
+		}
+	})
+
+	t.Run("all skills", func(t *testing.T) {
+		matches, err := setup().MatchVolunteers(7, 5, dto.MatchQuery{AllSkills: true})
+		assert.NoError(t, err)
+		if assert.Len(t, matches, 1) {
+			assert.Equal(t, "Bob", matches[0].Name)
+		}
+	})
+
+	t.Run("verified only", func(t *testing.T) {
+		matches, err := setup().MatchVolunteers(7, 5, dto.MatchQuery{VerifiedOnly: true, Limit: 2})
+		assert.NoError(t, err)
+		if assert.Len(t, matches, 2) {
+			assert.Equal(t, "Anna", matches[0].Name)
+			assert.Equal(t, "Dan", matches[1].Name)
+		}
+	})
+
+	t.Run("outside department", func(t *testing.T) {
+		garden := 2
+		mockRepo := new(MockSkillRepository)
+		departments := new(MockDepartmentScopeRepository)
+		mockRepo.On("FindActivity", 5).Return(activity, nil)
+		departments.On("FindUserDepartment", 7).Return(&garden, nil)
+
+		_, err := NewSkillUsecase(mockRepo, new(MockAvailabilityRepository), departmentUsecase.NewDepartmentScope(departments), time.UTC).MatchVolunteers(7, 5, dto.MatchQuery{})
+		assert.ErrorIs(t, err, domain.ErrOutsideDepartment)
+	})
+}
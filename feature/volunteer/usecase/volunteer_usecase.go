@@ -1,34 +1,38 @@
 package usecase
 
 import (
+	"sort"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
 )
 
 type VolunteerUsecaseInterface interface {
-	CreateVolunteer(input dto.VolunteerCreateDTO) error
 	UpdateVolunteer(id int, input dto.VolunteerUpdateDTO) error
 	DeleteVolunteer(id int) error
+	ListDeletedVolunteers() ([]*dto.VolunteerResponseDTO, error)
+	RestoreVolunteer(id int) error
 	FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error)
+	ListVolunteers(query dto.ListVolunteerQuery) (*dto.ListVolunteerDTO, error)
+	AssignPosition(id int, adminID int, input dto.AssignPositionDTO) (*dto.PositionAssignmentDTO, error)
+	EndPosition(id int) error
+	ListPositionHistory(id int) ([]dto.PositionAssignmentDTO, error)
+	DeactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error)
+	ReactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error)
+	ListHistory(id int) ([]dto.VolunteerHistoryEntryDTO, error)
 }
 
 type VolunteerUsecase struct {
-	VolunteerRepo storage.VolunteerRepositoryInterface
+	VolunteerRepo      storage.VolunteerRepositoryInterface
+	deactivationPolicy string
 }
 
-func NewVolunteerUsecase(volunteerRepo storage.VolunteerRepositoryInterface) *VolunteerUsecase {
-	return &VolunteerUsecase{VolunteerRepo: volunteerRepo}
+func NewVolunteerUsecase(volunteerRepo storage.VolunteerRepositoryInterface, deactivationPolicy string) *VolunteerUsecase {
+	return &VolunteerUsecase{VolunteerRepo: volunteerRepo, deactivationPolicy: deactivationPolicy}
 }
 
-func (u *VolunteerUsecase) CreateVolunteer(input dto.VolunteerCreateDTO) error {
-	volunteer := &domain.Volunteer{
-		UserID:       input.UserID,
-		DepartmentID: input.DepartmentID,
-		Status:       input.Status,
-	}
-	return u.VolunteerRepo.CreateVolunteer(volunteer)
-}
+const defaultVolunteerPageSize = 20
 
 func (u *VolunteerUsecase) UpdateVolunteer(id int, input dto.VolunteerUpdateDTO) error {
 	volunteer, err := u.VolunteerRepo.FindVolunteerByID(id)
@@ -36,7 +40,6 @@ func (u *VolunteerUsecase) UpdateVolunteer(id int, input dto.VolunteerUpdateDTO)
 		return err
 	}
 	volunteer.DepartmentID = input.DepartmentID
-	volunteer.Status = input.Status
 
 	return u.VolunteerRepo.UpdateVolunteer(volunteer)
 }
@@ -50,11 +53,202 @@ func (u *VolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO,
 	if err != nil {
 		return nil, err
 	}
+	return toVolunteerResponse(volunteer), nil
+}
+
+// ListDeletedVolunteers returns the volunteers in the trash.
+func (u *VolunteerUsecase) ListDeletedVolunteers() ([]*dto.VolunteerResponseDTO, error) {
+	volunteers, err := u.VolunteerRepo.ListDeletedVolunteers()
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*dto.VolunteerResponseDTO, 0, len(volunteers))
+	for _, volunteer := range volunteers {
+		response = append(response, toVolunteerResponse(volunteer))
+	}
+	return response, nil
+}
+
+func (u *VolunteerUsecase) RestoreVolunteer(id int) error {
+	return u.VolunteerRepo.RestoreVolunteer(id)
+}
+
+func toVolunteerResponse(volunteer *domain.Volunteer) *dto.VolunteerResponseDTO {
 	response := &dto.VolunteerResponseDTO{
 		ID:           volunteer.ID,
 		UserID:       volunteer.UserID,
 		DepartmentID: volunteer.DepartmentID,
 		Status:       volunteer.Status,
+		OnboardedBy:  volunteer.OnboardedBy,
+	}
+	if volunteer.DeletedAt.Valid {
+		response.DeletedAt = &volunteer.DeletedAt.Time
+	}
+	return response
+}
+
+// ListVolunteers returns a page of the volunteer directory.
+func (u *VolunteerUsecase) ListVolunteers(query dto.ListVolunteerQuery) (*dto.ListVolunteerDTO, error) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultVolunteerPageSize
+	}
+	volunteers, total, err := u.VolunteerRepo.ListVolunteers(query)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.ListVolunteerDTO{
+		Volunteers: make([]dto.VolunteerListItemDTO, 0, len(volunteers)),
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Total:      total,
+		TotalPages: int((total + int64(query.PageSize) - 1) / int64(query.PageSize)),
+	}
+	for _, volunteer := range volunteers {
+		response.Volunteers = append(response.Volunteers, dto.VolunteerListItemDTO{
+			ID:             volunteer.ID,
+			UserID:         volunteer.UserID,
+			Email:          volunteer.Email,
+			Name:           volunteer.Name,
+			Surname:        volunteer.Surname,
+			Gender:         volunteer.Gender,
+			Mobile:         volunteer.Mobile,
+			RoleID:         volunteer.RoleID,
+			RoleName:       volunteer.RoleName,
+			DepartmentID:   volunteer.DepartmentID,
+			DepartmentName: volunteer.DepartmentName,
+			PositionID:     volunteer.PositionID,
+			PositionCode:   volunteer.PositionCode,
+			PositionName:   volunteer.PositionName,
+			Status:         volunteer.Status,
+			CreatedAt:      volunteer.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+// AssignPosition moves the volunteer to a position of the catalog.
+func (u *VolunteerUsecase) AssignPosition(id int, adminID int, input dto.AssignPositionDTO) (*dto.PositionAssignmentDTO, error) {
+	assignment, err := u.VolunteerRepo.AssignPosition(id, input.PositionID, adminID, input.Notes)
+	if err != nil {
+		return nil, err
+	}
+	response := toPositionAssignment(assignment)
+	return &response, nil
+}
+
+func (u *VolunteerUsecase) EndPosition(id int) error {
+	return u.VolunteerRepo.EndPosition(id)
+}
+
+// ListPositionHistory returns the positions the volunteer held, the current
+// one first.
+func (u *VolunteerUsecase) ListPositionHistory(id int) ([]dto.PositionAssignmentDTO, error) {
+	assignments, err := u.VolunteerRepo.ListPositionHistory(id)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.PositionAssignmentDTO, 0, len(assignments))
+	for _, assignment := range assignments {
+		response = append(response, toPositionAssignment(assignment))
 	}
 	return response, nil
 }
+
+func toPositionAssignment(assignment *domain.PositionAssignment) dto.PositionAssignmentDTO {
+	return dto.PositionAssignmentDTO{
+		ID:           assignment.ID,
+		VolunteerID:  assignment.VolunteerID,
+		PositionID:   assignment.PositionID,
+		PositionCode: assignment.PositionCode,
+		PositionName: assignment.PositionName,
+		AssignedBy:   assignment.AssignedBy,
+		Notes:        assignment.Notes,
+		StartedAt:    assignment.StartedAt,
+		EndedAt:      assignment.EndedAt,
+	}
+}
+
+// DeactivateVolunteer sets the volunteer inactive for the given reason, the
+// deactivation policy decides whether they can still log in.
+func (u *VolunteerUsecase) DeactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error) {
+	change, err := u.VolunteerRepo.DeactivateVolunteer(id, adminID, input.Reason, u.deactivationPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return toStatusChange(change), nil
+}
+
+func (u *VolunteerUsecase) ReactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) (*dto.VolunteerStatusChangeResponseDTO, error) {
+	change, err := u.VolunteerRepo.ReactivateVolunteer(id, adminID, input.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return toStatusChange(change), nil
+}
+
+// ListHistory returns the position and status changes of the volunteer, the
+// latest first.
+func (u *VolunteerUsecase) ListHistory(id int) ([]dto.VolunteerHistoryEntryDTO, error) {
+	assignments, err := u.VolunteerRepo.ListPositionHistory(id)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := u.VolunteerRepo.ListStatusChanges(id)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]dto.VolunteerHistoryEntryDTO, 0, 2*len(assignments)+len(changes))
+	for _, assignment := range assignments {
+		assignedBy := assignment.AssignedBy
+		entry := dto.VolunteerHistoryEntryDTO{
+			Event:        dto.HistoryPositionAssigned,
+			At:           assignment.StartedAt,
+			By:           &assignedBy,
+			Reason:       assignment.Notes,
+			PositionID:   assignment.PositionID,
+			PositionCode: assignment.PositionCode,
+			PositionName: assignment.PositionName,
+		}
+		history = append(history, entry)
+		if assignment.EndedAt != nil {
+			history = append(history, dto.VolunteerHistoryEntryDTO{
+				Event:        dto.HistoryPositionEnded,
+				At:           *assignment.EndedAt,
+				PositionID:   assignment.PositionID,
+				PositionCode: assignment.PositionCode,
+				PositionName: assignment.PositionName,
+			})
+		}
+	}
+	for _, change := range changes {
+		event := dto.HistoryReactivated
+		if change.Status == domain.VolunteerStatusInactive {
+			event = dto.HistoryDeactivated
+		}
+		changedBy := change.ChangedBy
+		history = append(history, dto.VolunteerHistoryEntryDTO{
+			Event:  event,
+			At:     change.CreatedAt,
+			By:     &changedBy,
+			Reason: change.Reason,
+		})
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].At.After(history[j].At)
+	})
+	return history, nil
+}
+
+func toStatusChange(change *domain.VolunteerStatusChange) *dto.VolunteerStatusChangeResponseDTO {
+	return &dto.VolunteerStatusChangeResponseDTO{
+		ID:          change.ID,
+		VolunteerID: change.VolunteerID,
+		Status:      change.Status,
+		Reason:      change.Reason,
+		ChangedBy:   change.ChangedBy,
+		CreatedAt:   change.CreatedAt,
+	}
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
@@ -15,11 +16,6 @@ type MockVolunteerRepository struct {
 	mock.Mock
 }
 
-func (m *MockVolunteerRepository) CreateVolunteer(volunteer *domain.Volunteer) error {
-	args := m.Called(volunteer)
-	return args.Error(0)
-}
-
 func (m *MockVolunteerRepository) UpdateVolunteer(volunteer *domain.Volunteer) error {
 	args := m.Called(volunteer)
 	return args.Error(0)
@@ -30,36 +26,74 @@ func (m *MockVolunteerRepository) DeleteVolunteer(id int) error {
 	return args.Error(0)
 }
 
-func (m *MockVolunteerRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
+func (m *MockVolunteerRepository) ListDeletedVolunteers() ([]*domain.Volunteer, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Volunteer), args.Error(1)
+}
+
+func (m *MockVolunteerRepository) RestoreVolunteer(id int) error {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Volunteer), args.Error(1)
+	return args.Error(0)
 }
 
-func TestCreateVolunteer(t *testing.T) {
-	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+func (m *MockVolunteerRepository) ListVolunteers(query dto.ListVolunteerQuery) ([]*domain.VolunteerListing, int64, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*domain.VolunteerListing), args.Get(1).(int64), args.Error(2)
+}
 
-	input := dto.VolunteerCreateDTO{
-		UserID:       1,
-		DepartmentID: 2,
-		Status:       0,
+func (m *MockVolunteerRepository) AssignPosition(volunteerID int, positionID int, assignedBy int, notes string) (*domain.PositionAssignment, error) {
+	args := m.Called(volunteerID, positionID, assignedBy, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*domain.PositionAssignment), args.Error(1)
+}
 
-	mockRepo.On("CreateVolunteer", mock.Anything).Return(nil)
+func (m *MockVolunteerRepository) EndPosition(volunteerID int) error {
+	args := m.Called(volunteerID)
+	return args.Error(0)
+}
 
-	err := usecase.CreateVolunteer(input)
+func (m *MockVolunteerRepository) ListPositionHistory(volunteerID int) ([]*domain.PositionAssignment, error) {
+	args := m.Called(volunteerID)
+	return args.Get(0).([]*domain.PositionAssignment), args.Error(1)
+}
 
-	assert.NoError(t, err)
-	mockRepo.AssertExpectations(t)
+func (m *MockVolunteerRepository) DeactivateVolunteer(volunteerID int, changedBy int, reason string, policy string) (*domain.VolunteerStatusChange, error) {
+	args := m.Called(volunteerID, changedBy, reason, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VolunteerStatusChange), args.Error(1)
+}
+
+func (m *MockVolunteerRepository) ReactivateVolunteer(volunteerID int, changedBy int, reason string) (*domain.VolunteerStatusChange, error) {
+	args := m.Called(volunteerID, changedBy, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VolunteerStatusChange), args.Error(1)
+}
+
+func (m *MockVolunteerRepository) ListStatusChanges(volunteerID int) ([]*domain.VolunteerStatusChange, error) {
+	args := m.Called(volunteerID)
+	return args.Get(0).([]*domain.VolunteerStatusChange), args.Error(1)
+}
+
+func (m *MockVolunteerRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Volunteer), args.Error(1)
 }
 
 func TestUpdateVolunteer(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationBlockLogin)
 
 	input := dto.VolunteerUpdateDTO{
 		DepartmentID: 4,
-		Status:       1,
 	}
 	volunteer := &domain.Volunteer{
 		ID:           1,
@@ -79,7 +113,7 @@ func TestUpdateVolunteer(t *testing.T) {
 
 func TestDeleteVolunteer(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationBlockLogin)
 
 	mockRepo.On("DeleteVolunteer", 1).Return(nil)
 
@@ -91,7 +125,7 @@ func TestDeleteVolunteer(t *testing.T) {
 
 func TestFindVolunteerByID(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationBlockLogin)
 
 	volunteer := &domain.Volunteer{
 		ID:           1,
@@ -116,7 +150,7 @@ func TestFindVolunteerByID(t *testing.T) {
 
 func TestFindVolunteerByID_NotFound(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationBlockLogin)
 
 	mockRepo.On("FindVolunteerByID", 1).Return(nil, errors.New("record not found"))
 
@@ -126,3 +160,59 @@ func TestFindVolunteerByID_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestListVolunteers(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationBlockLogin)
+
+	mockRepo.On("ListVolunteers", dto.ListVolunteerQuery{Page: 1, PageSize: 20}).
+		Return([]*domain.VolunteerListing{{ID: 1, UserID: 4, Name: "Anna", RoleName: "volunteer"}}, int64(21), nil)
+
+	result, err := usecase.ListVolunteers(dto.ListVolunteerQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalPages)
+	assert.Equal(t, []dto.VolunteerListItemDTO{{ID: 1, UserID: 4, Name: "Anna", RoleName: "volunteer"}}, result.Volunteers)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeactivateVolunteer(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationDemote)
+
+	mockRepo.On("DeactivateVolunteer", 1, 7, "moved abroad", domain.DeactivationDemote).
+		Return(&domain.VolunteerStatusChange{ID: 3, VolunteerID: 1, Status: domain.VolunteerStatusInactive, Reason: "moved abroad", ChangedBy: 7}, nil)
+
+	result, err := usecase.DeactivateVolunteer(1, 7, dto.VolunteerStatusChangeDTO{Reason: "moved abroad"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.VolunteerStatusChangeResponseDTO{ID: 3, VolunteerID: 1, Status: domain.VolunteerStatusInactive, Reason: "moved abroad", ChangedBy: 7}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListHistory(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	usecase := NewVolunteerUsecase(mockRepo, domain.DeactivationBlockLogin)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ended := start.Add(48 * time.Hour)
+	mockRepo.On("ListPositionHistory", 1).Return([]*domain.PositionAssignment{
+		{ID: 2, VolunteerID: 1, PositionID: 3, PositionCode: "CVL", AssignedBy: 7, StartedAt: start, EndedAt: &ended},
+	}, nil)
+	mockRepo.On("ListStatusChanges", 1).Return([]*domain.VolunteerStatusChange{
+		{ID: 5, VolunteerID: 1, Status: domain.VolunteerStatusActive, Reason: "back", ChangedBy: 8, CreatedAt: start.Add(72 * time.Hour)},
+		{ID: 4, VolunteerID: 1, Status: domain.VolunteerStatusInactive, Reason: "on leave", ChangedBy: 7, CreatedAt: start.Add(24 * time.Hour)},
+	}, nil)
+
+	history, err := usecase.ListHistory(1)
+
+	assert.NoError(t, err)
+	events := make([]string, 0, len(history))
+	for _, entry := range history {
+		events = append(events, entry.Event)
+	}
+	assert.Equal(t, []string{dto.HistoryReactivated, dto.HistoryPositionEnded, dto.HistoryDeactivated, dto.HistoryPositionAssigned}, events)
+	assert.Equal(t, "on leave", history[2].Reason)
+	assert.Equal(t, 7, *history[2].By)
+	mockRepo.AssertExpectations(t)
+}
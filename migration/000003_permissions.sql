@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS permissions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INT NOT NULL REFERENCES roles(id),
+    permission_id INT NOT NULL REFERENCES permissions(id),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+-- built-in roles, referenced by name from the code
+INSERT INTO roles (name)
+SELECT r.name FROM (VALUES ('admin'), ('applicant'), ('volunteer')) AS r(name)
+WHERE NOT EXISTS (SELECT 1 FROM roles WHERE roles.name = r.name);
+
+INSERT INTO permissions (name, description) VALUES
+    ('request:read', 'View registration and verification requests'),
+    ('request:approve', 'Approve registration and verification requests'),
+    ('request:reject', 'Reject requests and add reject notes'),
+    ('request:delete', 'Delete requests'),
+    ('role:manage', 'Manage roles and their permissions')
+ON CONFLICT (name) DO NOTHING;
+
+-- admins are granted every permission
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin'
+ON CONFLICT DO NOTHING;
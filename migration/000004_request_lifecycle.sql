@@ -0,0 +1,13 @@
+-- requests move through pending(0) -> in_review(3) -> approved(1)/rejected(2)
+-- -> resubmitted(4)/cancelled(5), see feature/user/domain/request_state.go
+ALTER TABLE requests DROP CONSTRAINT IF EXISTS requests_status_check;
+ALTER TABLE requests ADD CONSTRAINT requests_status_check CHECK (status IN (0, 1, 2, 3, 4, 5));
+
+INSERT INTO permissions (name, description) VALUES
+    ('request:review', 'Move pending requests into review')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin' AND permissions.name = 'request:review'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS request_events (
+    id SERIAL PRIMARY KEY,
+    request_id INT NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    actor_id INT DEFAULT NULL REFERENCES users(id),
+    action VARCHAR(20) NOT NULL,
+    from_status SMALLINT DEFAULT NULL,
+    to_status SMALLINT NOT NULL, -- equals from_status for notes
+    notes TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_events_request_id ON request_events (request_id, created_at);
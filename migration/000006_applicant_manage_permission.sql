@@ -0,0 +1,9 @@
+-- applicants use /me, the raw id endpoints are reserved to staff
+INSERT INTO permissions (name, description) VALUES
+    ('applicant:manage', 'Manage applicants, their requests and identities by id')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin' AND permissions.name = 'applicant:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- a resubmission links to the rejected request it replaces and keeps its
+-- reject notes for display
+ALTER TABLE requests ADD COLUMN IF NOT EXISTS previous_request_id INT DEFAULT NULL REFERENCES requests(id);
+ALTER TABLE requests ADD COLUMN IF NOT EXISTS previous_reject_notes TEXT DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_requests_previous_request_id ON requests (previous_request_id);
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_sent_at TIMESTAMPTZ DEFAULT NULL; -- throttles resending the link
+
+-- accounts created before email verification existed are considered verified
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
@@ -0,0 +1,14 @@
+-- conversation between the admins and the owner of a request, read_at is set
+-- when the other side reads the message
+CREATE TABLE IF NOT EXISTS request_messages (
+    id SERIAL PRIMARY KEY,
+    request_id INT NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    sender_id INT NOT NULL REFERENCES users(id),
+    from_admin BOOLEAN NOT NULL,
+    body TEXT NOT NULL,
+    read_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_messages_request_id ON request_messages (request_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_request_messages_unread ON request_messages (request_id, from_admin) WHERE read_at IS NULL;
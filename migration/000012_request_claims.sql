@@ -0,0 +1,14 @@
+-- a reviewer claims a request until claim_expires_at, other reviewers cannot
+-- approve or reject it meanwhile
+ALTER TABLE requests ADD COLUMN IF NOT EXISTS claimed_by INT DEFAULT NULL REFERENCES users(id);
+ALTER TABLE requests ADD COLUMN IF NOT EXISTS claim_expires_at TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_requests_claimed_by ON requests (claimed_by);
+
+-- last time each admin opened a request
+CREATE TABLE IF NOT EXISTS request_views (
+    request_id INT NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    admin_id INT NOT NULL REFERENCES users(id),
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (request_id, admin_id)
+);
@@ -0,0 +1,38 @@
+-- volunteer position catalog, positions report to their parent
+CREATE TABLE IF NOT EXISTS positions (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(10) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) DEFAULT NULL,
+    parent_id INT DEFAULT NULL REFERENCES positions(id),
+    rank INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- positions held by volunteers, the row without ended_at is the current one
+CREATE TABLE IF NOT EXISTS volunteer_positions (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    position_id INT NOT NULL REFERENCES positions(id),
+    assigned_by INT NOT NULL REFERENCES users(id),
+    notes VARCHAR(255) DEFAULT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_positions_volunteer_id ON volunteer_positions (volunteer_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_positions_position_id ON volunteer_positions (position_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_volunteer_positions_current ON volunteer_positions (volunteer_id) WHERE ended_at IS NULL;
+
+INSERT INTO positions (code, name, description, rank) VALUES
+    ('COM', 'Committee', 'Runs the volunteer programme', 1)
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO positions (code, name, description, parent_id, rank)
+SELECT 'MNVC', 'Manager of volunteer coordinators', 'Leads the volunteer coordinators', id, 2 FROM positions WHERE code = 'COM'
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO positions (code, name, description, parent_id, rank)
+SELECT 'CVL', 'Civil volunteer', 'Takes part in volunteer activities', id, 3 FROM positions WHERE code = 'MNVC'
+ON CONFLICT (code) DO NOTHING;
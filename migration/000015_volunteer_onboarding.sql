@@ -0,0 +1,3 @@
+-- admin who added the volunteer by hand, NULL for volunteers who went through
+-- verification
+ALTER TABLE volunteer_details ADD COLUMN IF NOT EXISTS onboarded_by INT DEFAULT NULL REFERENCES users(id);
@@ -0,0 +1,11 @@
+-- deactivations and reactivations of volunteers, with the admin and reason
+CREATE TABLE IF NOT EXISTS volunteer_status_changes (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    status INT NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    changed_by INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_status_changes_volunteer_id ON volunteer_status_changes (volunteer_id);
@@ -0,0 +1,50 @@
+-- activities of a department volunteers sign up for
+CREATE TABLE IF NOT EXISTS activities (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT DEFAULT NULL,
+    department_id INT NOT NULL REFERENCES departments(id),
+    location VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    capacity INT NOT NULL CHECK (capacity > 0),
+    created_by INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_activities_department_id ON activities (department_id);
+CREATE INDEX IF NOT EXISTS idx_activities_starts_at ON activities (starts_at);
+
+-- positions required to sign up, any of them will do
+CREATE TABLE IF NOT EXISTS activity_positions (
+    activity_id INT NOT NULL REFERENCES activities(id),
+    position_id INT NOT NULL REFERENCES positions(id),
+    PRIMARY KEY (activity_id, position_id)
+);
+
+-- confirmed, waitlisted or cancelled sign-ups, one that is not cancelled per
+-- volunteer and activity
+CREATE TABLE IF NOT EXISTS activity_signups (
+    id SERIAL PRIMARY KEY,
+    activity_id INT NOT NULL REFERENCES activities(id),
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_signups_activity_id ON activity_signups (activity_id);
+CREATE INDEX IF NOT EXISTS idx_activity_signups_volunteer_id ON activity_signups (volunteer_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_activity_signups_active ON activity_signups (activity_id, volunteer_id) WHERE status <> 'cancelled';
+
+INSERT INTO permissions (name, description) VALUES
+    ('activity:manage', 'Manage the activities of their department and the sign-ups')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin' AND permissions.name = 'activity:manage'
+ON CONFLICT DO NOTHING;
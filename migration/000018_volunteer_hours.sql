@@ -0,0 +1,31 @@
+-- hours volunteers spent on an activity or for a department, reviewed by a
+-- coordinator
+CREATE TABLE IF NOT EXISTS volunteer_hours (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    date DATE NOT NULL,
+    activity_id INT DEFAULT NULL REFERENCES activities(id),
+    department_id INT NOT NULL REFERENCES departments(id),
+    minutes INT NOT NULL CHECK (minutes > 0 AND minutes <= 1440),
+    notes VARCHAR(255) DEFAULT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by INT DEFAULT NULL REFERENCES users(id),
+    reviewed_at TIMESTAMPTZ DEFAULT NULL,
+    review_note VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_hours_volunteer_id ON volunteer_hours (volunteer_id, date);
+CREATE INDEX IF NOT EXISTS idx_volunteer_hours_department_id ON volunteer_hours (department_id, date);
+CREATE INDEX IF NOT EXISTS idx_volunteer_hours_activity_id ON volunteer_hours (activity_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_hours_status ON volunteer_hours (status);
+
+INSERT INTO permissions (name, description) VALUES
+    ('hours:review', 'Approve or dispute the hours logged by volunteers and see the totals')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin' AND permissions.name = 'hours:review'
+ON CONFLICT DO NOTHING;
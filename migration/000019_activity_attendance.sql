@@ -0,0 +1,24 @@
+-- when volunteers checked in and out of an activity, once per activity
+CREATE TABLE IF NOT EXISTS activity_attendances (
+    id SERIAL PRIMARY KEY,
+    activity_id INT NOT NULL REFERENCES activities(id),
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    checked_in_at TIMESTAMPTZ NOT NULL,
+    checked_out_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (checked_out_at IS NULL OR checked_out_at >= checked_in_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_activity_attendances_volunteer ON activity_attendances (activity_id, volunteer_id);
+CREATE INDEX IF NOT EXISTS idx_activity_attendances_volunteer_id ON activity_attendances (volunteer_id);
+
+-- attendance tokens a volunteer already used, so they cannot be replayed
+CREATE TABLE IF NOT EXISTS attendance_token_uses (
+    token_id VARCHAR(64) NOT NULL,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    used_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (token_id, volunteer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_token_uses_volunteer_id ON attendance_token_uses (volunteer_id);
@@ -0,0 +1,34 @@
+-- weekly periods a volunteer is usually available, weekday 0 being Sunday
+CREATE TABLE IF NOT EXISTS volunteer_availability_slots (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time VARCHAR(5) NOT NULL,
+    end_time VARCHAR(5) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_availability_slots_volunteer_id ON volunteer_availability_slots (volunteer_id);
+
+-- days a volunteer is unavailable, or available, unlike their weekly slots,
+-- for the whole day when the times are empty
+CREATE TABLE IF NOT EXISTS volunteer_availability_exceptions (
+    id SERIAL PRIMARY KEY,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    date DATE NOT NULL,
+    start_time VARCHAR(5) DEFAULT NULL,
+    end_time VARCHAR(5) DEFAULT NULL,
+    available BOOLEAN NOT NULL DEFAULT FALSE,
+    note VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_availability_exceptions_volunteer_id ON volunteer_availability_exceptions (volunteer_id, date);
+
+-- hashed token authenticating the calendar feeds of a user
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INT PRIMARY KEY REFERENCES users(id),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,41 @@
+-- skill catalog, such as languages, first aid or driving licences
+CREATE TABLE IF NOT EXISTS skills (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(50) DEFAULT NULL,
+    description VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_name ON skills (LOWER(name));
+
+-- skills declared by volunteers, verified by a coordinator
+CREATE TABLE IF NOT EXISTS volunteer_skills (
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    skill_id INT NOT NULL REFERENCES skills(id),
+    note VARCHAR(255) DEFAULT NULL,
+    verified_by INT DEFAULT NULL REFERENCES users(id),
+    verified_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (volunteer_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_skills_skill_id ON volunteer_skills (skill_id);
+
+-- skills an activity needs, used to match volunteers
+CREATE TABLE IF NOT EXISTS activity_skills (
+    activity_id INT NOT NULL REFERENCES activities(id),
+    skill_id INT NOT NULL REFERENCES skills(id),
+    PRIMARY KEY (activity_id, skill_id)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('skill:manage', 'Manage the skill catalog and verify the skills of volunteers')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin' AND permissions.name = 'skill:manage'
+ON CONFLICT DO NOTHING;
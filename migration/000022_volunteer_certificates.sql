@@ -0,0 +1,21 @@
+-- certificates of service signed for the approved hours of a volunteer, the
+-- names and hours are kept as they were when the certificate was issued
+CREATE TABLE IF NOT EXISTS volunteer_certificates (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    volunteer_id INT NOT NULL REFERENCES volunteer_details(id),
+    volunteer_name VARCHAR(255) NOT NULL,
+    department_id INT NOT NULL REFERENCES departments(id),
+    department_name VARCHAR(255) NOT NULL,
+    period_from DATE NOT NULL,
+    period_to DATE NOT NULL,
+    total_minutes INT NOT NULL,
+    signed_by INT NOT NULL REFERENCES users(id),
+    signer_name VARCHAR(255) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMPTZ DEFAULT NULL,
+    revoked_by INT DEFAULT NULL REFERENCES users(id),
+    revoke_reason VARCHAR(255) DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_certificates_volunteer_id ON volunteer_certificates (volunteer_id);
@@ -0,0 +1,9 @@
+-- coordinators without a department act on every department only with this
+INSERT INTO permissions (name, description) VALUES
+    ('department:all', 'Act on every department without belonging to one')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions
+WHERE roles.name = 'admin' AND permissions.name = 'department:all'
+ON CONFLICT DO NOTHING;